@@ -3,41 +3,122 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds application configuration
+// Only fields tagged `config:"safe"` are exposed by Redacted; leave secrets and personal data untagged
 type Config struct {
-	Port        string
-	Environment string
-	LogLevel    string
-	APIBaseURL  string
+	Port        string `config:"safe"`
+	Environment string `config:"safe"`
+	LogLevel    string `config:"safe"`
+	APIBaseURL  string `config:"safe"`
+	PublicURL   string `config:"safe"` // Externally reachable origin used to build links in emails
 
 	// Database
-	DatabaseURL string
+	DatabaseURL string // Includes the database password
 
-	// Email Provider (gmail or mailgun)
-	EmailProvider string
+	// Email Provider (gmail, mailgun or smtp)
+	EmailProvider string `config:"safe"`
+
+	EmailProviderChain []string `config:"safe"` // Providers tried in order at startup and on send failure (overrides EmailProvider)
 
 	// Mailgun Email
 	MailgunAPIKey    string
-	MailgunDomain    string
-	MailgunBaseURL   string
-	MailgunFromEmail string
+	MailgunDomain    string `config:"safe"`
+	MailgunBaseURL   string `config:"safe"`
+	MailgunFromEmail string `config:"safe"`
 
 	// Gmail Email
-	GmailTokenPath string
+	GmailTokenPath string `config:"safe"`
 	GmailTokenJSON string // Token JSON as environment variable (alternative to file)
-	GmailFromEmail string
+	GmailFromEmail string `config:"safe"`
+
+	// SMTP Email
+	SMTPHost      string `config:"safe"`
+	SMTPPort      string `config:"safe"`
+	SMTPUsername  string `config:"safe"` // Empty sends without authenticating
+	SMTPPassword  string
+	SMTPFromEmail string `config:"safe"`
+
+	OTPExpiryMinutes         int `config:"safe"`
+	OTPLength                int `config:"safe"` // Digits in each OTP code, from 4 to 10
+	OTPResendCooldownSeconds int `config:"safe"` // Minimum time between OTP requests for the same email (0 disables)
+
+	EmailSendTimeoutSeconds int  `config:"safe"` // Give up on a provider call after this long (0 disables the timeout)
+	SendWelcomeEmail        bool `config:"safe"` // Send a welcome email to newly registered users
+
+	// Signups
+	BlockDisposableEmails      bool   `config:"safe"` // Reject OTP requests from new users with disposable email domains
+	DisposableEmailDomainsPath string `config:"safe"` // File of blocked domains, one per line (empty uses the built-in list)
+
+	// Profiles
+	ReservedDisplayNames     []string `config:"safe"` // Display names users may not take, compared case-insensitively
+	ReservedDisplayNameMatch string   `config:"safe"` // "exact" rejects only the names themselves, "contains" also rejects names containing them
+
+	// Moderation
+	ProfanityFilterEnabled bool     `config:"safe"` // Reject user text containing a word from ProfanityWords
+	ProfanityWords         []string `config:"safe"` // Blocked words, matched case-insensitively against whole words
+
+	// Games
+	InviteOnPlayWithoutPartner bool `config:"safe"` // Let users without a partner send a partner invite from the play request
 
-	OTPExpiryMinutes int
+	// Bulls and Cows
+	SecretAttemptLimit           int `config:"safe"` // Invalid secret attempts allowed before a cooldown (0 is unlimited)
+	SecretAttemptCooldownSeconds int `config:"safe"` // How long secret entry is blocked once the limit is reached
+	GuessArchiveThreshold        int `config:"safe"` // Guesses kept in a play row; older ones move to the guess archive (0 disables)
+
+	// Live plays
+	MaxLivePlaysPerUser int `config:"safe"` // Live plays a user may have at once across partners, bots and practice (0 is unlimited)
+
+	// Anti-cheat
+	CheatDetectionEnabled   bool `config:"safe"` // Score won plays for bot-like guessing (flags only, never rejects)
+	CheatSuspicionThreshold int  `config:"safe"` // Scores (0-100) at or above this are logged and listed for admins
+
+	// Pagination
+	PageDefaultLimit int `config:"safe"` // Page size of list endpoints when no limit is given
+	PageMaxLimit     int `config:"safe"` // Largest page size a client may request; larger limits are clamped
+
+	// Notifications
+	NotificationDedupWindowMinutes int `config:"safe"` // Collapse identical unread notifications within this window (0 disables)
+	GameRequestReminderMinutes     int `config:"safe"` // Remind the partner this long before a pending game request expires (0 disables)
 
 	// JWT
-	JWTSecret string
-	JWTExpiry string
+	JWTSecret        string
+	JWTExpiry        string `config:"safe"`
+	JWTRefreshExpiry string `config:"safe"` // Lifetime of refresh tokens, as a Go duration
+	JWTBindDevice    bool   `config:"safe"` // Bind access tokens to the device fingerprint they were issued to
+
+	// Retention
+	PlayRetentionDays int `config:"safe"` // Purge ended plays older than this many days (0 keeps them forever)
+
+	// Admin
+	AdminEmails []string // Users allowed to call admin endpoints
+
+	// Service
+	ServiceAPIKey   string   // Shared key other services use to call service-only endpoints (empty disables them)
+	TrustedNetworks []string `config:"safe"` // CIDRs whose requests may use the service key on internal routes
+
+	// CORS
+	CORSAllowedOrigins []string `config:"safe"` // Origins allowed by CORS (empty allows any)
 }
 
+// Reserved display name matching modes
+const (
+	ReservedNameMatchExact    = "exact"
+	ReservedNameMatchContains = "contains"
+)
+
+// EmailProviders are the supported values of EmailProvider and EmailProviderChain
+var EmailProviders = []string{"gmail", "mailgun", "smtp"}
+
+// defaultReservedDisplayNames are used when RESERVED_DISPLAY_NAMES is not set
+var defaultReservedDisplayNames = []string{"Admin", "Administrator", "Moderator", "Support", "System"}
+
 // Load reads configuration from environment variables
 func Load() *Config {
 	// Load .env file if it exists (ignore error if file doesn't exist)
@@ -50,11 +131,23 @@ func Load() *Config {
 		}
 	}
 
+	// Codes must fit the OTP code column
+	otpLength := getEnvInt("OTP_LENGTH", 4)
+	if otpLength < 4 || otpLength > 10 {
+		otpLength = 4
+	}
+
+	reservedDisplayNames := getEnvList("RESERVED_DISPLAY_NAMES")
+	if len(reservedDisplayNames) == 0 {
+		reservedDisplayNames = defaultReservedDisplayNames
+	}
+
 	cfg := &Config{
 		Port:             getEnv("PORT", "8080"),
 		Environment:      getEnv("ENVIRONMENT", "development"),
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
 		APIBaseURL:       getEnv("API_BASE_URL", "/api/v1"),
+		PublicURL:        getEnv("PUBLIC_URL", "http://localhost:8080"),
 		DatabaseURL:      getEnv("DATABASE_URL", ""),
 		EmailProvider:    getEnv("EMAIL_PROVIDER", "gmail"), // Default to gmail
 		MailgunAPIKey:    getEnv("MAILGUN_API_KEY", ""),
@@ -64,14 +157,115 @@ func Load() *Config {
 		GmailTokenPath:   getEnv("GMAIL_TOKEN_PATH", "config/token.json"),
 		GmailTokenJSON:   getEnv("GMAIL_TOKEN_JSON", ""), // Token JSON as env var (alternative to file)
 		GmailFromEmail:   getEnv("GMAIL_FROM_EMAIL", "me"),
+		SMTPHost:         getEnv("SMTP_HOST", ""),
+		SMTPPort:         getEnv("SMTP_PORT", "587"),
+		SMTPUsername:     getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:     getEnv("SMTP_PASSWORD", ""),
+		SMTPFromEmail:    getEnv("SMTP_FROM_EMAIL", "noreply@gamesapp.com"),
 		OTPExpiryMinutes: otpExpiryMinutes,
+		OTPLength:        otpLength,
 		JWTSecret:        getEnv("JWT_SECRET", ""),
 		JWTExpiry:        getEnv("JWT_EXPIRY", "24h"),
+		JWTRefreshExpiry: getEnv("JWT_REFRESH_EXPIRY", "720h"),
+		JWTBindDevice:    getEnv("JWT_BIND_DEVICE", "false") == "true",
+
+		OTPResendCooldownSeconds: getEnvInt("OTP_RESEND_COOLDOWN_SECONDS", 60),
+
+		EmailProviderChain: getEnvList("EMAIL_PROVIDER_CHAIN"),
+
+		EmailSendTimeoutSeconds: getEnvInt("EMAIL_SEND_TIMEOUT_SECONDS", 10),
+		SendWelcomeEmail:        getEnv("SEND_WELCOME_EMAIL", "false") == "true",
+
+		BlockDisposableEmails:      getEnv("BLOCK_DISPOSABLE_EMAILS", "false") == "true",
+		DisposableEmailDomainsPath: getEnv("DISPOSABLE_EMAIL_DOMAINS_PATH", ""),
+
+		ReservedDisplayNames:     reservedDisplayNames,
+		ReservedDisplayNameMatch: getEnv("RESERVED_DISPLAY_NAME_MATCH", ReservedNameMatchExact),
+
+		ProfanityFilterEnabled: getEnv("PROFANITY_FILTER_ENABLED", "false") == "true",
+		ProfanityWords:         getEnvList("PROFANITY_WORDS"),
+
+		InviteOnPlayWithoutPartner: getEnv("INVITE_ON_PLAY_WITHOUT_PARTNER", "false") == "true",
+
+		SecretAttemptLimit:           getEnvInt("SECRET_ATTEMPT_LIMIT", 0),
+		SecretAttemptCooldownSeconds: getEnvInt("SECRET_ATTEMPT_COOLDOWN_SECONDS", 60),
+		GuessArchiveThreshold:        getEnvInt("GUESS_ARCHIVE_THRESHOLD", 0),
+
+		MaxLivePlaysPerUser: getEnvInt("MAX_LIVE_PLAYS_PER_USER", 50),
+
+		CheatDetectionEnabled:   getEnv("CHEAT_DETECTION_ENABLED", "false") == "true",
+		CheatSuspicionThreshold: getEnvInt("CHEAT_SUSPICION_THRESHOLD", 50),
+
+		PageDefaultLimit: getEnvInt("PAGE_DEFAULT_LIMIT", 20),
+		PageMaxLimit:     getEnvInt("PAGE_MAX_LIMIT", 100),
+
+		NotificationDedupWindowMinutes: getEnvInt("NOTIFICATION_DEDUP_WINDOW_MINUTES", 10),
+		GameRequestReminderMinutes:     getEnvInt("GAME_REQUEST_REMINDER_MINUTES", 60),
+
+		PlayRetentionDays: getEnvInt("PLAY_RETENTION_DAYS", 0),
+
+		AdminEmails: getEnvList("ADMIN_EMAILS"),
+
+		ServiceAPIKey:   getEnv("SERVICE_API_KEY", ""),
+		TrustedNetworks: getEnvList("TRUSTED_NETWORKS"),
+
+		CORSAllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS"),
+	}
+
+	// Pages always hold at least one row, and the default never exceeds the maximum
+	if cfg.PageMaxLimit < 1 {
+		cfg.PageMaxLimit = 100
+	}
+	if cfg.PageDefaultLimit < 1 || cfg.PageDefaultLimit > cfg.PageMaxLimit {
+		cfg.PageDefaultLimit = min(20, cfg.PageMaxLimit)
 	}
 
 	return cfg
 }
 
+// Validate reports configuration values that can't be used, so the server refuses to start with them
+func (c *Config) Validate() error {
+	for _, provider := range append([]string{c.EmailProvider}, c.EmailProviderChain...) {
+		if !slices.Contains(EmailProviders, provider) {
+			return fmt.Errorf("unsupported email provider %q (supported: %s)", provider, strings.Join(EmailProviders, ", "))
+		}
+	}
+
+	switch c.ReservedDisplayNameMatch {
+	case ReservedNameMatchExact, ReservedNameMatchContains:
+	default:
+		return fmt.Errorf("RESERVED_DISPLAY_NAME_MATCH must be %q or %q, got %q",
+			ReservedNameMatchExact, ReservedNameMatchContains, c.ReservedDisplayNameMatch)
+	}
+	return nil
+}
+
+// redactedValue replaces unexposed values in Redacted
+const redactedValue = "[REDACTED]"
+
+// Redacted returns the configuration as a field name to value map with every field not tagged safe redacted
+// New fields stay hidden until tagged, so a secret is never exposed by omission
+// Redacted values are replaced entirely (never partially shown); unset ones are reported as empty
+func (c *Config) Redacted() map[string]interface{} {
+	values := reflect.ValueOf(*c)
+	fields := values.Type()
+
+	redacted := make(map[string]interface{}, fields.NumField())
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		value := values.Field(i).Interface()
+		if field.Tag.Get("config") != "safe" {
+			if !values.Field(i).IsZero() {
+				value = redactedValue
+			} else {
+				value = ""
+			}
+		}
+		redacted[field.Name] = value
+	}
+	return redacted
+}
+
 // getEnv retrieves an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -79,3 +273,25 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt retrieves a non-negative integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value := defaultValue
+	if valueStr := os.Getenv(key); valueStr != "" {
+		if parsed, err := fmt.Sscanf(valueStr, "%d", &value); err != nil || parsed != 1 || value < 0 {
+			value = defaultValue
+		}
+	}
+	return value
+}
+
+// getEnvList retrieves a comma-separated environment variable as a list, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
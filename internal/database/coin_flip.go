@@ -0,0 +1,44 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CoinFlip is a recorded random decision made for a partnership
+// Outcome is derived from Seed, so either partner can check it wasn't picked by hand
+type CoinFlip struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PartnershipID uuid.UUID `gorm:"type:uuid;not null;index" json:"partnership_id"`
+	FlippedBy     uuid.UUID `gorm:"type:uuid;not null" json:"flipped_by"`
+	Label         string    `gorm:"type:varchar(100);not null;default:''" json:"label"`
+	Seed          string    `gorm:"type:varchar(64);not null" json:"seed"`
+	Outcome       string    `gorm:"type:varchar(10);not null" json:"outcome"` // heads, tails
+	WinnerID      uuid.UUID `gorm:"type:uuid;not null" json:"winner_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (f *CoinFlip) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// CoinFlipRepository handles coin flip database operations
+type CoinFlipRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinFlipRepository creates a new coin flip repository
+func NewCoinFlipRepository(db *gorm.DB) *CoinFlipRepository {
+	return &CoinFlipRepository{db: db}
+}
+
+// Create records a coin flip
+func (r *CoinFlipRepository) Create(flip *CoinFlip) error {
+	return r.db.Create(flip).Error
+}
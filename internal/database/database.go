@@ -1,9 +1,11 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -46,9 +48,27 @@ func AutoMigrate() error {
 		&Game{},
 		&GameRequest{},
 		&Play{},
+		&Notification{},
+		&PlayStat{},
+		&GuessArchive{},
+		&UserPreferences{},
+		&RefreshToken{},
+		&RevokedToken{},
+		&CoinFlip{},
+		&PlayMessage{},
+		&PlayChatMute{},
 	)
 }
 
+// uniqueViolationCode is the Postgres error code for a unique constraint violation
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether err was caused by a unique constraint violation
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 // Close closes the database connection
 func Close() error {
 	sqlDB, err := DB.DB()
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the database named by TEST_DATABASE_URL and migrates it, skipping the test when unset
+// Tests share the database, so each creates its own rows and removes them when it finishes
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	previous := DB
+	DB = db
+	t.Cleanup(func() { DB = previous })
+	if err := AutoMigrate(); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// createTestUser creates a user with a unique email, removed when the test finishes
+func createTestUser(t *testing.T, db *gorm.DB) *User {
+	t.Helper()
+
+	user := &User{Email: uuid.NewString() + "@example.com", Name: "Test User"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(user) })
+	return user
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unique violation", err: &pgconn.PgError{Code: uniqueViolationCode}, want: true},
+		{name: "wrapped unique violation", err: fmt.Errorf("create: %w", &pgconn.PgError{Code: uniqueViolationCode}), want: true},
+		{name: "other constraint", err: &pgconn.PgError{Code: "23503"}, want: false},
+		{name: "not a Postgres error", err: errors.New("boom"), want: false},
+		{name: "no error", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUniqueViolation(tt.err); got != tt.want {
+				t.Errorf("IsUniqueViolation(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePartnershipTwiceIsUniqueViolation(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPartnershipRepository(db)
+	user1 := createTestUser(t, db)
+	user2 := createTestUser(t, db)
+
+	partnership := &Partnership{User1ID: user1.ID, User2ID: user2.ID}
+	if err := repo.CreatePartnership(partnership); err != nil {
+		t.Fatalf("CreatePartnership() error = %v", err)
+	}
+	t.Cleanup(func() { db.Delete(partnership) })
+
+	// What a concurrent accept of the same request runs into
+	err := repo.CreatePartnership(&Partnership{User1ID: user1.ID, User2ID: user2.ID})
+	if !IsUniqueViolation(err) {
+		t.Errorf("second CreatePartnership() error = %v, want a unique violation", err)
+	}
+}
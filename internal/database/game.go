@@ -3,19 +3,26 @@ package database
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrCorruptedJSONB is returned when a stored JSONB value can't be decoded into a JSON object
+var ErrCorruptedJSONB = errors.New("corrupted jsonb value")
+
 // JSONB is a custom type for PostgreSQL JSONB fields
 type JSONB map[string]interface{}
 
 // Value implements the driver.Valuer interface
+// A nil map is stored as an empty object since JSONB columns are NOT NULL
 func (j JSONB) Value() (driver.Value, error) {
 	if j == nil {
-		return nil, nil
+		return []byte("{}"), nil
 	}
 	return json.Marshal(j)
 }
@@ -26,17 +33,30 @@ func (j *JSONB) Scan(value interface{}) error {
 		*j = nil
 		return nil
 	}
-	bytes, ok := value.([]byte)
-	if !ok {
-		return nil
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("%w: unexpected type %T", ErrCorruptedJSONB, value)
+	}
+	if err := json.Unmarshal(bytes, j); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptedJSONB, err)
 	}
-	return json.Unmarshal(bytes, j)
+	// A stored JSON null decodes to a nil map; normalize it so it saves back as {}
+	if *j == nil {
+		*j = JSONB{}
+	}
+	return nil
 }
 
 // Game represents a game in the database
 type Game struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
+	Slug        string    `gorm:"type:varchar(100);uniqueIndex" json:"slug"` // Readable ID for URLs, e.g. "bulls-and-cows"
 	Description string    `gorm:"type:text" json:"description"`
 	Icon        string    `gorm:"type:varchar(10)" json:"icon"`
 	Details     JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"details"`
@@ -54,14 +74,15 @@ func (g *Game) BeforeCreate(tx *gorm.DB) error {
 
 // GameRequest represents a game request in the database
 type GameRequest struct {
-	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	GameID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"game_id"`
-	RequesterID uuid.UUID `gorm:"type:uuid;not null;index" json:"requester_id"`
-	PartnerID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"partner_id"`
-	Status     string     `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // pending, accepted, rejected, expired
-	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	GameID       uuid.UUID `gorm:"type:uuid;not null;index" json:"game_id"`
+	RequesterID  uuid.UUID `gorm:"type:uuid;not null;index" json:"requester_id"`
+	PartnerID    uuid.UUID `gorm:"type:uuid;not null;index" json:"partner_id"`
+	Status       string    `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // pending, accepted, rejected, expired
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	ReminderSent bool      `gorm:"not null;default:false" json:"-"` // Set once the expiry reminder has been sent
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 
 	// Relations
 	Game      Game `gorm:"foreignKey:GameID" json:"game,omitempty"`
@@ -90,15 +111,34 @@ type Play struct {
 	Partner2ID uuid.UUID `gorm:"type:uuid;not null;index" json:"partner2_id"`
 	PlayData   JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"play_data"`
 	IsLive     bool      `gorm:"not null;default:true;index" json:"is_live"`
+	Score      *int      `json:"score"`                                     // Winner's score, set when the play completes
+	ShareToken *string   `gorm:"type:varchar(64);uniqueIndex" json:"-"`     // Lets non-participants fetch the replay
+	Suspicion  *int      `gorm:"column:cheat_suspicion;index" json:"-"`     // Cheat suspicion score (0-100) of the winner, admin-only
+	IsPractice bool      `gorm:"not null;default:false" json:"is_practice"` // Imported from a replay; excluded from stats and leaderboards
+	Version    int       `gorm:"not null;default:0" json:"version"`         // Incremented on every update, so concurrent updates can't overwrite each other
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // Set when purged by the retention policy
+
+	// Whose turn it is in a live play, kept in sync with PlayData["current_turn"] by BeforeSave
+	CurrentTurnUserID *uuid.UUID `gorm:"type:uuid;index" json:"-"`
+
+	// Which Bulls and Cows secrets are set, from the viewing partner's side; computed per response, never stored
+	SecretsStatus *SecretsStatus `gorm:"-" json:"secrets_status,omitempty"`
+
 	// Relations
 	Game     Game `gorm:"foreignKey:GameID" json:"game,omitempty"`
 	Partner1 User `gorm:"foreignKey:Partner1ID" json:"partner1,omitempty"`
 	Partner2 User `gorm:"foreignKey:Partner2ID" json:"partner2,omitempty"`
 }
 
+// SecretsStatus tells a partner whether each side has set its secret without revealing either
+type SecretsStatus struct {
+	YouSet      bool `json:"you_set"`
+	OpponentSet bool `json:"opponent_set"`
+}
+
 // BeforeCreate hook to generate UUID if not set
 func (p *Play) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
@@ -107,6 +147,21 @@ func (p *Play) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// BeforeSave hook to denormalize the current turn so it can be queried without scanning play data
+// Only live plays in the playing state have a current turn
+func (p *Play) BeforeSave(tx *gorm.DB) error {
+	p.CurrentTurnUserID = nil
+	if status, _ := p.PlayData["status"].(string); !p.IsLive || status != "playing" {
+		return nil
+	}
+	if currentTurn, ok := p.PlayData["current_turn"].(string); ok {
+		if userID, err := uuid.Parse(currentTurn); err == nil {
+			p.CurrentTurnUserID = &userID
+		}
+	}
+	return nil
+}
+
 // GameRepository handles game database operations
 type GameRepository struct {
 	db *gorm.DB
@@ -134,6 +189,16 @@ func (r *GameRepository) FindByID(id uuid.UUID) (*Game, error) {
 	return &game, nil
 }
 
+// FindBySlug finds a game by its slug
+func (r *GameRepository) FindBySlug(slug string) (*Game, error) {
+	var game Game
+	err := r.db.Where("slug = ?", slug).First(&game).Error
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
 // GameRequestRepository handles game request database operations
 type GameRequestRepository struct {
 	db *gorm.DB
@@ -174,6 +239,15 @@ func (r *GameRequestRepository) FindPendingRequestsByPartner(partnerID uuid.UUID
 	return requests, err
 }
 
+// CountPendingRequestsByPartner counts unexpired pending requests received by a partner
+func (r *GameRequestRepository) CountPendingRequestsByPartner(partnerID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&GameRequest{}).
+		Where("partner_id = ? AND status = ? AND expires_at > ?", partnerID, "pending", time.Now()).
+		Count(&count).Error
+	return count, err
+}
+
 // FindPendingRequestsByRequester finds all pending requests sent by a requester
 func (r *GameRequestRepository) FindPendingRequestsByRequester(requesterID uuid.UUID) ([]GameRequest, error) {
 	var requests []GameRequest
@@ -185,11 +259,45 @@ func (r *GameRequestRepository) FindPendingRequestsByRequester(requesterID uuid.
 	return requests, err
 }
 
+// FindPendingRequestsBetweenPartners finds all pending requests exchanged between two partners
+func (r *GameRequestRepository) FindPendingRequestsBetweenPartners(partner1ID, partner2ID uuid.UUID) ([]GameRequest, error) {
+	var requests []GameRequest
+	err := r.db.Where("((requester_id = ? AND partner_id = ?) OR (requester_id = ? AND partner_id = ?)) AND status = ? AND expires_at > ?",
+		partner1ID, partner2ID, partner2ID, partner1ID, "pending", time.Now()).
+		Order("created_at DESC").
+		Find(&requests).Error
+	return requests, err
+}
+
 // UpdateRequest updates a game request
 func (r *GameRequestRepository) UpdateRequest(request *GameRequest) error {
 	return r.db.Save(request).Error
 }
 
+// ErrRequestNotPending is returned when accepting a game request that was already responded to
+var ErrRequestNotPending = errors.New("game request is no longer pending")
+
+// AcceptRequest marks a pending request accepted and starts its play in one transaction,
+// ending any live play of the same game between the partners
+func (r *GameRequestRepository) AcceptRequest(request *GameRequest, play *Play) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&GameRequest{}).
+			Where("id = ? AND status = ?", request.ID, "pending").
+			Update("status", "accepted")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRequestNotPending
+		}
+		if err := replaceLivePlay(tx, play); err != nil {
+			return err
+		}
+		request.Status = "accepted"
+		return nil
+	})
+}
+
 // ExpireOldRequests marks expired requests as expired
 func (r *GameRequestRepository) ExpireOldRequests() error {
 	return r.db.Model(&GameRequest{}).
@@ -197,6 +305,36 @@ func (r *GameRequestRepository) ExpireOldRequests() error {
 		Update("status", "expired").Error
 }
 
+// ClaimRequestsForReminder finds pending requests expiring within the window that have not been
+// reminded yet and marks them as reminded in the same transaction
+// Rows locked by a concurrent sweep are skipped, so each request is claimed (and reminded) at most once
+func (r *GameRequestRepository) ClaimRequestsForReminder(window time.Duration) ([]GameRequest, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&GameRequest{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND reminder_sent = ? AND expires_at > ? AND expires_at <= ?",
+				"pending", false, now, now.Add(window)).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&GameRequest{}).Where("id IN ?", ids).Update("reminder_sent", true).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var requests []GameRequest
+	err = r.db.Where("id IN ?", ids).
+		Preload("Game").
+		Find(&requests).Error
+	return requests, err
+}
+
 // PlayRepository handles play database operations
 type PlayRepository struct {
 	db *gorm.DB
@@ -249,16 +387,498 @@ func (r *PlayRepository) FindLivePlayByPartners(partner1ID, partner2ID uuid.UUID
 	return &play, nil
 }
 
-// UpdatePlay updates a play
+// CountLivePlaysByUser counts the live plays the user takes part in, with any partner
+func (r *PlayRepository) CountLivePlaysByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&Play{}).
+		Where("(partner1_id = ? OR partner2_id = ?) AND is_live = ?", userID, userID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// FindLivePlaysByPartners finds all live plays for a partner combination across games, most recently active first
+func (r *PlayRepository) FindLivePlaysByPartners(partner1ID, partner2ID uuid.UUID) ([]Play, error) {
+	var plays []Play
+	err := r.db.Where("((partner1_id = ? AND partner2_id = ?) OR (partner1_id = ? AND partner2_id = ?)) AND is_live = ?",
+		partner1ID, partner2ID, partner2ID, partner1ID, true).
+		Preload("Game").
+		Order("updated_at DESC").
+		Find(&plays).Error
+	return plays, err
+}
+
+// PlayFilter holds optional filters and pagination for play queries
+type PlayFilter struct {
+	From *time.Time // Only plays created at or after this time
+	To   *time.Time // Only plays created at or before this time
+	Pagination
+}
+
+// GameOutcomeStats aggregates the outcomes of a game's completed plays across all users
+// Only the winner's guesses, archived ones included, count towards guesses to win
+type GameOutcomeStats struct {
+	CompletedPlays      int
+	AverageGuessesToWin *float64 // Nil if no play has a winner yet
+	FewestGuessesToWin  *int
+	FastestWinSeconds   *int64 // From play start to the winning guess, excluding time paused
+}
+
+// FindGameOutcomeStats aggregates a game's completed non-practice plays in the database
+// Purged plays aren't included
+func (r *PlayRepository) FindGameOutcomeStats(gameID uuid.UUID) (*GameOutcomeStats, error) {
+	// Each completed play with its winner's guess count and winning guess time
+	plays := r.db.Model(&Play{}).
+		Select(`plays.created_at,
+			play_data->>'winner_id' AS winner_id,
+			COALESCE((play_data->>'paused_seconds')::float8, 0) AS paused_seconds,
+			(SELECT COUNT(*) FROM jsonb_array_elements(guesses.list) AS g
+				WHERE g->>'player_id' = play_data->>'winner_id')
+			+ (SELECT COUNT(*) FROM guess_archives AS a
+				WHERE a.play_id = plays.id AND a.guess->>'player_id' = play_data->>'winner_id') AS winner_guesses,
+			(SELECT MAX((g->>'timestamp')::timestamptz) FROM jsonb_array_elements(guesses.list) AS g
+				WHERE g->>'player_id' = play_data->>'winner_id') AS won_at`).
+		Joins(`CROSS JOIN LATERAL (SELECT CASE WHEN jsonb_typeof(play_data->'guesses') = 'array'
+			THEN play_data->'guesses' ELSE '[]'::jsonb END AS list) AS guesses`).
+		Where("game_id = ? AND play_data->>'status' = ? AND is_practice = ?", gameID, "completed", false)
+
+	var stats GameOutcomeStats
+	err := r.db.Table("(?) AS completed", plays).
+		Select(`COUNT(*) AS completed_plays,
+			(AVG(winner_guesses) FILTER (WHERE winner_id IS NOT NULL))::float8 AS average_guesses_to_win,
+			MIN(winner_guesses) FILTER (WHERE winner_id IS NOT NULL) AS fewest_guesses_to_win,
+			MIN(FLOOR(EXTRACT(EPOCH FROM won_at - created_at))::bigint - FLOOR(paused_seconds)::bigint)
+				FILTER (WHERE winner_id IS NOT NULL) AS fastest_win_seconds`).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// FindCompletedPlaysByUser finds the user's completed non-practice plays across all games and partners
+// Purged plays aren't included; their totals are kept in PlayStat
+func (r *PlayRepository) FindCompletedPlaysByUser(userID uuid.UUID) ([]Play, error) {
+	var plays []Play
+	err := r.db.Where("(partner1_id = ? OR partner2_id = ?) AND is_live = ? AND is_practice = ? AND play_data->>'status' = ?",
+		userID, userID, false, false, "completed").
+		Find(&plays).Error
+	return plays, err
+}
+
+// FindTopScoresByGame finds the highest scoring completed plays of a game
+// Purged plays are only soft-deleted, so they keep their places
+func (r *PlayRepository) FindTopScoresByGame(gameID uuid.UUID, limit int) ([]Play, error) {
+	var plays []Play
+	err := r.db.Unscoped().Where("game_id = ? AND score IS NOT NULL AND is_practice = ?", gameID, false).
+		Preload("Partner1").
+		Preload("Partner2").
+		Order("score DESC, updated_at ASC").
+		Limit(limit).
+		Find(&plays).Error
+	return plays, err
+}
+
+// SetShareToken sets the token that lets non-participants fetch a play's replay
+func (r *PlayRepository) SetShareToken(playID uuid.UUID, token string) error {
+	return r.db.Model(&Play{}).
+		Where("id = ?", playID).
+		Update("share_token", token).Error
+}
+
+// PlayedGame summarizes a user's plays of a single game
+type PlayedGame struct {
+	GameID       uuid.UUID `json:"game_id"`
+	Game         Game      `gorm:"foreignKey:GameID" json:"game"`
+	PlayCount    int64     `json:"play_count"`
+	LastPlayedAt time.Time `json:"last_played_at"`
+}
+
+// PopularGame is a game with the number of plays started across all users
+type PopularGame struct {
+	GameID    uuid.UUID `json:"game_id"`
+	Game      Game      `gorm:"foreignKey:GameID" json:"game"`
+	PlayCount int64     `json:"play_count"`
+}
+
+// HeadToHeadRecord is a user's results against one opponent in one game
+type HeadToHeadRecord struct {
+	GameID     uuid.UUID
+	OpponentID uuid.UUID
+	Wins       int64
+	Losses     int64
+	Draws      int64 // Completed without a winner
+}
+
+// FindHeadToHeadRecords tallies the user's completed non-practice plays by game and opponent
+// Purged plays aren't included; their totals are kept in PlayStat
+func (r *PlayRepository) FindHeadToHeadRecords(userID uuid.UUID) ([]HeadToHeadRecord, error) {
+	records := []HeadToHeadRecord{}
+	err := r.db.Model(&Play{}).
+		Select(`game_id,
+			CASE WHEN partner1_id = ? THEN partner2_id ELSE partner1_id END AS opponent_id,
+			COUNT(*) FILTER (WHERE play_data->>'winner_id' = ?) AS wins,
+			COUNT(*) FILTER (WHERE play_data->>'winner_id' IS NOT NULL AND play_data->>'winner_id' <> ?) AS losses,
+			COUNT(*) FILTER (WHERE play_data->>'winner_id' IS NULL) AS draws`,
+			userID, userID.String(), userID.String()).
+		Where("(partner1_id = ? OR partner2_id = ?) AND is_practice = ? AND play_data->>'status' = ?",
+			userID, userID, false, "completed").
+		Group("game_id, opponent_id").
+		Scan(&records).Error
+	return records, err
+}
+
+// FindPlaysAwaitingTurn finds the live plays where it is the user's turn, oldest move first
+func (r *PlayRepository) FindPlaysAwaitingTurn(userID uuid.UUID) ([]Play, error) {
+	var plays []Play
+	err := r.db.Where("current_turn_user_id = ? AND is_live = ?", userID, true).
+		Preload("Game").
+		Preload("Partner1").
+		Preload("Partner2").
+		Order("updated_at ASC").
+		Find(&plays).Error
+	return plays, err
+}
+
+// FindMostRecentLivePlayByUser finds the user's most recently updated live play across all partners and games
+func (r *PlayRepository) FindMostRecentLivePlayByUser(userID uuid.UUID) (*Play, error) {
+	var play Play
+	err := r.db.Where("(partner1_id = ? OR partner2_id = ?) AND is_live = ?", userID, userID, true).
+		Preload("Game").
+		Preload("Partner1").
+		Preload("Partner2").
+		Order("updated_at DESC").
+		First(&play).Error
+	if err != nil {
+		return nil, err
+	}
+	return &play, nil
+}
+
+// CountPlaysAwaitingTurn counts the live plays where it's the user's turn
+func (r *PlayRepository) CountPlaysAwaitingTurn(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&Play{}).
+		Where("current_turn_user_id = ? AND is_live = ?", userID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// FindRecentWinsByUser finds the user's most recent non-practice wins of a game
+func (r *PlayRepository) FindRecentWinsByUser(userID, gameID uuid.UUID, limit int) ([]Play, error) {
+	var plays []Play
+	err := r.db.Where("game_id = ? AND is_practice = ? AND play_data->>'winner_id' = ?", gameID, false, userID.String()).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&plays).Error
+	return plays, err
+}
+
+// FindSuspiciousPlays finds plays whose cheat suspicion score is at least minScore, most suspicious first,
+// along with the total matching count
+func (r *PlayRepository) FindSuspiciousPlays(minScore int, filter PlayFilter) ([]Play, int64, error) {
+	query := r.db.Model(&Play{}).Where("cheat_suspicion >= ?", minScore)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var plays []Play
+	err := query.
+		Preload("Game").
+		Preload("Partner1").
+		Preload("Partner2").
+		Order("cheat_suspicion DESC, updated_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&plays).Error
+	return plays, total, err
+}
+
+// FindPlayedGamesByUser finds the distinct games a user has played with any partner, most recently played first
+func (r *PlayRepository) FindPlayedGamesByUser(userID uuid.UUID) ([]PlayedGame, error) {
+	playedGames := []PlayedGame{}
+	err := r.db.Model(&Play{}).
+		Select("game_id, COUNT(*) AS play_count, MAX(updated_at) AS last_played_at").
+		Where("(partner1_id = ? OR partner2_id = ?) AND is_practice = ?", userID, userID, false).
+		Group("game_id").
+		Order("last_played_at DESC").
+		Find(&playedGames).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(playedGames) == 0 {
+		return playedGames, nil
+	}
+
+	gameIDs := make([]uuid.UUID, len(playedGames))
+	for i, playedGame := range playedGames {
+		gameIDs[i] = playedGame.GameID
+	}
+
+	var games []Game
+	if err := r.db.Where("id IN ?", gameIDs).Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	gamesByID := make(map[uuid.UUID]Game, len(games))
+	for _, game := range games {
+		gamesByID[game.ID] = game
+	}
+	for i := range playedGames {
+		playedGames[i].Game = gamesByID[playedGames[i].GameID]
+	}
+
+	return playedGames, nil
+}
+
+// FindPopularGames ranks games by how many plays were started since the given time (nil counts every play)
+// Practice and bot plays are excluded, as are purged plays
+func (r *PlayRepository) FindPopularGames(since *time.Time) ([]PopularGame, error) {
+	popularGames := []PopularGame{}
+	query := r.db.Model(&Play{}).
+		Select("plays.game_id, COUNT(*) AS play_count").
+		Joins("JOIN games ON games.id = plays.game_id").
+		Where("plays.is_practice = ? AND plays.partner1_id <> ? AND plays.partner2_id <> ?", false, BotUserID, BotUserID)
+	if since != nil {
+		query = query.Where("plays.created_at >= ?", *since)
+	}
+	err := query.Group("plays.game_id").
+		Order("play_count DESC, plays.game_id").
+		Find(&popularGames).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(popularGames) == 0 {
+		return popularGames, nil
+	}
+
+	gameIDs := make([]uuid.UUID, len(popularGames))
+	for i, popularGame := range popularGames {
+		gameIDs[i] = popularGame.GameID
+	}
+
+	var games []Game
+	if err := r.db.Where("id IN ?", gameIDs).Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	gamesByID := make(map[uuid.UUID]Game, len(games))
+	for _, game := range games {
+		gamesByID[game.ID] = game
+	}
+	for i := range popularGames {
+		popularGames[i].Game = gamesByID[popularGames[i].GameID]
+	}
+
+	return popularGames, nil
+}
+
+// FindPlaysByUser finds plays the user took part in, most recent first, along with the total matching count
+func (r *PlayRepository) FindPlaysByUser(userID uuid.UUID, filter PlayFilter) ([]Play, int64, error) {
+	query := r.db.Model(&Play{}).Where("partner1_id = ? OR partner2_id = ?", userID, userID)
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var plays []Play
+	err := query.
+		Preload("Game").
+		Preload("Partner1").
+		Preload("Partner2").
+		Order("created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&plays).Error
+	return plays, total, err
+}
+
+// FindPlaysByPartners finds the non-practice plays between two users, oldest first, along with the total matching count
+func (r *PlayRepository) FindPlaysByPartners(partner1ID, partner2ID uuid.UUID, filter PlayFilter) ([]Play, int64, error) {
+	query := r.db.Model(&Play{}).
+		Where("((partner1_id = ? AND partner2_id = ?) OR (partner1_id = ? AND partner2_id = ?)) AND is_practice = ?",
+			partner1ID, partner2ID, partner2ID, partner1ID, false)
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var plays []Play
+	err := query.
+		Preload("Game").
+		Order("created_at ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&plays).Error
+	return plays, total, err
+}
+
+// PurgeEndedPlaysBefore soft-deletes plays that ended before the cutoff
+// Each purged completed play is first added to both partners' PlayStat counters so aggregates stay accurate
+func (r *PlayRepository) PurgeEndedPlaysBefore(cutoff time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var plays []Play
+		if err := tx.Where("is_live = ? AND updated_at < ?", false, cutoff).Find(&plays).Error; err != nil {
+			return err
+		}
+
+		for _, play := range plays {
+			// Only completed non-practice plays count towards stats
+			if status, _ := play.PlayData["status"].(string); !play.IsPractice && status == "completed" {
+				winnerID, _ := play.PlayData["winner_id"].(string)
+				if err := incrementPlayStat(tx, play.Partner1ID, play.GameID, play.Partner2ID, winnerID); err != nil {
+					return err
+				}
+				if err := incrementPlayStat(tx, play.Partner2ID, play.GameID, play.Partner1ID, winnerID); err != nil {
+					return err
+				}
+			}
+			// Chat isn't kept past the play it belongs to
+			if err := tx.Where("play_id = ?", play.ID).Delete(&PlayMessage{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("play_id = ?", play.ID).Delete(&PlayChatMute{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&Play{}, "id = ?", play.ID).Error; err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// UpdatePlay updates a play, failing with ErrStalePlay if it was updated since it was loaded
 func (r *PlayRepository) UpdatePlay(play *Play) error {
-	return r.db.Save(play).Error
+	return saveVersionedPlay(r.db, play)
 }
 
-// EndLivePlay marks a play as not live
-func (r *PlayRepository) EndLivePlay(playID uuid.UUID) error {
+// Play outcomes recorded in a play's result
+const (
+	PlayOutcomeWin       = "win"       // A player won the play
+	PlayOutcomeDraw      = "draw"      // The play finished level, with no winner
+	PlayOutcomeAbandoned = "abandoned" // The play ended without a winner
+)
+
+// Reasons recorded in a play's result
+const (
+	PlayEndReasonSolved           = "solved"            // The winner guessed the secret
+	PlayEndReasonThreeInARow      = "three_in_a_row"    // The winner completed a Tic-Tac-Toe line
+	PlayEndReasonBoardFull        = "board_full"        // Every Tic-Tac-Toe cell was marked without a line
+	PlayEndReasonForfeited        = "forfeited"         // The loser conceded
+	PlayEndReasonReplaced         = "replaced"          // A new play of the same game started between the partners
+	PlayEndReasonPartnershipEnded = "partnership_ended" // The partners split up
+	PlayEndReasonEnded            = "ended"             // Ended without a more specific reason (also used for legacy plays)
+)
+
+// PlayResult builds the result every terminal transition records in PlayData["result"]
+// winnerID is nil when nobody won
+func PlayResult(outcome, reason string, winnerID *uuid.UUID) map[string]interface{} {
+	result := map[string]interface{}{
+		"outcome":   outcome,
+		"winner_id": nil,
+		"reason":    reason,
+	}
+	if winnerID != nil {
+		result["winner_id"] = winnerID.String()
+	}
+	return result
+}
+
+// endedPlayColumns are the columns set when plays are ended without being loaded
+// Column updates don't go through BeforeSave, so the denormalized current turn is cleared here,
+// and the abandoned result is merged into play_data in SQL
+func endedPlayColumns(reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"is_live":              false,
+		"current_turn_user_id": nil,
+		"version":              gorm.Expr("version + 1"),
+		"play_data": gorm.Expr("play_data || jsonb_build_object('result', jsonb_build_object('outcome', ?::text, 'winner_id', NULL, 'reason', ?::text))",
+			PlayOutcomeAbandoned, reason),
+	}
+}
+
+// ErrStalePlay is returned when a conditional play update finds the play was changed concurrently
+var ErrStalePlay = errors.New("play was changed concurrently")
+
+// saveVersionedPlay saves every column of a loaded play if its version in the database is unchanged,
+// incrementing the version; conditions already on db narrow the update further
+// Returns ErrStalePlay, leaving the version as loaded, if no row matched
+func saveVersionedPlay(db *gorm.DB, play *Play) error {
+	loadedVersion := play.Version
+	play.Version++
+	result := db.Model(play).
+		Where("version = ?", loadedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(play)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStalePlay
+	}
+	if result.Error != nil {
+		play.Version = loadedVersion
+	}
+	return result.Error
+}
+
+// UpdatePlayOnTurn saves a play only if it is unchanged, still live and still the given user's turn in the database
+// This guards turn-based moves against concurrent moves that the in-process play lock can't serialize
+func (r *PlayRepository) UpdatePlayOnTurn(play *Play, turnUserID uuid.UUID) error {
+	return saveVersionedPlay(r.db.Where("is_live = ? AND current_turn_user_id = ?", true, turnUserID), play)
+}
+
+// EndLivePlay marks a play as not live, recording it as abandoned for the given reason
+func (r *PlayRepository) EndLivePlay(playID uuid.UUID, reason string) error {
 	return r.db.Model(&Play{}).
 		Where("id = ?", playID).
-		Update("is_live", false).Error
+		Updates(endedPlayColumns(reason)).Error
+}
+
+// EndLivePlaysByPartnersAndGame ends the live plays of a single game for a partner combination, as replaced
+// Live plays of other games between the same partners are left untouched
+func (r *PlayRepository) EndLivePlaysByPartnersAndGame(partner1ID, partner2ID uuid.UUID, gameID uuid.UUID) error {
+	return r.db.Model(&Play{}).
+		Where("((partner1_id = ? AND partner2_id = ?) OR (partner1_id = ? AND partner2_id = ?)) AND game_id = ? AND is_live = ?",
+			partner1ID, partner2ID, partner2ID, partner1ID, gameID, true).
+		Updates(endedPlayColumns(PlayEndReasonReplaced)).Error
+}
+
+// ReplaceLivePlay creates a live play, ending any live play of the same game between its partners in the same transaction
+func (r *PlayRepository) ReplaceLivePlay(play *Play) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceLivePlay(tx, play)
+	})
+}
+
+// replaceLivePlay ends the partners' live plays of the play's game and creates the play, within tx
+func replaceLivePlay(tx *gorm.DB, play *Play) error {
+	if err := NewPlayRepository(tx).EndLivePlaysByPartnersAndGame(play.Partner1ID, play.Partner2ID, play.GameID); err != nil {
+		return err
+	}
+	return tx.Create(play).Error
 }
 
 // EndAllLivePlaysByPartners ends all live plays for a partner combination
@@ -274,6 +894,5 @@ func (r *PlayRepository) EndAllLivePlaysByPartners(partner1ID, partner2ID uuid.U
 	return r.db.Model(&Play{}).
 		Where("((partner1_id = ? AND partner2_id = ?) OR (partner1_id = ? AND partner2_id = ?)) AND is_live = ?",
 			smallerID, largerID, largerID, smallerID, true).
-		Update("is_live", false).Error
+		Updates(endedPlayColumns(PlayEndReasonPartnershipEnded)).Error
 }
-
@@ -0,0 +1,324 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// createTestPlay creates a live play between two new users, removed when the test finishes
+func createTestPlay(t *testing.T, db *gorm.DB) *Play {
+	t.Helper()
+
+	game := &Game{Name: "Test Game", Slug: "test-" + uuid.NewString()}
+	if err := db.Create(game).Error; err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	partner1 := createTestUser(t, db)
+	partner2 := createTestUser(t, db)
+
+	play := &Play{GameID: game.ID, Partner1ID: partner1.ID, Partner2ID: partner2.ID, PlayData: JSONB{}, IsLive: true}
+	if err := db.Create(play).Error; err != nil {
+		t.Fatalf("failed to create play: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Delete(play)
+		db.Delete(game)
+	})
+	return play
+}
+
+func TestSaveVersionedPlay(t *testing.T) {
+	db := openTestDB(t)
+
+	tests := []struct {
+		name string
+		// concurrent changes the stored play after it was loaded
+		concurrent func(db *gorm.DB, play *Play) error
+		// scope narrows the conditional update, as UpdatePlayOnTurn does
+		scope       func(db *gorm.DB) *gorm.DB
+		wantErr     error
+		wantVersion int // Version of the loaded play after saving
+	}{
+		{
+			name:        "unchanged play is saved",
+			wantVersion: 1,
+		},
+		{
+			name: "play updated concurrently is stale",
+			concurrent: func(db *gorm.DB, play *Play) error {
+				return db.Model(&Play{}).Where("id = ?", play.ID).Update("version", gorm.Expr("version + 1")).Error
+			},
+			wantErr:     ErrStalePlay,
+			wantVersion: 0,
+		},
+		{
+			name: "play ended concurrently fails the extra condition",
+			concurrent: func(db *gorm.DB, play *Play) error {
+				return db.Model(&Play{}).Where("id = ?", play.ID).Update("is_live", false).Error
+			},
+			scope:       func(db *gorm.DB) *gorm.DB { return db.Where("is_live = ?", true) },
+			wantErr:     ErrStalePlay,
+			wantVersion: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			play := createTestPlay(t, db)
+			if tt.concurrent != nil {
+				if err := tt.concurrent(db, play); err != nil {
+					t.Fatalf("concurrent update failed: %v", err)
+				}
+			}
+
+			scoped := db
+			if tt.scope != nil {
+				scoped = tt.scope(db)
+			}
+			play.PlayData = JSONB{"status": "playing"}
+			err := saveVersionedPlay(scoped, play)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("saveVersionedPlay() error = %v, want %v", err, tt.wantErr)
+			}
+			if play.Version != tt.wantVersion {
+				t.Errorf("play.Version = %d, want %d", play.Version, tt.wantVersion)
+			}
+
+			var stored Play
+			if err := db.First(&stored, "id = ?", play.ID).Error; err != nil {
+				t.Fatalf("failed to reload play: %v", err)
+			}
+			_, saved := stored.PlayData["status"]
+			if saved != (tt.wantErr == nil) {
+				t.Errorf("play data saved = %v, want %v", saved, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestFindGameOutcomeStats(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPlayRepository(db)
+	won := createTestPlay(t, db)
+	winner, loser := won.Partner1ID.String(), won.Partner2ID.String()
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	guess := func(playerID string, after time.Duration) map[string]interface{} {
+		return map[string]interface{}{"player_id": playerID, "timestamp": startedAt.Add(after).Format(time.RFC3339)}
+	}
+	won.CreatedAt = startedAt
+	won.PlayData = JSONB{
+		"status":              "completed",
+		"winner_id":           winner,
+		"paused_seconds":      5.0,
+		ArchivedGuessCountKey: 1,
+		"guesses":             []interface{}{guess(winner, 10*time.Second), guess(loser, 20*time.Second), guess(winner, 30*time.Second)},
+	}
+	if err := db.Save(won).Error; err != nil {
+		t.Fatalf("failed to complete play: %v", err)
+	}
+	archived := &GuessArchive{PlayID: won.ID, Seq: 0, Guess: JSONB(guess(winner, 5*time.Second))}
+	if err := db.Create(archived).Error; err != nil {
+		t.Fatalf("failed to archive guess: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(archived) })
+
+	// A draw counts as completed without a winner; practice plays don't count at all
+	for _, play := range []*Play{
+		{GameID: won.GameID, Partner1ID: won.Partner1ID, Partner2ID: won.Partner2ID, PlayData: JSONB{"status": "completed"}},
+		{GameID: won.GameID, Partner1ID: won.Partner1ID, Partner2ID: won.Partner2ID, IsPractice: true,
+			PlayData: JSONB{"status": "completed", "winner_id": winner, "guesses": []interface{}{guess(winner, time.Second)}}},
+	} {
+		if err := db.Create(play).Error; err != nil {
+			t.Fatalf("failed to create play: %v", err)
+		}
+		t.Cleanup(func() { db.Unscoped().Delete(play) })
+	}
+
+	stats, err := repo.FindGameOutcomeStats(won.GameID)
+	if err != nil {
+		t.Fatalf("FindGameOutcomeStats() error = %v", err)
+	}
+	if stats.CompletedPlays != 2 {
+		t.Errorf("CompletedPlays = %d, want 2", stats.CompletedPlays)
+	}
+	if stats.AverageGuessesToWin == nil || *stats.AverageGuessesToWin != 3 {
+		t.Errorf("AverageGuessesToWin = %v, want 3", stats.AverageGuessesToWin)
+	}
+	if stats.FewestGuessesToWin == nil || *stats.FewestGuessesToWin != 3 {
+		t.Errorf("FewestGuessesToWin = %v, want 3", stats.FewestGuessesToWin)
+	}
+	if stats.FastestWinSeconds == nil || *stats.FastestWinSeconds != 25 {
+		t.Errorf("FastestWinSeconds = %v, want 25 (30s to the winning guess less 5s paused)", stats.FastestWinSeconds)
+	}
+}
+
+func TestFindPlaysByUserDateRange(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPlayRepository(db)
+	recent := createTestPlay(t, db)
+	old := &Play{GameID: recent.GameID, Partner1ID: recent.Partner2ID, Partner2ID: recent.Partner1ID, PlayData: JSONB{}}
+	if err := db.Create(old).Error; err != nil {
+		t.Fatalf("failed to create play: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(old) })
+	if err := db.Model(old).Update("created_at", time.Now().AddDate(0, 0, -10)).Error; err != nil {
+		t.Fatalf("failed to backdate play: %v", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -5)
+	before := time.Now().AddDate(0, 0, -5)
+	tests := []struct {
+		name   string
+		filter PlayFilter
+		want   []uuid.UUID
+	}{
+		{name: "no range", filter: PlayFilter{}, want: []uuid.UUID{recent.ID, old.ID}},
+		{name: "from", filter: PlayFilter{From: &since}, want: []uuid.UUID{recent.ID}},
+		{name: "to", filter: PlayFilter{To: &before}, want: []uuid.UUID{old.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Pagination = Pagination{Limit: 10}
+			plays, total, err := repo.FindPlaysByUser(recent.Partner1ID, tt.filter)
+			if err != nil {
+				t.Fatalf("FindPlaysByUser() error = %v", err)
+			}
+			var got []uuid.UUID
+			for _, play := range plays {
+				got = append(got, play.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("FindPlaysByUser() = %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceLivePlayKeepsOtherGames(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPlayRepository(db)
+	existing := createTestPlay(t, db)
+
+	otherGame := &Game{Name: "Other Game", Slug: "test-" + uuid.NewString()}
+	if err := db.Create(otherGame).Error; err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	otherPlay := &Play{GameID: otherGame.ID, Partner1ID: existing.Partner1ID, Partner2ID: existing.Partner2ID, PlayData: JSONB{}, IsLive: true}
+	if err := db.Create(otherPlay).Error; err != nil {
+		t.Fatalf("failed to create play: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Delete(otherPlay)
+		db.Delete(otherGame)
+	})
+
+	// The new play lists the partners in the other order
+	replacement := &Play{GameID: existing.GameID, Partner1ID: existing.Partner2ID, Partner2ID: existing.Partner1ID, PlayData: JSONB{}, IsLive: true}
+	if err := repo.ReplaceLivePlay(replacement); err != nil {
+		t.Fatalf("ReplaceLivePlay() error = %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(replacement) })
+
+	for _, tt := range []struct {
+		name     string
+		play     *Play
+		wantLive bool
+	}{
+		{name: "replaced play", play: existing, wantLive: false},
+		{name: "other game's play", play: otherPlay, wantLive: true},
+		{name: "new play", play: replacement, wantLive: true},
+	} {
+		var stored Play
+		if err := db.First(&stored, "id = ?", tt.play.ID).Error; err != nil {
+			t.Fatalf("failed to reload %s: %v", tt.name, err)
+		}
+		if stored.IsLive != tt.wantLive {
+			t.Errorf("%s is_live = %v, want %v", tt.name, stored.IsLive, tt.wantLive)
+		}
+	}
+}
+
+func TestFindPlayedGamesByUser(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPlayRepository(db)
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	otherPartner := createTestUser(t, db)
+
+	createGame := func() *Game {
+		game := &Game{Name: "Test Game", Slug: "test-" + uuid.NewString()}
+		if err := db.Create(game).Error; err != nil {
+			t.Fatalf("failed to create game: %v", err)
+		}
+		t.Cleanup(func() { db.Delete(game) })
+		return game
+	}
+	createPlay := func(game *Game, partner1, partner2 *User, isPractice bool, updatedAt time.Time) {
+		play := &Play{GameID: game.ID, Partner1ID: partner1.ID, Partner2ID: partner2.ID, PlayData: JSONB{}, IsPractice: isPractice}
+		if err := db.Create(play).Error; err != nil {
+			t.Fatalf("failed to create play: %v", err)
+		}
+		t.Cleanup(func() { db.Unscoped().Delete(play) })
+		if err := db.Model(play).UpdateColumn("updated_at", updatedAt).Error; err != nil {
+			t.Fatalf("failed to set updated_at: %v", err)
+		}
+	}
+
+	recentGame, olderGame, practiceGame := createGame(), createGame(), createGame()
+	createPlay(recentGame, user, partner, false, time.Now().Add(-time.Hour))
+	createPlay(recentGame, otherPartner, user, false, time.Now())
+	createPlay(olderGame, user, partner, false, time.Now().AddDate(0, 0, -1))
+	createPlay(practiceGame, user, partner, true, time.Now())
+
+	played, err := repo.FindPlayedGamesByUser(user.ID)
+	if err != nil {
+		t.Fatalf("FindPlayedGamesByUser() error = %v", err)
+	}
+	if len(played) != 2 {
+		t.Fatalf("FindPlayedGamesByUser() returned %d games, want 2: %+v", len(played), played)
+	}
+	if played[0].GameID != recentGame.ID || played[0].PlayCount != 2 || played[0].Game.ID != recentGame.ID {
+		t.Errorf("first game = %+v, want %s played twice", played[0], recentGame.ID)
+	}
+	if played[1].GameID != olderGame.ID || played[1].PlayCount != 1 {
+		t.Errorf("second game = %+v, want %s played once", played[1], olderGame.ID)
+	}
+}
+
+func TestPlayBeforeSaveCurrentTurn(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name     string
+		isLive   bool
+		playData JSONB
+		want     *uuid.UUID
+	}{
+		{name: "playing", isLive: true, playData: JSONB{"status": "playing", "current_turn": userID.String()}, want: &userID},
+		{name: "waiting for secrets", isLive: true, playData: JSONB{"status": "waiting_secrets", "current_turn": userID.String()}},
+		{name: "completed", isLive: true, playData: JSONB{"status": "completed", "current_turn": userID.String()}},
+		{name: "ended", isLive: false, playData: JSONB{"status": "playing", "current_turn": userID.String()}},
+		{name: "no turn", isLive: true, playData: JSONB{"status": "playing"}},
+		{name: "invalid turn", isLive: true, playData: JSONB{"status": "playing", "current_turn": "nobody"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A stale value from an earlier save must not survive
+			stale := uuid.New()
+			play := &Play{IsLive: tt.isLive, PlayData: tt.playData, CurrentTurnUserID: &stale}
+			if err := play.BeforeSave(nil); err != nil {
+				t.Fatalf("BeforeSave() error = %v", err)
+			}
+			if !reflect.DeepEqual(play.CurrentTurnUserID, tt.want) {
+				t.Errorf("CurrentTurnUserID = %v, want %v", play.CurrentTurnUserID, tt.want)
+			}
+		})
+	}
+}
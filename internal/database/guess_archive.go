@@ -0,0 +1,89 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArchivedGuessCountKey is the PlayData key counting guesses moved out of the play row into GuessArchive
+const ArchivedGuessCountKey = "archived_guess_count"
+
+// GuessArchive holds a guess moved out of a long play's PlayData to keep the row small
+// Seq is the guess's position in the play's full history, starting at 0
+type GuessArchive struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlayID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_guess_archives_play_seq" json:"play_id"`
+	Seq       int       `gorm:"not null;uniqueIndex:idx_guess_archives_play_seq" json:"seq"`
+	Guess     JSONB     `gorm:"type:jsonb;not null" json:"guess"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (g *GuessArchive) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// ArchivedGuessCount returns how many of a play's guesses have been archived
+func ArchivedGuessCount(playData JSONB) int {
+	switch count := playData[ArchivedGuessCountKey].(type) {
+	case int:
+		return count
+	case float64:
+		// Numbers loaded from the database are float64
+		return int(count)
+	}
+	return 0
+}
+
+// UpdatePlayArchivingGuesses saves a play, first moving its oldest guesses into the archive
+// so at most keep guesses stay in PlayData; fails with ErrStalePlay like UpdatePlay
+func (r *PlayRepository) UpdatePlayArchivingGuesses(play *Play, keep int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		guesses, _ := play.PlayData["guesses"].([]interface{})
+		if overflow := len(guesses) - keep; overflow > 0 {
+			archived := ArchivedGuessCount(play.PlayData)
+			for i, g := range guesses[:overflow] {
+				guess, ok := g.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				entry := &GuessArchive{PlayID: play.ID, Seq: archived + i, Guess: JSONB(guess)}
+				if err := tx.Create(entry).Error; err != nil {
+					return err
+				}
+			}
+			play.PlayData["guesses"] = guesses[overflow:]
+			play.PlayData[ArchivedGuessCountKey] = archived + overflow
+		}
+		return saveVersionedPlay(tx, play)
+	})
+}
+
+// FindGuessHistory returns a play's full guess history in order: archived guesses followed by those in PlayData
+func (r *PlayRepository) FindGuessHistory(play *Play) ([]interface{}, error) {
+	guesses, _ := play.PlayData["guesses"].([]interface{})
+	if ArchivedGuessCount(play.PlayData) == 0 {
+		return guesses, nil
+	}
+
+	var archived []GuessArchive
+	if err := r.db.Where("play_id = ?", play.ID).Order("seq ASC").Find(&archived).Error; err != nil {
+		return nil, err
+	}
+
+	history := make([]interface{}, 0, len(archived)+len(guesses))
+	for _, entry := range archived {
+		history = append(history, map[string]interface{}(entry.Guess))
+	}
+	return append(history, guesses...), nil
+}
+
+// DeleteGuessArchive deletes every archived guess of a play
+func (r *PlayRepository) DeleteGuessArchive(playID uuid.UUID) error {
+	return r.db.Where("play_id = ?", playID).Delete(&GuessArchive{}).Error
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type      string     `gorm:"type:varchar(50);not null;index" json:"type"` // game_request, partner_request
+	ActorID   *uuid.UUID `gorm:"type:uuid;index" json:"actor_id"`
+	GameID    *uuid.UUID `gorm:"type:uuid;index" json:"game_id"`
+	Message   string     `gorm:"type:text;not null" json:"message"`
+	Read      bool       `gorm:"not null;default:false;index" json:"read"`
+	Count     int        `gorm:"not null;default:1" json:"count"` // Number of events collapsed into this notification
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// NotificationRepository handles notification database operations
+type NotificationRepository struct {
+	db          *gorm.DB
+	dedupWindow time.Duration
+}
+
+// NewNotificationRepository creates a new notification repository
+// Unread notifications of the same type and subject created within dedupWindow are collapsed into one (0 disables dedup)
+func NewNotificationRepository(db *gorm.DB, dedupWindow time.Duration) *NotificationRepository {
+	return &NotificationRepository{db: db, dedupWindow: dedupWindow}
+}
+
+// Create creates a new notification, or bumps a recent unread duplicate instead of adding a new row
+func (r *NotificationRepository) Create(notification *Notification) error {
+	if r.dedupWindow > 0 {
+		existing, err := r.findRecentDuplicate(notification)
+		if err == nil {
+			existing.Message = notification.Message
+			existing.Count++
+			existing.UpdatedAt = time.Now()
+			if err := r.db.Save(existing).Error; err != nil {
+				return err
+			}
+			*notification = *existing
+			return nil
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+	}
+
+	return r.db.Create(notification).Error
+}
+
+// findRecentDuplicate finds an unread notification with the same recipient, type and subject updated within the dedup window
+func (r *NotificationRepository) findRecentDuplicate(notification *Notification) (*Notification, error) {
+	var existing Notification
+	query := r.db.Where("user_id = ? AND type = ? AND read = ? AND updated_at > ?",
+		notification.UserID, notification.Type, false, time.Now().Add(-r.dedupWindow))
+
+	if notification.ActorID != nil {
+		query = query.Where("actor_id = ?", *notification.ActorID)
+	} else {
+		query = query.Where("actor_id IS NULL")
+	}
+	if notification.GameID != nil {
+		query = query.Where("game_id = ?", *notification.GameID)
+	} else {
+		query = query.Where("game_id IS NULL")
+	}
+
+	err := query.Order("updated_at DESC").First(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// NotificationFilter holds optional filters and pagination for notification queries
+type NotificationFilter struct {
+	Type string // Only notifications of this type
+	Read *bool  // Only read (true) or unread (false) notifications
+	Pagination
+}
+
+// FindByUser finds a page of a user's notifications matching the filter, most recent first,
+// along with the total number matching
+func (r *NotificationRepository) FindByUser(userID uuid.UUID, filter NotificationFilter) ([]Notification, int64, error) {
+	query := r.db.Model(&Notification{}).Where("user_id = ?", userID)
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Read != nil {
+		query = query.Where("read = ?", *filter.Read)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []Notification
+	err := query.
+		Order("updated_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&notifications).Error
+	return notifications, total, err
+}
+
+// FindByID finds a notification by ID
+func (r *NotificationRepository) FindByID(id uuid.UUID) (*Notification, error) {
+	var notification Notification
+	err := r.db.Where("id = ?", id).First(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// MarkAsRead marks a notification as read
+func (r *NotificationRepository) MarkAsRead(id uuid.UUID) error {
+	return r.db.Model(&Notification{}).Where("id = ?", id).Update("read", true).Error
+}
+
+// MarkAllRead marks all of a user's unread notifications as read and returns how many were updated
+func (r *NotificationRepository) MarkAllRead(userID uuid.UUID) (int64, error) {
+	result := r.db.Model(&Notification{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Update("read", true)
+	return result.RowsAffected, result.Error
+}
+
+// CountUnreadByType counts unread notifications for a user, per notification type
+func (r *NotificationRepository) CountUnreadByType(userID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		Type  string
+		Count int64
+	}
+	err := r.db.Model(&Notification{}).
+		Select("type, COUNT(*) AS count").
+		Where("user_id = ? AND read = ?", userID, false).
+		Group("type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// CountUnread counts unread notifications for a user
+func (r *NotificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&Notification{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
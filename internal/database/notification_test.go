@@ -0,0 +1,87 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNotificationDedup(t *testing.T) {
+	db := openTestDB(t)
+	user := createTestUser(t, db)
+	actor := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("user_id = ?", user.ID).Delete(&Notification{}) })
+
+	notify := func(repo *NotificationRepository, message string) *Notification {
+		t.Helper()
+		notification := &Notification{UserID: user.ID, Type: "game_request", ActorID: &actor.ID, Message: message}
+		if err := repo.Create(notification); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return notification
+	}
+	countRows := func() int64 {
+		t.Helper()
+		var count int64
+		if err := db.Model(&Notification{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+			t.Fatalf("failed to count notifications: %v", err)
+		}
+		return count
+	}
+
+	repo := NewNotificationRepository(db, time.Hour)
+	first := notify(repo, "first")
+	second := notify(repo, "second")
+	if second.ID != first.ID || second.Count != 2 || second.Message != "second" {
+		t.Errorf("duplicate = %+v, want notification %s bumped to count 2 with the latest message", second, first.ID)
+	}
+	if count := countRows(); count != 1 {
+		t.Errorf("%d notifications stored, want 1", count)
+	}
+
+	// A read notification isn't bumped, so the user hears about new events
+	if err := repo.MarkAsRead(first.ID); err != nil {
+		t.Fatalf("MarkAsRead() error = %v", err)
+	}
+	if third := notify(repo, "third"); third.ID == first.ID {
+		t.Error("notification was collapsed into a read one")
+	}
+
+	// Without a window every event gets its own notification
+	notify(NewNotificationRepository(db, 0), "fourth")
+	if count := countRows(); count != 3 {
+		t.Errorf("%d notifications stored, want 3", count)
+	}
+}
+
+func TestNotificationMarkAllRead(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewNotificationRepository(db, 0)
+	user := createTestUser(t, db)
+	other := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("user_id IN ?", []uuid.UUID{user.ID, other.ID}).Delete(&Notification{}) })
+
+	for _, userID := range []uuid.UUID{user.ID, user.ID, other.ID} {
+		notification := &Notification{UserID: userID, Type: "game_request", Message: "Play with me"}
+		if err := repo.Create(notification); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	updated, err := repo.MarkAllRead(user.ID)
+	if err != nil {
+		t.Fatalf("MarkAllRead() error = %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("MarkAllRead() = %d, want 2", updated)
+	}
+	if updated, _ := repo.MarkAllRead(user.ID); updated != 0 {
+		t.Errorf("second MarkAllRead() = %d, want 0", updated)
+	}
+
+	// Other users' notifications are untouched
+	if unread, err := repo.CountUnread(other.ID); err != nil || unread != 1 {
+		t.Errorf("CountUnread() for another user = %d, %v, want 1", unread, err)
+	}
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"crypto/subtle"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,7 +12,7 @@ import (
 type OTP struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	Email     string    `gorm:"type:varchar(255);not null;index" json:"email"`
-	Code      string    `gorm:"type:varchar(4);not null" json:"code"`
+	Code      string    `gorm:"type:varchar(10);not null" json:"code"`
 	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
 	Used      bool      `gorm:"default:false" json:"used"`
 	CreatedAt time.Time `json:"created_at"`
@@ -46,21 +47,69 @@ func (r *OTPRepository) Create(otp *OTP) error {
 }
 
 // FindValidOTP finds a valid (not used, not expired) OTP for the given email and code
+// Codes are compared in constant time so response timing doesn't reveal partial matches
 func (r *OTPRepository) FindValidOTP(email, code string) (*OTP, error) {
-	var otp OTP
-	err := r.db.Where("email = ? AND code = ? AND used = ? AND expires_at > ?",
-		email, code, false, time.Now()).
+	var otps []OTP
+	err := r.db.Where("email = ? AND used = ? AND expires_at > ?", email, false, time.Now()).
 		Order("created_at DESC").
-		First(&otp).Error
+		Find(&otps).Error
 	if err != nil {
 		return nil, err
 	}
-	return &otp, nil
+
+	// Check every candidate rather than returning early on a match
+	var match *OTP
+	for i := range otps {
+		if subtle.ConstantTimeCompare([]byte(otps[i].Code), []byte(code)) == 1 && match == nil {
+			match = &otps[i]
+		}
+	}
+	if match == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return match, nil
 }
 
 // MarkAsUsed marks an OTP as used
+// Returns gorm.ErrRecordNotFound if the OTP was already used, so concurrent verifications can't both succeed
 func (r *OTPRepository) MarkAsUsed(id uuid.UUID) error {
-	return r.db.Model(&OTP{}).Where("id = ?", id).Update("used", true).Error
+	result := r.db.Model(&OTP{}).Where("id = ? AND used = ?", id, false).Update("used", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// InvalidateAllForEmail marks every active (unused, unexpired) OTP for an email as used and returns how many were invalidated
+func (r *OTPRepository) InvalidateAllForEmail(email string) (int64, error) {
+	result := r.db.Model(&OTP{}).
+		Where("email = ? AND used = ? AND expires_at > ?", email, false, time.Now()).
+		Update("used", true)
+	return result.RowsAffected, result.Error
+}
+
+// FindRecentOTPTimes returns the creation times of OTPs created for an email in the last N minutes, oldest first
+func (r *OTPRepository) FindRecentOTPTimes(email string, minutes int) ([]time.Time, error) {
+	var times []time.Time
+	since := time.Now().Add(-time.Duration(minutes) * time.Minute)
+	err := r.db.Model(&OTP{}).
+		Where("email = ? AND created_at > ?", email, since).
+		Order("created_at ASC").
+		Pluck("created_at", &times).Error
+	return times, err
+}
+
+// FindMostRecentOTP finds the latest OTP created for an email, used or not
+func (r *OTPRepository) FindMostRecentOTP(email string) (*OTP, error) {
+	var otp OTP
+	err := r.db.Where("email = ?", email).Order("created_at DESC").First(&otp).Error
+	if err != nil {
+		return nil, err
+	}
+	return &otp, nil
 }
 
 // CountRecentOTPs counts OTPs created for an email in the last N minutes
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestOTPSingleUse(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewOTPRepository(db)
+	email := "otp-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() { db.Where("email = ?", email).Delete(&OTP{}) })
+
+	for _, otp := range []*OTP{
+		{Email: email, Code: "1111", ExpiresAt: time.Now().Add(-time.Minute)},
+		{Email: email, Code: "2222", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		if err := repo.Create(otp); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	for _, code := range []string{"1111", "2223", ""} {
+		if _, err := repo.FindValidOTP(email, code); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindValidOTP(%q) error = %v, want %v", code, err, gorm.ErrRecordNotFound)
+		}
+	}
+
+	otp, err := repo.FindValidOTP(email, "2222")
+	if err != nil {
+		t.Fatalf("FindValidOTP() error = %v", err)
+	}
+	if err := repo.MarkAsUsed(otp.ID); err != nil {
+		t.Fatalf("MarkAsUsed() error = %v", err)
+	}
+	// A concurrent verification that found the same code loses the race
+	if err := repo.MarkAsUsed(otp.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("second MarkAsUsed() error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+	if _, err := repo.FindValidOTP(email, "2222"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("FindValidOTP() for a used code error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
+
+func TestInvalidateAllForEmail(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewOTPRepository(db)
+	email := "otp-" + uuid.NewString() + "@example.com"
+	other := "otp-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() { db.Where("email IN ?", []string{email, other}).Delete(&OTP{}) })
+
+	for _, otp := range []*OTP{
+		{Email: email, Code: "1111", ExpiresAt: time.Now().Add(time.Hour)},
+		{Email: email, Code: "2222", ExpiresAt: time.Now().Add(time.Hour)},
+		{Email: email, Code: "3333", ExpiresAt: time.Now().Add(-time.Minute)},
+		{Email: email, Code: "4444", ExpiresAt: time.Now().Add(time.Hour), Used: true},
+		{Email: other, Code: "1111", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		if err := repo.Create(otp); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	invalidated, err := repo.InvalidateAllForEmail(email)
+	if err != nil {
+		t.Fatalf("InvalidateAllForEmail() error = %v", err)
+	}
+	// Expired and already used codes aren't counted
+	if invalidated != 2 {
+		t.Errorf("InvalidateAllForEmail() = %d, want 2", invalidated)
+	}
+	for _, code := range []string{"1111", "2222"} {
+		if _, err := repo.FindValidOTP(email, code); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindValidOTP(%q) after invalidation error = %v, want %v", code, err, gorm.ErrRecordNotFound)
+		}
+	}
+	if _, err := repo.FindValidOTP(other, "1111"); err != nil {
+		t.Errorf("FindValidOTP() for another email error = %v, want it untouched", err)
+	}
+}
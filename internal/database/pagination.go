@@ -0,0 +1,7 @@
+package database
+
+// Pagination selects a page of a list query
+type Pagination struct {
+	Limit  int // Rows in the page
+	Offset int // Rows skipped before the page
+}
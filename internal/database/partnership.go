@@ -14,6 +14,9 @@ type PartnerRequest struct {
 	RecipientEmail string     `gorm:"type:varchar(255);not null;index" json:"recipient_email"`
 	RecipientID    *uuid.UUID `gorm:"type:uuid;index" json:"recipient_id"`
 	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // pending, accepted, rejected, cancelled
+	AcceptTokenID  *uuid.UUID `gorm:"type:uuid" json:"-"`                                              // ID of the outstanding emailed accept link, cleared when used
+	EmailedAt      *time.Time `json:"emailed_at"`                                                      // When the email was last resent; nil if only sent on creation
+	GameID         *uuid.UUID `gorm:"type:uuid" json:"game_id,omitempty"`                              // Game requested once accepted, when the invite was sent from PlayGame
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 
@@ -37,6 +40,10 @@ type Partnership struct {
 	User2ID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex;index" json:"user2_id"`
 	CreatedAt time.Time `json:"created_at"`
 
+	// Shared metadata both partners can read and update (nickname, theme, ...)
+	Metadata        JSONB `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
+	MetadataVersion int   `gorm:"not null;default:0" json:"metadata_version"` // Incremented on every metadata update
+
 	// Relations
 	User1 User `gorm:"foreignKey:User1ID" json:"user1,omitempty"`
 	User2 User `gorm:"foreignKey:User2ID" json:"user2,omitempty"`
@@ -60,6 +67,14 @@ func NewPartnershipRepository(db *gorm.DB) *PartnershipRepository {
 	return &PartnershipRepository{db: db}
 }
 
+// Transaction runs fn with a repository bound to a single database transaction
+// The transaction is rolled back if fn returns an error
+func (r *PartnershipRepository) Transaction(fn func(repo *PartnershipRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewPartnershipRepository(tx))
+	})
+}
+
 // CreateRequest creates a new partner request
 func (r *PartnershipRepository) CreateRequest(request *PartnerRequest) error {
 	return r.db.Create(request).Error
@@ -106,11 +121,52 @@ func (r *PartnershipRepository) FindPendingRequestsByRecipient(recipientID uuid.
 	return requests, err
 }
 
+// CountPendingRequestsByRecipient counts pending requests addressed to the user by ID or email
+func (r *PartnershipRepository) CountPendingRequestsByRecipient(recipientID uuid.UUID, recipientEmail string) (int64, error) {
+	var count int64
+	err := r.db.Model(&PartnerRequest{}).
+		Where("(recipient_id = ? OR recipient_email = ?) AND status = ?", recipientID, recipientEmail, "pending").
+		Count(&count).Error
+	return count, err
+}
+
 // UpdateRequest updates a partner request
 func (r *PartnershipRepository) UpdateRequest(request *PartnerRequest) error {
 	return r.db.Save(request).Error
 }
 
+// ClaimRequestResend marks a pending request as emailed now if its email wasn't sent within the cooldown
+// The creation email counts as sent at creation; returns false if the request isn't pending or is cooling down
+func (r *PartnershipRepository) ClaimRequestResend(requestID uuid.UUID, cooldown time.Duration) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&PartnerRequest{}).
+		Where("id = ? AND status = ? AND COALESCE(emailed_at, created_at) <= ?", requestID, "pending", now.Add(-cooldown)).
+		Update("emailed_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SetAcceptToken records the ID of the accept link token issued for a request
+func (r *PartnershipRepository) SetAcceptToken(requestID, tokenID uuid.UUID) error {
+	return r.db.Model(&PartnerRequest{}).
+		Where("id = ?", requestID).
+		Update("accept_token_id", tokenID).Error
+}
+
+// ConsumeAcceptToken clears a request's accept link token if it still matches tokenID
+// Returns false if the token was already used or replaced
+func (r *PartnershipRepository) ConsumeAcceptToken(requestID, tokenID uuid.UUID) (bool, error) {
+	result := r.db.Model(&PartnerRequest{}).
+		Where("id = ? AND accept_token_id = ?", requestID, tokenID).
+		Update("accept_token_id", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // CancelPendingRequestsByUser cancels all pending requests for a user (both sent and received)
 func (r *PartnershipRepository) CancelPendingRequestsByUser(userID uuid.UUID) error {
 	return r.db.Model(&PartnerRequest{}).
@@ -136,6 +192,48 @@ func (r *PartnershipRepository) FindPartnershipByUser(userID uuid.UUID) (*Partne
 	return &partnership, nil
 }
 
+// UpdateMetadata replaces a partnership's metadata if its version still matches expectedVersion
+// Returns false if another update landed first
+func (r *PartnershipRepository) UpdateMetadata(partnershipID uuid.UUID, metadata JSONB, expectedVersion int) (bool, error) {
+	result := r.db.Model(&Partnership{}).
+		Where("id = ? AND metadata_version = ?", partnershipID, expectedVersion).
+		Updates(map[string]interface{}{
+			"metadata":         metadata,
+			"metadata_version": expectedVersion + 1,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindPartnershipByID finds a partnership by ID
+func (r *PartnershipRepository) FindPartnershipByID(id uuid.UUID) (*Partnership, error) {
+	var partnership Partnership
+	err := r.db.Where("id = ?", id).
+		Preload("User1").
+		Preload("User2").
+		First(&partnership).Error
+	if err != nil {
+		return nil, err
+	}
+	return &partnership, nil
+}
+
+// FindPartnershipBetween finds the partnership between two users, in either order
+func (r *PartnershipRepository) FindPartnershipBetween(userID, otherUserID uuid.UUID) (*Partnership, error) {
+	var partnership Partnership
+	err := r.db.Where("(user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)",
+		userID, otherUserID, otherUserID, userID).
+		Preload("User1").
+		Preload("User2").
+		First(&partnership).Error
+	if err != nil {
+		return nil, err
+	}
+	return &partnership, nil
+}
+
 // DeletePartnership deletes a partnership
 func (r *PartnershipRepository) DeletePartnership(partnershipID uuid.UUID) error {
 	return r.db.Delete(&Partnership{}, partnershipID).Error
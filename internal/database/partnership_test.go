@@ -0,0 +1,39 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestFindPartnershipBetween(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPartnershipRepository(db)
+	user1 := createTestUser(t, db)
+	user2 := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+
+	partnership := &Partnership{User1ID: user1.ID, User2ID: user2.ID}
+	if err := repo.CreatePartnership(partnership); err != nil {
+		t.Fatalf("CreatePartnership() error = %v", err)
+	}
+	t.Cleanup(func() { db.Delete(partnership) })
+
+	// Either member can be named first
+	for _, pair := range [][2]*User{{user1, user2}, {user2, user1}} {
+		found, err := repo.FindPartnershipBetween(pair[0].ID, pair[1].ID)
+		if err != nil {
+			t.Fatalf("FindPartnershipBetween() error = %v", err)
+		}
+		if found.ID != partnership.ID || found.User1.ID != user1.ID || found.User2.ID != user2.ID {
+			t.Errorf("FindPartnershipBetween() = %+v, want partnership %s with both users loaded", found, partnership.ID)
+		}
+	}
+
+	for _, pair := range [][2]*User{{user1, stranger}, {stranger, user2}} {
+		if _, err := repo.FindPartnershipBetween(pair[0].ID, pair[1].ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindPartnershipBetween() for non-partners error = %v, want %v", err, gorm.ErrRecordNotFound)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlayMessage is a chat message sent between the partners of a play
+type PlayMessage struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlayID    uuid.UUID `gorm:"type:uuid;not null;index" json:"play_id"`
+	SenderID  uuid.UUID `gorm:"type:uuid;not null" json:"sender_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// PlayChatMute marks a play whose chat messages a participant isn't notified about
+type PlayChatMute struct {
+	PlayID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"play_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (m *PlayMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// PlayMessageRepository handles play chat message database operations
+type PlayMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewPlayMessageRepository creates a new play message repository
+func NewPlayMessageRepository(db *gorm.DB) *PlayMessageRepository {
+	return &PlayMessageRepository{db: db}
+}
+
+// Create saves a play message
+func (r *PlayMessageRepository) Create(message *PlayMessage) error {
+	return r.db.Create(message).Error
+}
+
+// FindByPlay finds a play's messages, newest first, along with the total count
+func (r *PlayMessageRepository) FindByPlay(playID uuid.UUID, page Pagination) ([]PlayMessage, int64, error) {
+	query := r.db.Model(&PlayMessage{}).Where("play_id = ?", playID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	messages := []PlayMessage{}
+	err := query.
+		Order("created_at DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(&messages).Error
+	return messages, total, err
+}
+
+// SetChatMuted mutes or unmutes a play's chat notifications for a user; repeating either is a no-op
+func (r *PlayMessageRepository) SetChatMuted(playID, userID uuid.UUID, muted bool) error {
+	if !muted {
+		return r.db.Where("play_id = ? AND user_id = ?", playID, userID).Delete(&PlayChatMute{}).Error
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&PlayChatMute{
+		PlayID: playID,
+		UserID: userID,
+	}).Error
+}
+
+// IsChatMuted reports whether a user muted a play's chat notifications
+func (r *PlayMessageRepository) IsChatMuted(playID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&PlayChatMute{}).Where("play_id = ? AND user_id = ?", playID, userID).Count(&count).Error
+	return count > 0, err
+}
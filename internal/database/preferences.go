@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Email categories a user can opt out of
+// Game requests and reminders are only delivered in-app today; their settings apply once they are emailed
+const (
+	EmailCategoryPartnerRequests = "partner_requests"
+	EmailCategoryGameRequests    = "game_requests"
+	EmailCategoryReminders       = "reminders"
+	EmailCategorySummaries       = "summaries"
+)
+
+// UserPreferences holds a user's notification settings
+// Users without a row get DefaultUserPreferences, so every email is on until turned off
+type UserPreferences struct {
+	UserID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	EmailPartnerRequests bool      `gorm:"not null" json:"email_partner_requests"`
+	EmailGameRequests    bool      `gorm:"not null" json:"email_game_requests"`
+	EmailReminders       bool      `gorm:"not null" json:"email_reminders"`
+	EmailSummaries       bool      `gorm:"not null" json:"email_summaries"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// DefaultUserPreferences returns the preferences of a user who hasn't changed any
+func DefaultUserPreferences(userID uuid.UUID) *UserPreferences {
+	return &UserPreferences{
+		UserID:               userID,
+		EmailPartnerRequests: true,
+		EmailGameRequests:    true,
+		EmailReminders:       true,
+		EmailSummaries:       true,
+	}
+}
+
+// AllowsEmail reports whether the user wants emails of the given category
+// Unknown categories are allowed
+func (p *UserPreferences) AllowsEmail(category string) bool {
+	switch category {
+	case EmailCategoryPartnerRequests:
+		return p.EmailPartnerRequests
+	case EmailCategoryGameRequests:
+		return p.EmailGameRequests
+	case EmailCategoryReminders:
+		return p.EmailReminders
+	case EmailCategorySummaries:
+		return p.EmailSummaries
+	default:
+		return true
+	}
+}
+
+// UserPreferencesRepository handles user preference database operations
+type UserPreferencesRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPreferencesRepository creates a new user preferences repository
+func NewUserPreferencesRepository(db *gorm.DB) *UserPreferencesRepository {
+	return &UserPreferencesRepository{db: db}
+}
+
+// FindByUserID finds a user's preferences, falling back to the defaults if they have none saved
+func (r *UserPreferencesRepository) FindByUserID(userID uuid.UUID) (*UserPreferences, error) {
+	var prefs UserPreferences
+	err := r.db.Where("user_id = ?", userID).First(&prefs).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultUserPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// Save creates or replaces a user's preferences
+func (r *UserPreferencesRepository) Save(prefs *UserPreferences) error {
+	prefs.UpdatedAt = time.Now()
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(prefs).Error
+}
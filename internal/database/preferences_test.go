@@ -0,0 +1,59 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUserPreferencesAllowsEmail(t *testing.T) {
+	defaults := DefaultUserPreferences(uuid.New())
+	categories := []string{EmailCategoryPartnerRequests, EmailCategoryGameRequests, EmailCategoryReminders, EmailCategorySummaries}
+	for _, category := range categories {
+		if !defaults.AllowsEmail(category) {
+			t.Errorf("default preferences block %s emails", category)
+		}
+	}
+
+	// Turning one category off leaves the others on
+	prefs := DefaultUserPreferences(uuid.New())
+	prefs.EmailPartnerRequests = false
+	for _, category := range categories {
+		if want := category != EmailCategoryPartnerRequests; prefs.AllowsEmail(category) != want {
+			t.Errorf("AllowsEmail(%s) = %v, want %v", category, !want, want)
+		}
+	}
+	if !prefs.AllowsEmail("unknown") {
+		t.Error("AllowsEmail() blocks an unknown category")
+	}
+}
+
+func TestUserPreferencesSave(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserPreferencesRepository(db)
+	user := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("user_id = ?", user.ID).Delete(&UserPreferences{}) })
+
+	prefs, err := repo.FindByUserID(user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if *prefs != *DefaultUserPreferences(user.ID) {
+		t.Errorf("FindByUserID() without saved preferences = %+v, want the defaults", prefs)
+	}
+
+	// Saving twice updates the same row
+	for _, enabled := range []bool{false, true} {
+		prefs.EmailSummaries = enabled
+		if err := repo.Save(prefs); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		saved, err := repo.FindByUserID(user.ID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if saved.EmailSummaries != enabled || !saved.EmailPartnerRequests {
+			t.Errorf("saved preferences = %+v, want summaries %v and partner requests on", saved, enabled)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRefreshTokenInvalid is returned for unknown, expired and revoked refresh tokens alike
+var ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+
+// RefreshToken is a long-lived token that can be exchanged for new access tokens
+// Only a hash of the token is stored, so a leaked table can't be used to sign in
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"` // Set when rotated or revoked
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// HashRefreshToken returns the stored form of a refresh token
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshTokenRepository handles refresh token database operations
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create stores a new refresh token for a user
+func (r *RefreshTokenRepository) Create(userID uuid.UUID, token string, expiresAt time.Time) error {
+	return r.db.Create(&RefreshToken{
+		UserID:    userID,
+		TokenHash: HashRefreshToken(token),
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+// Rotate exchanges a refresh token for a new one and returns the user it belongs to
+// The old token is revoked in the same transaction, so it can only be used once. Presenting an already
+// revoked token means it was replayed, so every refresh token of that user is revoked as a precaution.
+func (r *RefreshTokenRepository) Rotate(token, newToken string, newExpiresAt time.Time) (uuid.UUID, error) {
+	var userID uuid.UUID
+	var reused bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", HashRefreshToken(token)).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRefreshTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		if existing.RevokedAt != nil {
+			reused = true
+			userID = existing.UserID
+			return ErrRefreshTokenInvalid
+		}
+		if time.Now().After(existing.ExpiresAt) {
+			return ErrRefreshTokenInvalid
+		}
+
+		now := time.Now()
+		if err := tx.Model(&existing).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		userID = existing.UserID
+		return tx.Create(&RefreshToken{
+			UserID:    existing.UserID,
+			TokenHash: HashRefreshToken(newToken),
+			ExpiresAt: newExpiresAt,
+		}).Error
+	})
+
+	if reused {
+		if revokeErr := r.RevokeAllForUser(userID); revokeErr != nil {
+			return uuid.Nil, revokeErr
+		}
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
+
+// Revoke revokes a single refresh token
+func (r *RefreshTokenRepository) Revoke(token string) error {
+	return r.db.Model(&RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL", HashRefreshToken(token)).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForUser revokes every outstanding refresh token of a user
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uuid.UUID) error {
+	return r.db.Model(&RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// PurgeExpired deletes refresh tokens that have expired, revoked or not, and returns how many were deleted
+// Revoked tokens are kept until they expire so Rotate can still recognize a replay
+func (r *RefreshTokenRepository) PurgeExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now()).Delete(&RefreshToken{})
+	return result.RowsAffected, result.Error
+}
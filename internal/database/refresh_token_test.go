@@ -0,0 +1,166 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestHashRefreshToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{name: "same token hashes the same", a: "token", b: "token", equal: true},
+		{name: "different tokens hash differently", a: "token", b: "token2", equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashA, hashB := HashRefreshToken(tt.a), HashRefreshToken(tt.b)
+			if (hashA == hashB) != tt.equal {
+				t.Errorf("HashRefreshToken(%q) == HashRefreshToken(%q) is %v, want %v", tt.a, tt.b, hashA == hashB, tt.equal)
+			}
+			if hashA == tt.a {
+				t.Errorf("HashRefreshToken(%q) returned the token itself", tt.a)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRotate(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRefreshTokenRepository(db)
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		// setup stores tokens for the user and returns the one to present
+		setup         func(t *testing.T, user *User) string
+		wantErr       error
+		wantRevokeAll bool // Every token of the user is revoked afterwards
+	}{
+		{
+			name: "valid token is rotated",
+			setup: func(t *testing.T, user *User) string {
+				return mustCreateRefreshToken(t, repo, user, "valid", expiresAt)
+			},
+		},
+		{
+			name: "unknown token is rejected",
+			setup: func(t *testing.T, user *User) string {
+				return "unknown-" + user.ID.String()
+			},
+			wantErr: ErrRefreshTokenInvalid,
+		},
+		{
+			name: "expired token is rejected",
+			setup: func(t *testing.T, user *User) string {
+				return mustCreateRefreshToken(t, repo, user, "expired", time.Now().Add(-time.Minute))
+			},
+			wantErr: ErrRefreshTokenInvalid,
+		},
+		{
+			name: "reused token revokes every token of the user",
+			setup: func(t *testing.T, user *User) string {
+				token := mustCreateRefreshToken(t, repo, user, "reused", expiresAt)
+				mustCreateRefreshToken(t, repo, user, "other-device", expiresAt)
+				if _, err := repo.Rotate(token, "rotated-"+user.ID.String(), expiresAt); err != nil {
+					t.Fatalf("first rotation failed: %v", err)
+				}
+				return token
+			},
+			wantErr:       ErrRefreshTokenInvalid,
+			wantRevokeAll: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := createTestUser(t, db)
+			t.Cleanup(func() { db.Where("user_id = ?", user.ID).Delete(&RefreshToken{}) })
+			token := tt.setup(t, user)
+
+			userID, err := repo.Rotate(token, "new-"+user.ID.String(), expiresAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rotate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil {
+				if userID != user.ID {
+					t.Errorf("Rotate() user = %v, want %v", userID, user.ID)
+				}
+				if _, err := repo.Rotate(token, "again-"+user.ID.String(), expiresAt); !errors.Is(err, ErrRefreshTokenInvalid) {
+					t.Errorf("second Rotate() of the same token error = %v, want %v", err, ErrRefreshTokenInvalid)
+				}
+			}
+
+			if tt.wantRevokeAll {
+				if outstanding := countOutstandingRefreshTokens(t, db, user); outstanding != 0 {
+					t.Errorf("%d refresh tokens still outstanding after reuse, want 0", outstanding)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshTokenPurgeExpired(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRefreshTokenRepository(db)
+	user := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("user_id = ?", user.ID).Delete(&RefreshToken{}) })
+
+	mustCreateRefreshToken(t, repo, user, "expired", time.Now().Add(-time.Minute))
+	revokedExpired := mustCreateRefreshToken(t, repo, user, "revoked-expired", time.Now().Add(-time.Minute))
+	revoked := mustCreateRefreshToken(t, repo, user, "revoked", time.Now().Add(time.Hour))
+	mustCreateRefreshToken(t, repo, user, "valid", time.Now().Add(time.Hour))
+	for _, token := range []string{revokedExpired, revoked} {
+		if err := repo.Revoke(token); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+	}
+
+	if _, err := repo.PurgeExpired(); err != nil {
+		t.Fatalf("PurgeExpired() error = %v", err)
+	}
+
+	var remaining []RefreshToken
+	if err := db.Where("user_id = ?", user.ID).Find(&remaining).Error; err != nil {
+		t.Fatalf("failed to load refresh tokens: %v", err)
+	}
+	// The unexpired revoked token stays so a replay of it is still detected
+	if len(remaining) != 2 {
+		t.Errorf("%d refresh tokens remain, want the 2 unexpired ones", len(remaining))
+	}
+	for _, token := range remaining {
+		if token.ExpiresAt.Before(time.Now()) {
+			t.Errorf("expired refresh token %s wasn't purged", token.ID)
+		}
+	}
+}
+
+// mustCreateRefreshToken stores a refresh token for a user, failing the test on error
+// The user's ID is appended to name, so tokens of different tests never collide; returns the stored token
+func mustCreateRefreshToken(t *testing.T, repo *RefreshTokenRepository, user *User, name string, expiresAt time.Time) string {
+	t.Helper()
+
+	token := name + "-" + user.ID.String()
+	if err := repo.Create(user.ID, token, expiresAt); err != nil {
+		t.Fatalf("failed to create refresh token: %v", err)
+	}
+	return token
+}
+
+// countOutstandingRefreshTokens returns how many of a user's refresh tokens aren't revoked
+func countOutstandingRefreshTokens(t *testing.T, db *gorm.DB, user *User) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Model(&RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count refresh tokens: %v", err)
+	}
+	return count
+}
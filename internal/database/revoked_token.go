@@ -0,0 +1,71 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedToken denylists an access token by its jti until the token would have expired anyway
+type RevokedToken struct {
+	JTI       string    `gorm:"type:varchar(64);primary_key" json:"jti"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevokedTokenRepository handles revoked token database operations
+type RevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRevokedTokenRepository creates a new revoked token repository
+func NewRevokedTokenRepository(db *gorm.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+// Revoke denylists a token id; revoking an already revoked token is a no-op
+func (r *RevokedTokenRepository) Revoke(jti string, expiresAt time.Time) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&RevokedToken{
+		JTI:       jti,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+// IsRevoked reports whether a token id is denylisted
+func (r *RevokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// TokenRevocation is what revokes an access token: its own denylisting or its user's session revocation
+type TokenRevocation struct {
+	Revoked           bool       // The token's jti is denylisted
+	SessionsRevokedAt *time.Time // When the user's sessions were last revoked; nil if never
+}
+
+// FindTokenRevocation looks up, in one query, whether a token id is denylisted and when its user's
+// sessions were last revoked; fails with gorm.ErrRecordNotFound if the user doesn't exist
+func (r *RevokedTokenRepository) FindTokenRevocation(jti string, userID uuid.UUID) (*TokenRevocation, error) {
+	var revocation TokenRevocation
+	result := r.db.Model(&User{}).
+		Select("EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?) AS revoked, sessions_revoked_at", jti).
+		Where("id = ?", userID).
+		Scan(&revocation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &revocation, nil
+}
+
+// PurgeExpired deletes denylist entries whose tokens have expired and returns how many were deleted
+// An expired token is rejected on its own, so its entry is no longer needed
+func (r *RevokedTokenRepository) PurgeExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now()).Delete(&RevokedToken{})
+	return result.RowsAffected, result.Error
+}
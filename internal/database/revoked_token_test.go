@@ -0,0 +1,72 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestFindTokenRevocation(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRevokedTokenRepository(db)
+	user := createTestUser(t, db)
+
+	jti := uuid.NewString()
+	revocation, err := repo.FindTokenRevocation(jti, user.ID)
+	if err != nil {
+		t.Fatalf("FindTokenRevocation() error = %v", err)
+	}
+	if revocation.Revoked || revocation.SessionsRevokedAt != nil {
+		t.Errorf("revocation = %+v, want nothing revoked", revocation)
+	}
+
+	if err := repo.Revoke(jti, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	t.Cleanup(func() { db.Delete(&RevokedToken{JTI: jti}) })
+	revokedAt := time.Now().Truncate(time.Second)
+	if err := db.Model(user).Update("sessions_revoked_at", revokedAt).Error; err != nil {
+		t.Fatalf("failed to revoke sessions: %v", err)
+	}
+
+	revocation, err = repo.FindTokenRevocation(jti, user.ID)
+	if err != nil {
+		t.Fatalf("FindTokenRevocation() error = %v", err)
+	}
+	if !revocation.Revoked || revocation.SessionsRevokedAt == nil || !revocation.SessionsRevokedAt.Equal(revokedAt) {
+		t.Errorf("revocation = %+v, want the token revoked and sessions revoked at %v", revocation, revokedAt)
+	}
+
+	if _, err := repo.FindTokenRevocation(jti, uuid.New()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("FindTokenRevocation() for a missing user error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
+
+func TestRevokedTokenPurgeExpired(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRevokedTokenRepository(db)
+
+	expired, valid := uuid.NewString(), uuid.NewString()
+	t.Cleanup(func() { db.Where("jti IN ?", []string{expired, valid}).Delete(&RevokedToken{}) })
+	if err := repo.Revoke(expired, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err := repo.Revoke(valid, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	if _, err := repo.PurgeExpired(); err != nil {
+		t.Fatalf("PurgeExpired() error = %v", err)
+	}
+
+	// The expired token is rejected for its expiry alone, so its entry can go
+	if revoked, err := repo.IsRevoked(expired); err != nil || revoked {
+		t.Errorf("IsRevoked(expired) = %v, %v, want the entry purged", revoked, err)
+	}
+	if revoked, err := repo.IsRevoked(valid); err != nil || !revoked {
+		t.Errorf("IsRevoked(valid) = %v, %v, want the entry kept", revoked, err)
+	}
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlayStat holds per-user, per-game, per-opponent totals for completed plays that have been purged
+// Aggregates add these to the counts from remaining plays
+type PlayStat struct {
+	UserID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	GameID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"game_id"`
+	OpponentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"opponent_id"`
+	Plays      int       `gorm:"not null;default:0" json:"plays"`
+	Wins       int       `gorm:"not null;default:0" json:"wins"`
+	Losses     int       `gorm:"not null;default:0" json:"losses"`
+	Draws      int       `gorm:"not null;default:0" json:"draws"` // Completed without a winner
+}
+
+// incrementPlayStat adds a purged completed play to a user's counters against the opponent
+// winnerID is empty for a draw
+func incrementPlayStat(tx *gorm.DB, userID, gameID, opponentID uuid.UUID, winnerID string) error {
+	stat := PlayStat{UserID: userID, GameID: gameID, OpponentID: opponentID, Plays: 1}
+	switch winnerID {
+	case "":
+		stat.Draws = 1
+	case userID.String():
+		stat.Wins = 1
+	default:
+		stat.Losses = 1
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "game_id"}, {Name: "opponent_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"plays":  gorm.Expr("play_stats.plays + ?", 1),
+			"wins":   gorm.Expr("play_stats.wins + ?", stat.Wins),
+			"losses": gorm.Expr("play_stats.losses + ?", stat.Losses),
+			"draws":  gorm.Expr("play_stats.draws + ?", stat.Draws),
+		}),
+	}).Create(&stat).Error
+}
+
+// PlayStatRepository handles play stat database operations
+type PlayStatRepository struct {
+	db *gorm.DB
+}
+
+// NewPlayStatRepository creates a new play stat repository
+func NewPlayStatRepository(db *gorm.DB) *PlayStatRepository {
+	return &PlayStatRepository{db: db}
+}
+
+// FindByUser finds the purged-play counters for a user across games
+func (r *PlayStatRepository) FindByUser(userID uuid.UUID) ([]PlayStat, error) {
+	var stats []PlayStat
+	err := r.db.Where("user_id = ?", userID).Find(&stats).Error
+	return stats, err
+}
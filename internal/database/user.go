@@ -9,15 +9,24 @@ import (
 
 // User represents a user in the database
 type User struct {
-	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Email         string    `gorm:"type:varchar(255);unique;not null;index" json:"email"`
-	Name          string    `gorm:"type:varchar(255);not null" json:"name"`
-	DisplayName   string    `gorm:"type:varchar(100)" json:"display_name"`
-	EmailVerified bool      `gorm:"default:false" json:"email_verified"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                   uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Email                string     `gorm:"type:varchar(255);unique;not null;index" json:"email"`
+	Name                 string     `gorm:"type:varchar(255);not null" json:"name"`
+	DisplayName          string     `gorm:"type:varchar(100)" json:"display_name"`
+	EmailVerified        bool       `gorm:"default:false" json:"email_verified"`
+	DefaultPartnershipID *uuid.UUID `gorm:"type:uuid" json:"-"`                   // Used for game actions that don't name a partner; private to the user
+	IsBot                bool       `gorm:"not null;default:false" json:"is_bot"` // The built-in bot opponent, never a real account
+	SessionsRevokedAt    *time.Time `json:"-"`                                    // Access tokens issued at or before this are rejected
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
 }
 
+// BotUserID is the bot user every player can practice against, seeded by migration 023
+var BotUserID = uuid.MustParse("b0700000-0000-4000-8000-000000000001")
+
+// BotUserEmail is the bot user's address; the reserved .invalid domain never receives mail
+const BotUserEmail = "bot@games.invalid"
+
 // BeforeCreate hook to generate UUID if not set
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == uuid.Nil {
@@ -83,7 +92,79 @@ func (r *UserRepository) FindByID(id uuid.UUID) (*User, error) {
 	return &user, nil
 }
 
+// FindByIDs finds the users with the given IDs; missing IDs are skipped
+func (r *UserRepository) FindByIDs(ids []uuid.UUID) ([]User, error) {
+	var users []User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// SetDefaultPartnership sets (or clears, with nil) the user's default partnership
+func (r *UserRepository) SetDefaultPartnership(userID uuid.UUID, partnershipID *uuid.UUID) error {
+	return r.db.Model(&User{}).Where("id = ?", userID).Update("default_partnership_id", partnershipID).Error
+}
+
+// ClearDefaultPartnership clears the default for every user pointing at the given partnership
+func (r *UserRepository) ClearDefaultPartnership(partnershipID uuid.UUID) error {
+	return r.db.Model(&User{}).
+		Where("default_partnership_id = ?", partnershipID).
+		Update("default_partnership_id", nil).Error
+}
+
 // Update updates a user's information
 func (r *UserRepository) Update(user *User) error {
 	return r.db.Save(user).Error
 }
+
+// UpdateFields updates only the given columns of a user, leaving all others untouched
+func (r *UserRepository) UpdateFields(userID uuid.UUID, fields map[string]interface{}) error {
+	return r.db.Model(&User{}).Where("id = ?", userID).Updates(fields).Error
+}
+
+// ChangeEmail changes a user's email and consolidates references to the old address
+// Pending partner requests sent to either address are repointed to the new email and linked
+// to the user by ID, so the user keeps receiving them. Plays reference users by ID and need no change.
+// Every session of the old identity ends: refresh tokens are revoked, access tokens issued so far are rejected
+// and OTPs sent to the old address can no longer be used.
+func (r *UserRepository) ChangeEmail(userID uuid.UUID, newEmail string) (*User, error) {
+	var user User
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+		oldEmail := user.Email
+
+		now := time.Now()
+		user.Email = newEmail
+		user.SessionsRevokedAt = &now
+		user.UpdatedAt = now
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&OTP{}).
+			Where("email = ? AND used = ?", oldEmail, false).
+			Update("used", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&PartnerRequest{}).
+			Where("status = ? AND (recipient_email = ? OR recipient_email = ? OR recipient_id = ?)", "pending", oldEmail, newEmail, userID).
+			Updates(map[string]interface{}{
+				"recipient_email": newEmail,
+				"recipient_id":    userID,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,110 @@
+package email
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/games-app/backend/internal/config"
+)
+
+// NewClient creates the email client for the configured provider
+func NewClient(cfg *config.Config) (EmailClient, error) {
+	switch cfg.EmailProvider {
+	case "mailgun":
+		return NewMailgunClient(cfg.MailgunAPIKey, cfg.MailgunDomain, cfg.MailgunBaseURL, cfg.MailgunFromEmail, sendTimeout(cfg.EmailSendTimeoutSeconds)), nil
+	case "smtp":
+		return newProviderClient(cfg, "smtp")
+	default:
+		// Default to Gmail
+		return newGmailClientFromConfig(cfg)
+	}
+}
+
+// newGmailClientFromConfig creates a Gmail client from the configured token
+func newGmailClientFromConfig(cfg *config.Config) (EmailClient, error) {
+	client, err := NewGmailClient(cfg.GmailTokenPath, cfg.GmailTokenJSON, cfg.GmailFromEmail, sendTimeout(cfg.EmailSendTimeoutSeconds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Gmail client: %w", err)
+	}
+	return client, nil
+}
+
+// SwitchableClient is an EmailClient whose underlying provider can be changed at runtime
+type SwitchableClient struct {
+	cfg      *config.Config
+	mu       sync.RWMutex
+	provider string
+	client   EmailClient
+}
+
+// NewSwitchableClient creates a switchable client starting with the configured provider
+// If a provider chain is configured, it starts with a FallbackClient over the chain instead
+func NewSwitchableClient(cfg *config.Config) (*SwitchableClient, error) {
+	if len(cfg.EmailProviderChain) > 0 {
+		fallback, err := newFallbackClient(cfg, cfg.EmailProviderChain)
+		if err != nil {
+			return nil, err
+		}
+		return &SwitchableClient{
+			cfg:      cfg,
+			provider: fallback.Primary(),
+			client:   fallback,
+		}, nil
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := cfg.EmailProvider
+	if provider != "mailgun" && provider != "smtp" {
+		provider = "gmail"
+	}
+
+	return &SwitchableClient{
+		cfg:      cfg,
+		provider: provider,
+		client:   client,
+	}, nil
+}
+
+// Provider returns the name of the active provider
+func (s *SwitchableClient) Provider() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.provider
+}
+
+// Switch re-initializes the client for the given provider and makes it active
+// The active provider is left unchanged if the target provider isn't configured
+// Switching replaces any provider chain with the single provider
+func (s *SwitchableClient) Switch(provider string) error {
+	client, err := newProviderClient(s.cfg, provider)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provider = provider
+	s.client = client
+	return nil
+}
+
+// current returns the active client
+func (s *SwitchableClient) current() EmailClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.client
+}
+
+// SendOTPEmail sends an OTP code using the active provider
+func (s *SwitchableClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	return s.current().SendOTPEmail(toEmail, otpCode, expiryMinutes)
+}
+
+// SendTemplated sends a templated email using the active provider
+func (s *SwitchableClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	return s.current().SendTemplated(toEmail, templateName, data)
+}
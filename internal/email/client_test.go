@@ -0,0 +1,42 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/games-app/backend/internal/config"
+)
+
+func TestSwitchableClientSwitch(t *testing.T) {
+	cfg := &config.Config{
+		EmailProvider: "mailgun",
+		MailgunAPIKey: "key",
+		MailgunDomain: "mg.example.com",
+	}
+	client, err := NewSwitchableClient(cfg)
+	if err != nil {
+		t.Fatalf("NewSwitchableClient() error = %v", err)
+	}
+	if client.Provider() != "mailgun" {
+		t.Fatalf("Provider() = %q, want mailgun", client.Provider())
+	}
+
+	// An unconfigured provider leaves the active one in place
+	if err := client.Switch("smtp"); err == nil {
+		t.Error("Switch() to unconfigured smtp succeeded, want an error")
+	}
+	if err := client.Switch("carrier-pigeon"); err == nil {
+		t.Error("Switch() to an unknown provider succeeded, want an error")
+	}
+	if client.Provider() != "mailgun" {
+		t.Errorf("Provider() = %q after failed switches, want mailgun", client.Provider())
+	}
+
+	cfg.SMTPHost = "smtp.example.com"
+	cfg.SMTPPort = "587"
+	if err := client.Switch("smtp"); err != nil {
+		t.Fatalf("Switch() error = %v", err)
+	}
+	if _, ok := client.current().(*SMTPClient); !ok || client.Provider() != "smtp" {
+		t.Errorf("active client = %T (%q), want the SMTP client", client.current(), client.Provider())
+	}
+}
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// providerClient is an initialized client for a named provider
+type providerClient struct {
+	name   string
+	client EmailClient
+}
+
+// FallbackClient is an EmailClient that sends through its providers in order,
+// moving on to the next provider whenever a send fails
+type FallbackClient struct {
+	providers []providerClient
+}
+
+// newProviderClient initializes the client for a single provider, failing if it isn't configured
+func newProviderClient(cfg *config.Config, provider string) (EmailClient, error) {
+	switch provider {
+	case "gmail":
+		return newGmailClientFromConfig(cfg)
+	case "mailgun":
+		if cfg.MailgunAPIKey == "" || cfg.MailgunDomain == "" {
+			return nil, fmt.Errorf("mailgun is not configured (MAILGUN_API_KEY and MAILGUN_DOMAIN are required)")
+		}
+		return NewMailgunClient(cfg.MailgunAPIKey, cfg.MailgunDomain, cfg.MailgunBaseURL, cfg.MailgunFromEmail, sendTimeout(cfg.EmailSendTimeoutSeconds)), nil
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("smtp is not configured (SMTP_HOST is required)")
+		}
+		return NewSMTPClient(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFromEmail, sendTimeout(cfg.EmailSendTimeoutSeconds)), nil
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", provider)
+	}
+}
+
+// newFallbackClient initializes every provider in the chain that can be, in order
+// Providers that fail to initialize are logged and skipped; it is an error if none initialize
+func newFallbackClient(cfg *config.Config, chain []string) (*FallbackClient, error) {
+	logger := logging.Component("email")
+
+	fallback := &FallbackClient{}
+	for _, provider := range chain {
+		client, err := newProviderClient(cfg, provider)
+		if err != nil {
+			logger.Warn("skipping email provider", "provider", provider, "error", err)
+			continue
+		}
+		fallback.providers = append(fallback.providers, providerClient{name: provider, client: client})
+	}
+
+	if len(fallback.providers) == 0 {
+		return nil, fmt.Errorf("no email provider in chain %v could be initialized", chain)
+	}
+	logger.Info("email provider chain initialized", "primary", fallback.Primary(), "providers", len(fallback.providers))
+	return fallback, nil
+}
+
+// Primary returns the name of the first provider tried
+func (f *FallbackClient) Primary() string {
+	return f.providers[0].name
+}
+
+// SendOTPEmail sends an OTP code through the first provider that succeeds
+func (f *FallbackClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	return f.send(func(client EmailClient) error {
+		return client.SendOTPEmail(toEmail, otpCode, expiryMinutes)
+	})
+}
+
+// SendTemplated sends a templated email through the first provider that succeeds
+func (f *FallbackClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	return f.send(func(client EmailClient) error {
+		return client.SendTemplated(toEmail, templateName, data)
+	})
+}
+
+// send tries each provider in order and returns the joined errors if all of them fail
+func (f *FallbackClient) send(sendWith func(client EmailClient) error) error {
+	var errs []error
+	for _, provider := range f.providers {
+		err := sendWith(provider.client)
+		if err == nil {
+			return nil
+		}
+		logging.Component("email").Warn("email provider failed to send", "provider", provider.name, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", provider.name, err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package email
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/games-app/backend/internal/config"
+)
+
+// stubClient is an EmailClient that fails with err, counting its sends
+type stubClient struct {
+	err   error
+	sends int
+}
+
+func (s *stubClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	s.sends++
+	return s.err
+}
+
+func (s *stubClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	s.sends++
+	return s.err
+}
+
+func TestFallbackClientSend(t *testing.T) {
+	errDown := errors.New("provider down")
+
+	t.Run("failing primary falls through to a working secondary", func(t *testing.T) {
+		primary, secondary := &stubClient{err: errDown}, &stubClient{}
+		fallback := &FallbackClient{providers: []providerClient{{name: "gmail", client: primary}, {name: "mailgun", client: secondary}}}
+
+		if err := fallback.SendOTPEmail("user@example.com", "1234", 5); err != nil {
+			t.Fatalf("SendOTPEmail() error = %v", err)
+		}
+		if primary.sends != 1 || secondary.sends != 1 {
+			t.Errorf("sends = %d primary, %d secondary, want 1 each", primary.sends, secondary.sends)
+		}
+	})
+
+	t.Run("working primary is the only one used", func(t *testing.T) {
+		primary, secondary := &stubClient{}, &stubClient{}
+		fallback := &FallbackClient{providers: []providerClient{{name: "gmail", client: primary}, {name: "mailgun", client: secondary}}}
+
+		if err := fallback.SendTemplated("user@example.com", TemplateWelcome, map[string]interface{}{"Name": "Alice"}); err != nil {
+			t.Fatalf("SendTemplated() error = %v", err)
+		}
+		if primary.sends != 1 || secondary.sends != 0 {
+			t.Errorf("sends = %d primary, %d secondary, want 1 and 0", primary.sends, secondary.sends)
+		}
+	})
+
+	t.Run("every provider failing returns their errors", func(t *testing.T) {
+		fallback := &FallbackClient{providers: []providerClient{{name: "gmail", client: &stubClient{err: errDown}}, {name: "mailgun", client: &stubClient{err: errDown}}}}
+
+		if err := fallback.SendOTPEmail("user@example.com", "1234", 5); !errors.Is(err, errDown) {
+			t.Errorf("SendOTPEmail() error = %v, want %v", err, errDown)
+		}
+	})
+}
+
+func TestNewFallbackClientSkipsUnconfiguredProviders(t *testing.T) {
+	cfg := &config.Config{MailgunAPIKey: "key", MailgunDomain: "mg.example.com", SMTPHost: "smtp.example.com", SMTPPort: "587"}
+
+	fallback, err := newFallbackClient(cfg, []string{"smtp", "mailgun"})
+	if err != nil {
+		t.Fatalf("newFallbackClient() error = %v", err)
+	}
+	if fallback.Primary() != "smtp" || len(fallback.providers) != 2 {
+		t.Errorf("primary = %q with %d providers, want smtp with 2", fallback.Primary(), len(fallback.providers))
+	}
+
+	cfg.SMTPHost = ""
+	fallback, err = newFallbackClient(cfg, []string{"smtp", "mailgun"})
+	if err != nil {
+		t.Fatalf("newFallbackClient() error = %v", err)
+	}
+	if fallback.Primary() != "mailgun" {
+		t.Errorf("primary = %q, want mailgun once smtp is unconfigured", fallback.Primary())
+	}
+
+	if _, err := newFallbackClient(&config.Config{}, []string{"smtp", "mailgun"}); err == nil {
+		t.Error("newFallbackClient() with nothing configured succeeded, want an error")
+	}
+}
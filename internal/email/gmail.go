@@ -17,8 +17,9 @@ import (
 
 // GmailClient handles email sending via Gmail API
 type GmailClient struct {
-	service   *gmail.Service
-	fromEmail string
+	service     *gmail.Service
+	fromEmail   string
+	sendTimeout time.Duration
 }
 
 // TokenData represents the token.json structure
@@ -33,7 +34,8 @@ type TokenData struct {
 }
 
 // NewGmailClient creates a new Gmail client using token.json or token JSON from env var
-func NewGmailClient(tokenPath string, tokenJSON string, fromEmail string) (*GmailClient, error) {
+// Sends are abandoned after sendTimeout (0 disables the timeout)
+func NewGmailClient(tokenPath string, tokenJSON string, fromEmail string, sendTimeout time.Duration) (*GmailClient, error) {
 	// Read token from env var first, then fall back to file
 	var tokenData *TokenData
 	var err error
@@ -105,21 +107,40 @@ func NewGmailClient(tokenPath string, tokenJSON string, fromEmail string) (*Gmai
 	}
 
 	return &GmailClient{
-		service:   service,
-		fromEmail: fromEmail,
+		service:     service,
+		fromEmail:   fromEmail,
+		sendTimeout: sendTimeout,
 	}, nil
 }
 
 // SendOTPEmail sends an OTP code to the specified email via Gmail API
-func (c *GmailClient) SendOTPEmail(toEmail, otpCode string) error {
+func (c *GmailClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	return c.SendTemplated(toEmail, TemplateOTP, map[string]interface{}{
+		"Code":          otpCode,
+		"ExpiryMinutes": expiryMinutes,
+	})
+}
+
+// SendTemplated renders the named template with data and sends it to the specified email via Gmail API
+func (c *GmailClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	rendered, err := renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	return c.send(toEmail, rendered.Subject, rendered.HTML)
+}
+
+// send sends an HTML email with the given subject via Gmail API
+func (c *GmailClient) send(toEmail, subject, html string) error {
 	// Create email message in RFC 2822 format
 	message := fmt.Sprintf("From: %s\r\n", c.fromEmail)
 	message += fmt.Sprintf("To: %s\r\n", toEmail)
-	message += "Subject: Your Games Verification Code\r\n"
+	message += fmt.Sprintf("Subject: %s\r\n", subject)
 	message += "MIME-Version: 1.0\r\n"
 	message += "Content-Type: text/html; charset=UTF-8\r\n"
 	message += "\r\n"
-	message += fmt.Sprintf(`<h2>Your Verification Code</h2><p>Your verification code is: <strong>%s</strong></p><p>This code will expire in 5 minutes.</p>`, otpCode)
+	message += html
 
 	// Encode message in base64url format (URL-safe, no padding)
 	encodedMessage := base64.RawURLEncoding.EncodeToString([]byte(message))
@@ -131,8 +152,16 @@ func (c *GmailClient) SendOTPEmail(toEmail, otpCode string) error {
 
 	// Send the message
 	ctx := context.Background()
+	if c.sendTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.sendTimeout)
+		defer cancel()
+	}
 	_, err := c.service.Users.Messages.Send("me", msg).Context(ctx).Do()
 	if err != nil {
+		if isTimeout(err) {
+			return fmt.Errorf("failed to send email via Gmail API: %w", ErrSendTimeout)
+		}
 		return fmt.Errorf("failed to send email via Gmail API: %w", err)
 	}
 
@@ -201,7 +230,6 @@ func isBase64(s string) bool {
 // parseTokenJSON parses token data from JSON string (supports base64 encoded input)
 func parseTokenJSON(jsonStr string) (*TokenData, error) {
 	jsonStr = strings.TrimSpace(jsonStr)
-	fmt.Println("jsonStr", jsonStr)
 
 	// Check if it looks like base64 or JSON
 	if isBase64(jsonStr) {
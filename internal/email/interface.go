@@ -1,7 +1,32 @@
 package email
 
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrSendTimeout is returned when the email provider doesn't respond within the send timeout
+var ErrSendTimeout = errors.New("email send timed out")
+
+// sendTimeout converts the configured send timeout in seconds to a duration
+func sendTimeout(seconds int) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+// isTimeout reports whether err was caused by the send timeout expiring
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // EmailClient interface for sending emails
+// OTP emails state expiryMinutes, the configured lifetime of the code
 type EmailClient interface {
-	SendOTPEmail(toEmail, otpCode string) error
+	SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error
+	SendTemplated(toEmail, templateName string, data map[string]interface{}) error
 }
-
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/games-app/backend/internal/logging"
 )
 
 // MailgunClient handles email sending via Mailgun API
@@ -14,26 +17,54 @@ type MailgunClient struct {
 	Domain    string
 	BaseURL   string
 	FromEmail string
+
+	httpClient *http.Client
 }
 
 // NewMailgunClient creates a new Mailgun client
-func NewMailgunClient(apiKey, domain, baseURL, fromEmail string) *MailgunClient {
+// Requests to Mailgun are abandoned after timeout (0 disables the timeout)
+func NewMailgunClient(apiKey, domain, baseURL, fromEmail string, timeout time.Duration) *MailgunClient {
 	return &MailgunClient{
-		APIKey:    apiKey,
-		Domain:    domain,
-		BaseURL:   baseURL,
-		FromEmail: fromEmail,
+		APIKey:     apiKey,
+		Domain:     domain,
+		BaseURL:    baseURL,
+		FromEmail:  fromEmail,
+		httpClient: &http.Client{Timeout: timeout},
 	}
 }
 
 // SendOTPEmail sends an OTP code to the specified email
-func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
+func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
 	if c.APIKey == "" {
 		// In development, just log the OTP instead of sending
-		fmt.Printf("[Mailgun] OTP for %s: %s\n", toEmail, otpCode)
+		logging.Component("mailgun").Info("OTP email not sent (no API key)", "to", toEmail, "code", otpCode)
+		return nil
+	}
+
+	return c.SendTemplated(toEmail, TemplateOTP, map[string]interface{}{
+		"Code":          otpCode,
+		"ExpiryMinutes": expiryMinutes,
+	})
+}
+
+// SendTemplated renders the named template with data and sends it to the specified email
+func (c *MailgunClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	rendered, err := renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	if c.APIKey == "" {
+		// In development, just log the email instead of sending
+		logging.Component("mailgun").Info("email not sent (no API key)", "template", templateName, "to", toEmail, "body", rendered.Text)
 		return nil
 	}
 
+	return c.send(toEmail, rendered.Subject, rendered.Text, rendered.HTML)
+}
+
+// send sends an email with the given subject and text/HTML bodies via the Mailgun API
+func (c *MailgunClient) send(toEmail, subject, text, html string) error {
 	// Validate configuration
 	if c.Domain == "" {
 		return fmt.Errorf("mailgun domain is not configured")
@@ -47,7 +78,7 @@ func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
 		fromEmail = fmt.Sprintf("Games <postmaster@%s>", c.Domain)
 	} else {
 		// Check if from email domain matches Mailgun domain
-		emailDomain := extractDomainFromEmail(fromEmail)
+		emailDomain := ExtractDomainFromEmail(fromEmail)
 		if emailDomain != c.Domain {
 			// Extract display name if present, otherwise use default
 			displayName := extractDisplayName(fromEmail)
@@ -55,7 +86,7 @@ func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
 				displayName = "Games"
 			}
 			fromEmail = fmt.Sprintf("%s <postmaster@%s>", displayName, c.Domain)
-			fmt.Printf("[Mailgun] From email domain doesn't match Mailgun domain. Using '%s' instead\n", fromEmail)
+			logging.Component("mailgun").Warn("from email domain doesn't match Mailgun domain", "using", fromEmail)
 		}
 	}
 
@@ -66,9 +97,9 @@ func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
 	data := url.Values{}
 	data.Set("from", fromEmail)
 	data.Set("to", toEmail)
-	data.Set("subject", "Your Games Verification Code")
-	data.Set("text", fmt.Sprintf("Your verification code is: %s\n\nThis code will expire in 5 minutes.", otpCode))
-	data.Set("html", fmt.Sprintf("<h2>Your Verification Code</h2><p>Your verification code is: <strong>%s</strong></p><p>This code will expire in 5 minutes.</p>", otpCode))
+	data.Set("subject", subject)
+	data.Set("text", text)
+	data.Set("html", html)
 
 	req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -79,9 +110,11 @@ func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
 	req.SetBasicAuth("api", c.APIKey)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if isTimeout(err) {
+			return fmt.Errorf("failed to send email via Mailgun: %w", ErrSendTimeout)
+		}
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 	defer resp.Body.Close()
@@ -109,9 +142,9 @@ func (c *MailgunClient) SendOTPEmail(toEmail, otpCode string) error {
 	return nil
 }
 
-// extractDomainFromEmail extracts the domain from an email address
+// ExtractDomainFromEmail extracts the domain from an email address
 // Handles both "email@domain.com" and "Display Name <email@domain.com>" formats
-func extractDomainFromEmail(email string) string {
+func ExtractDomainFromEmail(email string) string {
 	// Extract email from "Display Name <email@domain.com>" format if present
 	if strings.Contains(email, "<") && strings.Contains(email, ">") {
 		start := strings.Index(email, "<")
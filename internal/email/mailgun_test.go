@@ -0,0 +1,49 @@
+package email
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailgunSendOTPEmailStatesExpiry(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		text = r.PostForm.Get("text")
+	}))
+	defer server.Close()
+
+	client := NewMailgunClient("key", "mg.example.com", server.URL, "noreply@mg.example.com", time.Second)
+	if err := client.SendOTPEmail("user@example.com", "1234", 15); err != nil {
+		t.Fatalf("SendOTPEmail() error = %v", err)
+	}
+
+	if !strings.Contains(text, "1234") || !strings.Contains(text, "expire in 15 minutes") {
+		t.Errorf("text = %q, want the code and a 15 minute expiry", text)
+	}
+}
+
+func TestMailgunSendTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewMailgunClient("key", "mg.example.com", server.URL, "noreply@mg.example.com", 50*time.Millisecond)
+	start := time.Now()
+	err := client.SendOTPEmail("user@example.com", "1234", 15)
+	if !errors.Is(err, ErrSendTimeout) {
+		t.Fatalf("SendOTPEmail() error = %v, want %v", err, ErrSendTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("SendOTPEmail() took %v, want it abandoned after the timeout", elapsed)
+	}
+}
@@ -0,0 +1,158 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/games-app/backend/internal/logging"
+)
+
+// SMTPClient handles email sending via an SMTP server
+type SMTPClient struct {
+	Host      string
+	Port      string
+	Username  string // Empty sends without authenticating
+	Password  string
+	FromEmail string
+
+	timeout time.Duration
+}
+
+// NewSMTPClient creates a new SMTP client
+// Each send is abandoned after timeout (0 disables the timeout)
+func NewSMTPClient(host, port, username, password, fromEmail string, timeout time.Duration) *SMTPClient {
+	return &SMTPClient{
+		Host:      host,
+		Port:      port,
+		Username:  username,
+		Password:  password,
+		FromEmail: fromEmail,
+		timeout:   timeout,
+	}
+}
+
+// SendOTPEmail sends an OTP code to the specified email
+func (c *SMTPClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	return c.SendTemplated(toEmail, TemplateOTP, map[string]interface{}{
+		"Code":          otpCode,
+		"ExpiryMinutes": expiryMinutes,
+	})
+}
+
+// SendTemplated renders the named template with data and sends it to the specified email
+func (c *SMTPClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	rendered, err := renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	message, err := buildMIMEMessage(c.FromEmail, toEmail, rendered.Subject, rendered.Text, rendered.HTML)
+	if err != nil {
+		return err
+	}
+
+	if err := c.send(toEmail, message); err != nil {
+		if isTimeout(err) {
+			return fmt.Errorf("failed to send email via SMTP: %w", ErrSendTimeout)
+		}
+		return fmt.Errorf("failed to send email via SMTP: %w", err)
+	}
+	return nil
+}
+
+// send delivers a complete message to the server, upgrading to TLS when the server offers it
+func (c *SMTPClient) send(toEmail string, message []byte) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(c.Host, c.Port), c.timeout)
+	if err != nil {
+		return err
+	}
+	if c.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, c.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(nil); err != nil {
+			return err
+		}
+	}
+	if c.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.Username, c.Password, c.Host)); err != nil {
+			return err
+		}
+	}
+
+	// The envelope sender is the bare address of a "Display Name <email@domain>" from email
+	from, err := mail.ParseAddress(c.FromEmail)
+	if err != nil {
+		return fmt.Errorf("invalid from email %q: %w", c.FromEmail, err)
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(toEmail); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	logging.Component("smtp").Debug("email sent", "to", toEmail)
+	return client.Quit()
+}
+
+// buildMIMEMessage builds a multipart/alternative message with text and HTML bodies
+func buildMIMEMessage(from, to, subject, text, html string) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=UTF-8", content: text},
+		{contentType: "text/html; charset=UTF-8", content: html},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", to)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
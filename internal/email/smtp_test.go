@@ -0,0 +1,50 @@
+package email
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMIMEMessage(t *testing.T) {
+	message, err := buildMIMEMessage("Games <noreply@example.com>", "user@example.com", "Your code: ✓", "plain body", "<p>html body</p>")
+	if err != nil {
+		t.Fatalf("buildMIMEMessage() error = %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(message)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Your code: ✓" {
+		t.Errorf("subject = %q (error %v), want %q", subject, err, "Your code: ✓")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q (error %v), want multipart/alternative", mediaType, err)
+	}
+
+	var bodies []string
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(body))
+	}
+
+	want := []string{"text/plain; charset=UTF-8: plain body", "text/html; charset=UTF-8: <p>html body</p>"}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("parts = %q, want %q", bodies, want)
+	}
+}
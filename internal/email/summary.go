@@ -0,0 +1,35 @@
+package email
+
+import "fmt"
+
+// GameSummary holds the details of a finished play included in a summary email
+type GameSummary struct {
+	GameName     string
+	PlayerName   string
+	OpponentName string
+	WinnerName   string // Empty if the play ended without a winner
+	Won          bool
+	GuessCount   int
+}
+
+// outcome returns a one-line description of the result from the player's perspective
+func (s GameSummary) outcome() string {
+	switch {
+	case s.Won:
+		return fmt.Sprintf("You beat %s!", s.OpponentName)
+	case s.WinnerName != "":
+		return fmt.Sprintf("%s won this round.", s.WinnerName)
+	default:
+		return "The game ended without a winner."
+	}
+}
+
+// TemplateData returns the data for rendering the game summary template
+func (s GameSummary) TemplateData() map[string]interface{} {
+	return map[string]interface{}{
+		"GameName":     s.GameName,
+		"OpponentName": s.OpponentName,
+		"Outcome":      s.outcome(),
+		"GuessCount":   s.GuessCount,
+	}
+}
@@ -0,0 +1,88 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Names of the available email templates
+const (
+	TemplateOTP         = "otp"
+	TemplateGameSummary = "game_summary"
+	TemplateWelcome     = "welcome"
+	TemplatePartner     = "partner_request"
+)
+
+// emailTemplate holds the parsed subject, plain-text and HTML templates for one email type
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// newEmailTemplate parses the subject, text and HTML sources of a template
+func newEmailTemplate(name, subject, text, html string) emailTemplate {
+	return emailTemplate{
+		subject: texttemplate.Must(texttemplate.New(name + "_subject").Parse(subject)),
+		text:    texttemplate.Must(texttemplate.New(name + "_text").Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New(name + "_html").Parse(html)),
+	}
+}
+
+// templates is the registry of templates available to SendTemplated
+var templates = map[string]emailTemplate{
+	TemplateOTP: newEmailTemplate(TemplateOTP,
+		"Your Games Verification Code",
+		"Your verification code is: {{.Code}}\n\nThis code will expire in {{.ExpiryMinutes}} minutes.",
+		"<h2>Your Verification Code</h2><p>Your verification code is: <strong>{{.Code}}</strong></p><p>This code will expire in {{.ExpiryMinutes}} minutes.</p>",
+	),
+	TemplateGameSummary: newEmailTemplate(TemplateGameSummary,
+		"Your {{.GameName}} game summary",
+		"{{.GameName}} vs {{.OpponentName}}\n\n{{.Outcome}}\nTotal guesses: {{.GuessCount}}",
+		"<h2>{{.GameName}} vs {{.OpponentName}}</h2><p>{{.Outcome}}</p><p>Total guesses: <strong>{{.GuessCount}}</strong></p>",
+	),
+	TemplatePartner: newEmailTemplate(TemplatePartner,
+		"{{.SenderName}} wants to be your partner on Games",
+		"{{.SenderName}} ({{.SenderEmail}}) wants to be your partner on Games.\n\nAccept the request: {{.AcceptURL}}\n\nThis link can be used once and expires in {{.ExpiryHours}} hours.",
+		"<h2>{{.SenderName}} wants to be your partner</h2><p>{{.SenderName}} ({{.SenderEmail}}) invited you to play together on Games.</p><p><a href=\"{{.AcceptURL}}\">Accept the request</a></p><p>This link can be used once and expires in {{.ExpiryHours}} hours.</p>",
+	),
+	TemplateWelcome: newEmailTemplate(TemplateWelcome,
+		"Welcome to Games, {{.Name}}!",
+		"Hi {{.Name}},\n\nWelcome to Games! To get started:\n- Invite a partner from the Partners tab\n- Pick a game and send them a request\n- Set your secret and start guessing\n\nHave fun!",
+		"<h2>Welcome to Games, {{.Name}}!</h2><p>To get started:</p><ul><li>Invite a partner from the Partners tab</li><li>Pick a game and send them a request</li><li>Set your secret and start guessing</li></ul><p>Have fun!</p>",
+	),
+}
+
+// renderedEmail is the output of rendering a template
+type renderedEmail struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// renderTemplate renders the named template with the given data
+func renderTemplate(templateName string, data map[string]interface{}) (*renderedEmail, error) {
+	tmpl, exists := templates[templateName]
+	if !exists {
+		return nil, fmt.Errorf("unknown email template: %s", templateName)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", templateName, err)
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", templateName, err)
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", templateName, err)
+	}
+
+	return &renderedEmail{
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
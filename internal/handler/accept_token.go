@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// partnerAcceptTokenPurpose marks tokens that may only be used to accept a partner request
+const partnerAcceptTokenPurpose = "partner_accept"
+
+// partnerAcceptTokenExpiry is how long an emailed accept link stays valid
+const partnerAcceptTokenExpiry = 72 * time.Hour
+
+// partnerAcceptClaims identifies the request and recipient an accept link was issued for
+type partnerAcceptClaims struct {
+	RequestID      uuid.UUID
+	RecipientEmail string
+	TokenID        uuid.UUID
+}
+
+// generatePartnerAcceptToken signs a token scoped to a single partner request and recipient
+func (h *AuthHandler) generatePartnerAcceptToken(requestID uuid.UUID, recipientEmail string, tokenID uuid.UUID) (string, error) {
+	claims := jwt.MapClaims{
+		"purpose":    partnerAcceptTokenPurpose,
+		"request_id": requestID.String(),
+		"email":      recipientEmail,
+		"jti":        tokenID.String(),
+		"exp":        time.Now().Add(partnerAcceptTokenExpiry).Unix(),
+		"iat":        time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(h.jwtSecret)
+}
+
+// verifyPartnerAcceptToken verifies an accept link token and returns its claims
+// Login tokens are rejected because they don't carry the accept purpose
+func (h *AuthHandler) verifyPartnerAcceptToken(tokenString string) (*partnerAcceptClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return h.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	if purpose, _ := claims["purpose"].(string); purpose != partnerAcceptTokenPurpose {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	requestIDStr, _ := claims["request_id"].(string)
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	tokenIDStr, _ := claims["jti"].(string)
+	tokenID, err := uuid.Parse(tokenIDStr)
+	if err != nil {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	recipientEmail, _ := claims["email"].(string)
+	if recipientEmail == "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	return &partnerAcceptClaims{
+		RequestID:      requestID,
+		RecipientEmail: recipientEmail,
+		TokenID:        tokenID,
+	}, nil
+}
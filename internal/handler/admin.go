@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
+	"github.com/games-app/backend/internal/jobs"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// AdminHandler handles operator-only requests
+type AdminHandler struct {
+	config      *config.Config
+	emailClient *email.SwitchableClient
+	playRepo    *database.PlayRepository
+	userRepo    *database.UserRepository
+	pageLimits  pageLimits
+	playHub     *playHub
+}
+
+// NewAdminHandler creates a new admin handler
+// Plays reset by an admin are published to the games handler's WebSocket watchers
+func NewAdminHandler(cfg *config.Config, emailClient *email.SwitchableClient, gamesHandler *GamesHandler) *AdminHandler {
+	return &AdminHandler{
+		config:      cfg,
+		emailClient: emailClient,
+		playRepo:    database.NewPlayRepository(database.DB),
+		userRepo:    database.NewUserRepository(database.DB),
+		pageLimits:  newPageLimits(cfg),
+		playHub:     gamesHandler.playHub,
+	}
+}
+
+// SetEmailProviderRequest represents the request body for switching the email provider
+type SetEmailProviderRequest struct {
+	Provider string `json:"provider" binding:"required,oneof=gmail mailgun smtp"`
+}
+
+// EmailProviderResponse represents the response with the active email provider
+type EmailProviderResponse struct {
+	Provider string `json:"provider"`
+}
+
+// GetEmailProvider handles getting the active email provider
+func (h *AdminHandler) GetEmailProvider(c *gin.Context) {
+	c.JSON(http.StatusOK, EmailProviderResponse{
+		Provider: h.emailClient.Provider(),
+	})
+}
+
+// SetEmailProvider handles switching the active email provider at runtime
+func (h *AdminHandler) SetEmailProvider(c *gin.Context) {
+	var req SetEmailProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.emailClient.Switch(req.Provider); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to switch email provider: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, EmailProviderResponse{
+		Provider: h.emailClient.Provider(),
+	})
+}
+
+// PurgePlaysResponse represents the response for purging old plays
+type PurgePlaysResponse struct {
+	Purged int64 `json:"purged"`
+}
+
+// PurgePlays handles running the play retention purge immediately
+func (h *AdminHandler) PurgePlays(c *gin.Context) {
+	if h.config.PlayRetentionDays <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Play retention is disabled (PLAY_RETENTION_DAYS is 0)"})
+		return
+	}
+
+	purged, err := jobs.PurgeExpiredPlays(h.playRepo, h.config.PlayRetentionDays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge plays: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PurgePlaysResponse{
+		Purged: purged,
+	})
+}
+
+// SuspiciousPlay is a play flagged by the cheat heuristics along with its score
+type SuspiciousPlay struct {
+	Play      database.Play `json:"play"`
+	Suspicion int           `json:"suspicion"`
+}
+
+// GetSuspiciousPlaysResponse represents the response for listing plays flagged for suspected cheating
+type GetSuspiciousPlaysResponse struct {
+	Plays  []SuspiciousPlay `json:"plays"`
+	Total  int64            `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// GetSuspiciousPlays handles listing plays whose cheat suspicion score reaches the configured threshold
+// Pass min_score to use a different threshold
+func (h *AdminHandler) GetSuspiciousPlays(c *gin.Context) {
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	minScore := h.config.CheatSuspicionThreshold
+	if minScoreStr := c.Query("min_score"); minScoreStr != "" {
+		parsed, err := strconv.Atoi(minScoreStr)
+		if err != nil || parsed < 0 || parsed > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_score must be an integer between 0 and 100"})
+			return
+		}
+		minScore = parsed
+	}
+
+	plays, total, err := h.playRepo.FindSuspiciousPlays(minScore, database.PlayFilter{
+		Pagination: page,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+		return
+	}
+
+	suspicious := make([]SuspiciousPlay, 0, len(plays))
+	for _, play := range plays {
+		suspicious = append(suspicious, SuspiciousPlay{
+			Play:      play,
+			Suspicion: *play.Suspicion,
+		})
+	}
+
+	c.JSON(http.StatusOK, GetSuspiciousPlaysResponse{
+		Plays:  suspicious,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// GetJobsResponse represents the response for getting background job statuses
+type GetJobsResponse struct {
+	Healthy bool             `json:"healthy"` // False if any job is stale
+	Jobs    []jobs.JobStatus `json:"jobs"`
+}
+
+// GetJobs handles reporting whether each background job has succeeded recently enough
+func (h *AdminHandler) GetJobs(c *gin.Context) {
+	statuses := jobs.Statuses()
+
+	healthy := true
+	for _, status := range statuses {
+		if !status.Healthy {
+			healthy = false
+		}
+	}
+
+	c.JSON(http.StatusOK, GetJobsResponse{
+		Healthy: healthy,
+		Jobs:    statuses,
+	})
+}
+
+// ResetPlayResponse represents the response for resetting a play
+type ResetPlayResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// ResetPlay handles resetting a stuck live play back to its initial secret-setting state
+// Guesses, turns and secrets are cleared so the partners can restart without a new play
+func (h *AdminHandler) ResetPlay(c *gin.Context) {
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Ended plays are final
+	if !play.IsLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Play has already ended"})
+		return
+	}
+
+	playData, err := initialPlayData(play.Game, play.Partner1ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize play: " + err.Error()})
+		return
+	}
+
+	// The restarted play starts with an empty guess history
+	if err := h.playRepo.DeleteGuessArchive(play.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear archived guesses: " + err.Error()})
+		return
+	}
+
+	play.PlayData = playData
+	play.Score = nil
+	if err := h.playRepo.UpdatePlay(play); err != nil {
+		respondPlayUpdateError(c, err, "Failed to reset play")
+		return
+	}
+	h.playHub.publish(play.ID)
+
+	logging.FromContext(c).Info("play reset by admin", "play_id", play.ID, "admin", c.GetString("email"))
+
+	c.JSON(http.StatusOK, ResetPlayResponse{
+		Play: play,
+	})
+}
+
+// GetConfigResponse represents the response with the effective server configuration
+type GetConfigResponse struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// GetConfig handles getting the effective server configuration with everything not marked safe redacted
+func (h *AdminHandler) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, GetConfigResponse{
+		Config: h.config.Redacted(),
+	})
+}
+
+// ChangeUserEmailRequest represents the request body for changing a user's email
+type ChangeUserEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ChangeUserEmailResponse represents the response for changing a user's email
+type ChangeUserEmailResponse struct {
+	User *database.User `json:"user"`
+}
+
+// ChangeUserEmail handles changing a user's email on their behalf
+// Pending partner requests addressed to the old email follow the user to the new one
+// The user is signed out everywhere and must log in again with the new email
+func (h *AdminHandler) ChangeUserEmail(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req ChangeUserEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(userID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	user, err := h.userRepo.ChangeEmail(userID, req.Email)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Email is already in use"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change email: " + err.Error()})
+		return
+	}
+
+	logging.FromContext(c).Info("user email changed by admin", "target_user_id", user.ID, "admin", c.GetString("email"))
+
+	c.JSON(http.StatusOK, ChangeUserEmailResponse{
+		User: user,
+	})
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestResetPlay(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	h := NewAdminHandler(cfg, nil, NewGamesHandler(cfg, nil, nil))
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	partner1, partner2 := createTestUser(t, db), createTestUser(t, db)
+
+	resetPlay := func(play *database.Play) int {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/admin/plays/"+play.ID.String()+"/reset", nil, uuid.Nil)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.ResetPlay(c)
+		return recorder.Code
+	}
+
+	stuck := createTestPlay(t, db, game, partner1, partner2, database.JSONB{
+		"status":                       "playing",
+		"partner1_secret":              "1234",
+		"partner2_secret":              "5678",
+		"current_turn":                 partner2.ID.String(),
+		"guesses":                      []interface{}{map[string]interface{}{"guess": "1243"}},
+		database.ArchivedGuessCountKey: float64(1),
+	}, true)
+	archived := &database.GuessArchive{PlayID: stuck.ID, Seq: 0, Guess: database.JSONB{"guess": "9876"}}
+	if err := db.Create(archived).Error; err != nil {
+		t.Fatalf("failed to archive guess: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(archived) })
+
+	if status := resetPlay(stuck); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	var stored database.Play
+	if err := db.First(&stored, "id = ?", stuck.ID).Error; err != nil {
+		t.Fatalf("failed to reload play: %v", err)
+	}
+	if len(stored.PlayData) != 0 || !stored.IsLive {
+		t.Errorf("play after reset is_live = %v with data %v, want a live play back in the secret-setting phase", stored.IsLive, stored.PlayData)
+	}
+	var archivedCount int64
+	if err := db.Model(&database.GuessArchive{}).Where("play_id = ?", stuck.ID).Count(&archivedCount).Error; err != nil {
+		t.Fatalf("failed to count archived guesses: %v", err)
+	}
+	if archivedCount != 0 {
+		t.Errorf("%d archived guesses remain, want none", archivedCount)
+	}
+
+	ended := createTestPlay(t, db, game, partner1, partner2, database.JSONB{"status": "completed"}, false)
+	if status := resetPlay(ended); status != http.StatusBadRequest {
+		t.Errorf("status for an ended play = %d, want %d", status, http.StatusBadRequest)
+	}
+}
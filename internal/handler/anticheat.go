@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// Cheat suspicion heuristics, each contributing up to half of the 0-100 score
+const (
+	// Guesses spaced this regularly and quickly look scripted
+	cheatMinTimedIntervals     = 3           // Intervals needed before timing is judged
+	cheatMaxMeanInterval       = time.Second // Mean interval must be below this to count as sub-second
+	cheatMaxIntervalVariation  = 0.2         // Coefficient of variation at or above which timing looks human
+	cheatTimingSuspicionWeight = 50
+
+	// Winning in (near) the minimum number of guesses once is luck; doing it repeatedly is not
+	cheatQuickWinGuesses         = 2  // Wins in at most this many guesses are quick
+	cheatRecentWins              = 10 // Recent wins of the game considered
+	cheatMinQuickWins            = 2  // Quick wins needed before they count at all
+	cheatQuickWinSuspicionWeight = 50
+)
+
+// guessTimingSuspicion scores how machine-like the intervals between a player's guesses are (0-50)
+// Only uniform sub-second intervals score; anything slower or irregular scores 0
+func guessTimingSuspicion(playData database.JSONB, playerID uuid.UUID) int {
+	var timestamps []time.Time
+	guesses, _ := playData["guesses"].([]interface{})
+	for _, g := range guesses {
+		guess, ok := g.(map[string]interface{})
+		if !ok || guess["player_id"] != playerID.String() {
+			continue
+		}
+		timestampStr, _ := guess["timestamp"].(string)
+		timestamp, err := time.Parse(time.RFC3339, timestampStr)
+		if err != nil {
+			continue
+		}
+		timestamps = append(timestamps, timestamp)
+	}
+
+	if len(timestamps)-1 < cheatMinTimedIntervals {
+		return 0
+	}
+
+	intervals := make([]float64, 0, len(timestamps)-1)
+	var sum float64
+	for i := 1; i < len(timestamps); i++ {
+		interval := timestamps[i].Sub(timestamps[i-1]).Seconds()
+		intervals = append(intervals, interval)
+		sum += interval
+	}
+	mean := sum / float64(len(intervals))
+	if mean >= cheatMaxMeanInterval.Seconds() {
+		return 0
+	}
+
+	// Identical intervals (including all zero) are as uniform as it gets
+	variation := 0.0
+	if mean > 0 {
+		var squares float64
+		for _, interval := range intervals {
+			squares += (interval - mean) * (interval - mean)
+		}
+		variation = math.Sqrt(squares/float64(len(intervals))) / mean
+	}
+	if variation >= cheatMaxIntervalVariation {
+		return 0
+	}
+	return int(math.Round(cheatTimingSuspicionWeight * (1 - variation/cheatMaxIntervalVariation)))
+}
+
+// quickWinSuspicion scores how often a player's recent wins took the minimum number of guesses (0-50)
+// winGuesses holds the winner's guess count for each recent win
+func quickWinSuspicion(winGuesses []int) int {
+	if len(winGuesses) == 0 {
+		return 0
+	}
+
+	quickWins := 0
+	for _, guesses := range winGuesses {
+		if guesses <= cheatQuickWinGuesses {
+			quickWins++
+		}
+	}
+	if quickWins < cheatMinQuickWins {
+		return 0
+	}
+	return cheatQuickWinSuspicionWeight * quickWins / len(winGuesses)
+}
+
+// winnerGuessCount counts the guesses a player made in a play
+func winnerGuessCount(playData database.JSONB, playerID uuid.UUID) int {
+	count := 0
+	guesses, _ := playData["guesses"].([]interface{})
+	for _, g := range guesses {
+		if guess, ok := g.(map[string]interface{}); ok && guess["player_id"] == playerID.String() {
+			count++
+		}
+	}
+	return count
+}
+
+// assessCheatSuspicion scores a just-won play for signs of automated play and records the score on it
+// The play must carry its full guess history (see withFullGuessHistory)
+// Suspicious plays are logged for review but never rejected
+func (h *GamesHandler) assessCheatSuspicion(c *gin.Context, play *database.Play, winnerID uuid.UUID) {
+	winGuesses := []int{winnerGuessCount(play.PlayData, winnerID)}
+	recentWins, err := h.playRepo.FindRecentWinsByUser(winnerID, play.GameID, cheatRecentWins-1)
+	if err != nil {
+		logging.FromContext(c).Error("failed to load recent wins for cheat check", "error", err)
+	}
+	for i := range recentWins {
+		if recentWins[i].ID == play.ID {
+			continue
+		}
+		// Count archived guesses too, so long past wins don't look suspiciously quick
+		win, err := h.withFullGuessHistory(&recentWins[i])
+		if err != nil {
+			logging.FromContext(c).Error("failed to load guess history for cheat check", "play_id", recentWins[i].ID, "error", err)
+			continue
+		}
+		winGuesses = append(winGuesses, winnerGuessCount(win.PlayData, winnerID))
+	}
+
+	suspicion := guessTimingSuspicion(play.PlayData, winnerID) + quickWinSuspicion(winGuesses)
+	play.Suspicion = &suspicion
+
+	if suspicion >= h.cheatSuspicionThreshold {
+		logging.FromContext(c).Warn("play flagged for suspected cheating",
+			"play_id", play.ID, "winner_id", winnerID, "suspicion", suspicion)
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestGuessTimingSuspicion(t *testing.T) {
+	player, opponent := uuid.New(), uuid.New()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	timedGuesses := func(playerID uuid.UUID, intervals ...time.Duration) []interface{} {
+		at := start
+		guesses := []interface{}{map[string]interface{}{"player_id": playerID.String(), "timestamp": at.Format(time.RFC3339Nano)}}
+		for _, interval := range intervals {
+			at = at.Add(interval)
+			guesses = append(guesses, map[string]interface{}{"player_id": playerID.String(), "timestamp": at.Format(time.RFC3339Nano)})
+		}
+		return guesses
+	}
+	ms := time.Millisecond
+
+	tests := []struct {
+		name    string
+		guesses []interface{}
+		want    int
+	}{
+		{name: "no guesses", guesses: nil, want: 0},
+		{name: "too few intervals", guesses: timedGuesses(player, 300*ms, 300*ms), want: 0},
+		{name: "uniform sub-second intervals", guesses: timedGuesses(player, 300*ms, 300*ms, 300*ms), want: cheatTimingSuspicionWeight},
+		{name: "slow intervals", guesses: timedGuesses(player, 5*time.Second, 5*time.Second, 5*time.Second), want: 0},
+		{name: "irregular intervals", guesses: timedGuesses(player, 100*ms, 900*ms, 200*ms), want: 0},
+		{name: "only the opponent's guesses", guesses: timedGuesses(opponent, 300*ms, 300*ms, 300*ms), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := guessTimingSuspicion(database.JSONB{"guesses": tt.guesses}, player)
+			if got != tt.want {
+				t.Errorf("guessTimingSuspicion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuickWinSuspicion(t *testing.T) {
+	tests := []struct {
+		name       string
+		winGuesses []int
+		want       int
+	}{
+		{name: "no wins", winGuesses: nil, want: 0},
+		{name: "one lucky win", winGuesses: []int{1, 8, 9, 7}, want: 0},
+		{name: "half quick wins", winGuesses: []int{1, 2, 8, 9}, want: cheatQuickWinSuspicionWeight / 2},
+		{name: "every win quick", winGuesses: []int{2, 1, 2}, want: cheatQuickWinSuspicionWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quickWinSuspicion(tt.winGuesses); got != tt.want {
+				t.Errorf("quickWinSuspicion(%v) = %d, want %d", tt.winGuesses, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWinnerGuessCount(t *testing.T) {
+	player, opponent := uuid.New(), uuid.New()
+	playData := database.JSONB{"guesses": []interface{}{
+		map[string]interface{}{"player_id": player.String()},
+		map[string]interface{}{"player_id": opponent.String()},
+		map[string]interface{}{"player_id": player.String()},
+	}}
+
+	if got := winnerGuessCount(playData, player); got != 2 {
+		t.Errorf("winnerGuessCount() = %d, want 2", got)
+	}
+	if got := winnerGuessCount(database.JSONB{}, player); got != 0 {
+		t.Errorf("winnerGuessCount() without guesses = %d, want 0", got)
+	}
+}
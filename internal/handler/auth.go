@@ -2,18 +2,23 @@ package handler
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/games-app/backend/internal/config"
 	"github.com/games-app/backend/internal/database"
 	"github.com/games-app/backend/internal/email"
+	"github.com/games-app/backend/internal/logging"
 )
 
 // AuthHandler handles authentication requests
@@ -21,37 +26,46 @@ type AuthHandler struct {
 	config      *config.Config
 	userRepo    *database.UserRepository
 	otpRepo     *database.OTPRepository
+	prefsRepo   *database.UserPreferencesRepository
+	refreshRepo *database.RefreshTokenRepository
+	revokedRepo *database.RevokedTokenRepository
 	emailClient email.EmailClient
 	jwtSecret   []byte
+
+	// Cleaning and profanity policy of display names and names
+	textPolicy textPolicy
+
+	// Email domains new users can't sign up with; nil when blocking is disabled
+	disposableDomains map[string]bool
+
+	// Recent OTP invalidation attempts per email, used for rate limiting
+	otpInvalidationsMu sync.Mutex
+	otpInvalidations   map[string][]time.Time
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(cfg *config.Config) (*AuthHandler, error) {
+func NewAuthHandler(cfg *config.Config, emailClient email.EmailClient) (*AuthHandler, error) {
 	// Generate or use JWT secret
 	jwtSecret := []byte(cfg.JWTSecret)
 	if len(jwtSecret) == 0 {
-		// Generate a random secret if not provided (for development only)
+		// An ephemeral secret invalidates every token on restart, so only allow it in development
+		if cfg.Environment != "development" {
+			return nil, fmt.Errorf("JWT_SECRET is required in %s environment", cfg.Environment)
+		}
+
 		jwtSecret = make([]byte, 32)
-		rand.Read(jwtSecret)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			return nil, fmt.Errorf("failed to generate JWT secret: %w", err)
+		}
+		logging.Component("auth").Warn("JWT_SECRET not set, using a generated secret (tokens will not survive restarts)")
 	}
 
-	// Initialize email client based on provider
-	var emailClient email.EmailClient
-	var err error
-
-	switch cfg.EmailProvider {
-	case "gmail":
-		emailClient, err = email.NewGmailClient(cfg.GmailTokenPath, cfg.GmailTokenJSON, cfg.GmailFromEmail)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize Gmail client: %w", err)
-		}
-	case "mailgun":
-		emailClient = email.NewMailgunClient(cfg.MailgunAPIKey, cfg.MailgunDomain, cfg.MailgunBaseURL, cfg.MailgunFromEmail)
-	default:
-		// Default to Gmail
-		emailClient, err = email.NewGmailClient(cfg.GmailTokenPath, cfg.GmailTokenJSON, cfg.GmailFromEmail)
+	var disposableDomains map[string]bool
+	if cfg.BlockDisposableEmails {
+		var err error
+		disposableDomains, err = loadDisposableDomains(cfg.DisposableEmailDomainsPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize Gmail client: %w", err)
+			return nil, fmt.Errorf("failed to load disposable email domains: %w", err)
 		}
 	}
 
@@ -59,8 +73,17 @@ func NewAuthHandler(cfg *config.Config) (*AuthHandler, error) {
 		config:      cfg,
 		userRepo:    database.NewUserRepository(database.DB),
 		otpRepo:     database.NewOTPRepository(database.DB),
+		prefsRepo:   database.NewUserPreferencesRepository(database.DB),
+		refreshRepo: database.NewRefreshTokenRepository(database.DB),
+		revokedRepo: database.NewRevokedTokenRepository(database.DB),
 		emailClient: emailClient,
 		jwtSecret:   jwtSecret,
+
+		textPolicy: newTextPolicy(cfg),
+
+		disposableDomains: disposableDomains,
+
+		otpInvalidations: make(map[string][]time.Time),
 	}, nil
 }
 
@@ -74,6 +97,18 @@ type RequestOtpResponse struct {
 	Message string `json:"message"`
 }
 
+// OTP request rate limit: at most otpRateLimitCount OTPs per email per window
+const (
+	otpRateLimitCount         = 3
+	otpRateLimitWindowMinutes = 10
+)
+
+// Error codes for RequestOtp's 429s, so clients can tell the cap from the resend cooldown
+const (
+	errorCodeOTPTooMany = "OTP_TOO_MANY" // The email hit the cap of OTPs per window
+	errorCodeOTPTooSoon = "OTP_TOO_SOON" // The email's last OTP is within the resend cooldown
+)
+
 // RequestOtp handles OTP request
 func (h *AuthHandler) RequestOtp(c *gin.Context) {
 	var req RequestOtpRequest
@@ -82,21 +117,52 @@ func (h *AuthHandler) RequestOtp(c *gin.Context) {
 		return
 	}
 
+	// The bot account can't be signed in to
+	if strings.EqualFold(req.Email, database.BotUserEmail) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This email address can't be used to sign in"})
+		return
+	}
+
+	// New accounts can't use disposable addresses; existing users keep signing in as before
+	if h.disposableDomains != nil && isDisposableDomain(h.disposableDomains, email.ExtractDomainFromEmail(req.Email)) {
+		if _, err := h.userRepo.FindByEmail(req.Email); errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Disposable email addresses can't be used to sign up. Please use a permanent address."})
+			return
+		}
+	}
+
 	email := req.Email
 
 	// Rate limiting: max 3 OTPs per email per 10 minutes
-	count, err := h.otpRepo.CountRecentOTPs(email, 10)
+	recentOTPs, err := h.otpRepo.FindRecentOTPTimes(email, otpRateLimitWindowMinutes)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rate limit"})
 		return
 	}
-	if count >= 3 {
-		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many OTP requests. Please try again later."})
+	if len(recentOTPs) >= otpRateLimitCount {
+		// Another OTP is allowed once enough of the recent ones fall out of the window
+		freedAt := recentOTPs[len(recentOTPs)-otpRateLimitCount].Add(otpRateLimitWindowMinutes * time.Minute)
+		respondTooManyRequestsCoded(c, errorCodeOTPTooMany, time.Until(freedAt))
 		return
 	}
 
-	// Generate 4-digit OTP
-	otpCode, err := generateOTP(4)
+	// Cooldown between consecutive requests, so resends can't use up the cap in seconds
+	if h.config.OTPResendCooldownSeconds > 0 {
+		lastOTP, err := h.otpRepo.FindMostRecentOTP(email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rate limit"})
+			return
+		}
+		if lastOTP != nil {
+			cooldown := time.Duration(h.config.OTPResendCooldownSeconds) * time.Second
+			if wait := cooldown - time.Since(lastOTP.CreatedAt); wait > 0 {
+				respondTooManyRequestsCoded(c, errorCodeOTPTooSoon, wait)
+				return
+			}
+		}
+	}
+
+	otpCode, err := generateOTP(h.config.OTPLength)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate OTP"})
 		return
@@ -116,9 +182,9 @@ func (h *AuthHandler) RequestOtp(c *gin.Context) {
 	}
 
 	// Send OTP via email
-	if err := h.emailClient.SendOTPEmail(email, otpCode); err != nil {
+	if err := h.emailClient.SendOTPEmail(email, otpCode, h.config.OTPExpiryMinutes); err != nil {
 		// Log error but don't fail the request (OTP is still created)
-		fmt.Printf("[AuthHandler] Failed to send email: %v\n", err)
+		logging.FromContext(c).Error("failed to send OTP email", "error", err)
 		// In development, return the OTP in the response for testing
 		if h.config.Environment == "development" {
 			c.JSON(http.StatusOK, RequestOtpResponse{
@@ -133,16 +199,20 @@ func (h *AuthHandler) RequestOtp(c *gin.Context) {
 	})
 }
 
+// invalidOTPMessage is the single error returned for every OTP verification failure
+const invalidOTPMessage = "Invalid or expired OTP"
+
 // VerifyOtpRequest represents the request body for verifying OTP
 type VerifyOtpRequest struct {
 	Email string `json:"email" binding:"required,email"`
-	OTP   string `json:"otp" binding:"required,len=4"`
+	OTP   string `json:"otp" binding:"required"` // Length is checked against the configured OTP length
 }
 
 // VerifyOtpResponse represents the response for verifying OTP
 type VerifyOtpResponse struct {
-	Token string         `json:"token"`
-	User  *database.User `json:"user"`
+	Token        string         `json:"token"`
+	RefreshToken string         `json:"refresh_token"`
+	User         *database.User `json:"user"`
 }
 
 // VerifyOtp handles OTP verification
@@ -153,15 +223,29 @@ func (h *AuthHandler) VerifyOtp(c *gin.Context) {
 		return
 	}
 
+	if err := h.validateOTPLength(req.OTP); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Find valid OTP
+	// Unknown email, wrong code, expired and reused codes all get the same response
 	otp, err := h.otpRepo.FindValidOTP(req.Email, req.OTP)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired OTP"})
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify OTP"})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": invalidOTPMessage})
 		return
 	}
 
-	// Mark OTP as used
+	// Mark OTP as used (fails if a concurrent request already consumed it)
 	if err := h.otpRepo.MarkAsUsed(otp.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": invalidOTPMessage})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark OTP as used"})
 		return
 	}
@@ -180,6 +264,10 @@ func (h *AuthHandler) VerifyOtp(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user: " + err.Error()})
 			return
 		}
+
+		if h.config.SendWelcomeEmail {
+			h.sendWelcomeEmail(c, user)
+		}
 	} else {
 		// Update existing user to mark email as verified
 		user.EmailVerified = true
@@ -191,21 +279,124 @@ func (h *AuthHandler) VerifyOtp(c *gin.Context) {
 	}
 
 	// Generate JWT token
-	token, err := h.generateJWT(user.ID, user.Email)
+	token, err := h.generateJWT(user.ID, user.Email, DeviceFingerprint(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
 		return
 	}
 
+	refreshToken, err := h.issueRefreshToken(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token: " + err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, VerifyOtpResponse{
-		Token: token,
-		User:  user,
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         user,
 	})
 }
 
+// OTP invalidation rate limit: at most otpInvalidationLimitCount attempts per email per window
+const (
+	otpInvalidationLimitCount  = 5
+	otpInvalidationLimitWindow = 10 * time.Minute
+)
+
+// InvalidateOtpsRequest represents the request body for invalidating outstanding OTPs
+// A currently valid code proves access to the inbox, so a third party can't wipe a victim's codes
+type InvalidateOtpsRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	OTP   string `json:"otp" binding:"required"` // Length is checked against the configured OTP length
+}
+
+// InvalidateOtpsResponse represents the response for invalidating outstanding OTPs
+type InvalidateOtpsResponse struct {
+	Message     string `json:"message"`
+	Invalidated int64  `json:"invalidated"`
+}
+
+// InvalidateOtps handles invalidating every outstanding OTP for an email, including the one presented
+func (h *AuthHandler) InvalidateOtps(c *gin.Context) {
+	var req InvalidateOtpsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.validateOTPLength(req.OTP); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if wait := h.recordOtpInvalidationAttempt(req.Email); wait > 0 {
+		respondTooManyRequests(c, "Too many attempts. Please try again later.", wait)
+		return
+	}
+
+	// Same response as VerifyOtp for every kind of bad code
+	if _, err := h.otpRepo.FindValidOTP(req.Email, req.OTP); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify OTP"})
+			return
+		}
+		logging.FromContext(c).Warn("otp invalidation rejected", "reason", "invalid_code")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": invalidOTPMessage})
+		return
+	}
+
+	invalidated, err := h.otpRepo.InvalidateAllForEmail(req.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate OTPs"})
+		return
+	}
+	logging.FromContext(c).Info("otps invalidated", "count", invalidated)
+
+	c.JSON(http.StatusOK, InvalidateOtpsResponse{
+		Message:     "All outstanding codes have been invalidated",
+		Invalidated: invalidated,
+	})
+}
+
+// recordOtpInvalidationAttempt records an invalidation attempt for an email
+// Returns how long to wait if the email is over the limit (the attempt is then not recorded)
+func (h *AuthHandler) recordOtpInvalidationAttempt(email string) time.Duration {
+	h.otpInvalidationsMu.Lock()
+	defer h.otpInvalidationsMu.Unlock()
+	h.pruneOtpInvalidations()
+
+	// Drop attempts that have fallen out of the window
+	now := time.Now()
+	attempts := h.otpInvalidations[email]
+	for len(attempts) > 0 && now.Sub(attempts[0]) >= otpInvalidationLimitWindow {
+		attempts = attempts[1:]
+	}
+
+	if len(attempts) >= otpInvalidationLimitCount {
+		h.otpInvalidations[email] = attempts
+		return attempts[len(attempts)-otpInvalidationLimitCount].Add(otpInvalidationLimitWindow).Sub(now)
+	}
+
+	h.otpInvalidations[email] = append(attempts, now)
+	return 0
+}
+
+// pruneOtpInvalidations forgets emails whose attempts have all fallen out of the window, which no longer limit anything,
+// so posting random emails can't grow the map without bound
+// Must be called with otpInvalidationsMu held
+func (h *AuthHandler) pruneOtpInvalidations() {
+	for email, attempts := range h.otpInvalidations {
+		if len(attempts) == 0 || time.Since(attempts[len(attempts)-1]) >= otpInvalidationLimitWindow {
+			delete(h.otpInvalidations, email)
+		}
+	}
+}
+
 // GetCurrentUserResponse represents the response for getting current user
 type GetCurrentUserResponse struct {
-	User *database.User `json:"user"`
+	User                 *database.User `json:"user"`
+	DefaultPartnershipID *uuid.UUID     `json:"default_partnership_id"` // Only shown to the user themselves
 }
 
 // GetCurrentUser returns the current authenticated user
@@ -229,7 +420,8 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, GetCurrentUserResponse{
-		User: user,
+		User:                 user,
+		DefaultPartnershipID: user.DefaultPartnershipID,
 	})
 }
 
@@ -238,9 +430,16 @@ type UpdateProfileRequest struct {
 	DisplayName string `json:"display_name" binding:"required,min=1,max=100"`
 }
 
+// Text rules of the user-editable profile fields
+var (
+	displayNameRules = textRules{field: "display_name", maxLength: 100, required: true}
+	nameRules        = textRules{field: "name", maxLength: 255, required: true}
+)
+
 // UpdateProfileResponse represents the response for updating profile
 type UpdateProfileResponse struct {
-	User *database.User `json:"user"`
+	User                 *database.User `json:"user"`
+	DefaultPartnershipID *uuid.UUID     `json:"default_partnership_id"` // Only shown to the user themselves
 }
 
 // UpdateProfile updates the current user's profile
@@ -263,25 +462,187 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	displayName, err := h.textPolicy.sanitize(req.DisplayName, displayNameRules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if h.isReservedDisplayName(displayName) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This display name is reserved"})
+		return
+	}
+
 	user, err := h.userRepo.FindByID(userUUID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
 
-	user.DisplayName = req.DisplayName
+	user.DisplayName = displayName
 	if err := h.userRepo.Update(user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile: " + err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, UpdateProfileResponse{
-		User: user,
+		User:                 user,
+		DefaultPartnershipID: user.DefaultPartnershipID,
 	})
 }
 
+// isReservedDisplayName reports whether a display name is on the configured reserved list
+// Names are compared case-insensitively, ignoring surrounding whitespace
+func (h *AuthHandler) isReservedDisplayName(displayName string) bool {
+	name := strings.ToLower(strings.TrimSpace(displayName))
+	for _, reserved := range h.config.ReservedDisplayNames {
+		reserved = strings.ToLower(reserved)
+		if name == reserved {
+			return true
+		}
+		if h.config.ReservedDisplayNameMatch == config.ReservedNameMatchContains && strings.Contains(name, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// PatchProfileRequest represents the request body for partially updating profile
+// Omitted fields are left unchanged
+type PatchProfileRequest struct {
+	DisplayName *string `json:"display_name" binding:"omitempty,max=100"`
+	Name        *string `json:"name" binding:"omitempty,max=255"`
+}
+
+// PatchProfile updates only the provided fields of the current user's profile
+func (h *AuthHandler) PatchProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req PatchProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Provided fields must not be blank; the columns hold required values
+	fields := make(map[string]interface{})
+	if req.DisplayName != nil {
+		displayName, err := h.textPolicy.sanitize(*req.DisplayName, displayNameRules)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if h.isReservedDisplayName(displayName) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "This display name is reserved"})
+			return
+		}
+		fields["display_name"] = displayName
+	}
+	if req.Name != nil {
+		name, err := h.textPolicy.sanitize(*req.Name, nameRules)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		// The name is shown wherever a user has no display name, so the same names are reserved
+		if h.isReservedDisplayName(name) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "This name is reserved"})
+			return
+		}
+		fields["name"] = name
+	}
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(userUUID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if err := h.userRepo.UpdateFields(userUUID, fields); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile: " + err.Error()})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UpdateProfileResponse{
+		User:                 user,
+		DefaultPartnershipID: user.DefaultPartnershipID,
+	})
+}
+
+// IntrospectRequest represents the request body for token introspection
+type IntrospectRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// IntrospectResponse represents the response for token introspection
+type IntrospectResponse struct {
+	Active bool   `json:"active"`
+	UserID string `json:"user_id,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// Introspect handles validating a token on behalf of another service
+func (h *AuthHandler) Introspect(c *gin.Context) {
+	var req IntrospectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Introspecting services don't know the device the token came from
+	userID, email, err := h.VerifyJWT(req.Token, "")
+	if err != nil {
+		// Inactive tokens are a normal answer, not an error
+		c.JSON(http.StatusOK, IntrospectResponse{
+			Active: false,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, IntrospectResponse{
+		Active: true,
+		UserID: userID.String(),
+		Email:  email,
+	})
+}
+
+// sendWelcomeEmail sends the welcome email to a newly registered user in the background
+// Failures are logged and never affect the login
+func (h *AuthHandler) sendWelcomeEmail(c *gin.Context, user *database.User) {
+	logger := logging.FromContext(c)
+	to := user.Email
+	data := map[string]interface{}{
+		"Name": user.Name,
+	}
+
+	go func() {
+		if err := h.emailClient.SendTemplated(to, email.TemplateWelcome, data); err != nil {
+			logger.Error("failed to send welcome email", "error", err)
+		}
+	}()
+}
+
 // generateJWT generates a JWT token for the user
-func (h *AuthHandler) generateJWT(userID uuid.UUID, email string) (string, error) {
+// When device binding is enabled the token is bound to the given device fingerprint
+func (h *AuthHandler) generateJWT(userID uuid.UUID, email, fingerprint string) (string, error) {
 	expiry := 24 * time.Hour
 	if h.config.JWTExpiry != "" {
 		var err error
@@ -294,34 +655,28 @@ func (h *AuthHandler) generateJWT(userID uuid.UUID, email string) (string, error
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
 		"email":   email,
+		"jti":     uuid.New().String(), // Lets logout revoke this token alone
 		"exp":     time.Now().Add(expiry).Unix(),
 		"iat":     time.Now().Unix(),
 	}
+	if h.config.JWTBindDevice && fingerprint != "" {
+		claims[deviceFingerprintClaim] = fingerprint
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(h.jwtSecret)
 }
 
-// VerifyJWT verifies and parses a JWT token
-func (h *AuthHandler) VerifyJWT(tokenString string) (uuid.UUID, string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return h.jwtSecret, nil
-	})
-
+// VerifyJWT verifies and parses a JWT token presented from the device with the given fingerprint
+// An empty fingerprint skips the device check, for tokens not presented by the device itself
+func (h *AuthHandler) VerifyJWT(tokenString, fingerprint string) (uuid.UUID, string, error) {
+	claims, err := h.parseJWT(tokenString)
 	if err != nil {
 		return uuid.Nil, "", err
 	}
 
-	if !token.Valid {
-		return uuid.Nil, "", jwt.ErrSignatureInvalid
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return uuid.Nil, "", jwt.ErrSignatureInvalid
+	if err := h.checkDeviceBinding(claims, fingerprint); err != nil {
+		return uuid.Nil, "", err
 	}
 
 	userIDStr, ok := claims["user_id"].(string)
@@ -339,6 +694,14 @@ func (h *AuthHandler) VerifyJWT(tokenString string) (uuid.UUID, string, error) {
 	return userID, email, nil
 }
 
+// validateOTPLength checks a submitted OTP has as many characters as generated codes
+func (h *AuthHandler) validateOTPLength(code string) error {
+	if len(code) != h.config.OTPLength {
+		return fmt.Errorf("OTP must be %d digits", h.config.OTPLength)
+	}
+	return nil
+}
+
 // generateOTP generates a random N-digit OTP code
 func generateOTP(length int) (string, error) {
 	code := ""
@@ -0,0 +1,471 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
+)
+
+// signTestToken signs claims with the secret, as generateJWT does
+func signTestToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// introspect calls Introspect with the token and returns the decoded response
+func introspect(t *testing.T, h *AuthHandler, token string) IntrospectResponse {
+	t.Helper()
+
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/introspect", IntrospectRequest{Token: token}, uuid.Nil)
+	h.Introspect(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var response IntrospectResponse
+	decodeResponse(t, recorder, &response)
+	return response
+}
+
+func TestIntrospectRejectsInvalidTokens(t *testing.T) {
+	cfg := newTestConfig()
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+
+	userID := uuid.New()
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"user_id": userID.String(),
+			"email":   "user@example.com",
+			"exp":     time.Now().Add(time.Hour).Unix(),
+			"iat":     time.Now().Unix(),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+	}{
+		{
+			name: "expired token",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				claims["iat"] = time.Now().Add(-time.Hour).Unix()
+				return signTestToken(t, cfg.JWTSecret, claims)
+			},
+		},
+		{
+			name: "tampered payload",
+			token: func(t *testing.T) string {
+				token := signTestToken(t, cfg.JWTSecret, validClaims())
+				other := validClaims()
+				other["user_id"] = uuid.NewString()
+				forged := strings.Split(signTestToken(t, cfg.JWTSecret, other), ".")
+				parts := strings.Split(token, ".")
+				return parts[0] + "." + forged[1] + "." + parts[2]
+			},
+		},
+		{
+			name: "tampered signature",
+			token: func(t *testing.T) string {
+				// Change a character inside the signature, since the last one also carries padding bits
+				token := []byte(signTestToken(t, cfg.JWTSecret, validClaims()))
+				i := len(token) - 10
+				if token[i] == 'A' {
+					token[i] = 'B'
+				} else {
+					token[i] = 'A'
+				}
+				return string(token)
+			},
+		},
+		{
+			name: "signed with another secret",
+			token: func(t *testing.T) string {
+				return signTestToken(t, "other-secret", validClaims())
+			},
+		},
+		{
+			name: "unsigned token",
+			token: func(t *testing.T) string {
+				token, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				if err != nil {
+					t.Fatalf("failed to build unsigned token: %v", err)
+				}
+				return token
+			},
+		},
+		{
+			name:  "not a token",
+			token: func(t *testing.T) string { return "not-a-token" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := introspect(t, h, tt.token(t))
+			if response.Active {
+				t.Errorf("Active = true, want false")
+			}
+			if response.UserID != "" || response.Email != "" {
+				t.Errorf("inactive token reported user %q <%s>, want none", response.UserID, response.Email)
+			}
+		})
+	}
+}
+
+func TestIntrospectValidToken(t *testing.T) {
+	db := openTestDB(t)
+	h, err := NewAuthHandler(newTestConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+
+	token, err := h.generateJWT(user.ID, user.Email, "")
+	if err != nil {
+		t.Fatalf("generateJWT() error = %v", err)
+	}
+
+	response := introspect(t, h, token)
+	if !response.Active {
+		t.Fatalf("Active = false, want true")
+	}
+	if response.UserID != user.ID.String() {
+		t.Errorf("UserID = %q, want %q", response.UserID, user.ID.String())
+	}
+	if response.Email != user.Email {
+		t.Errorf("Email = %q, want %q", response.Email, user.Email)
+	}
+}
+
+func TestRecordOtpInvalidationAttempt(t *testing.T) {
+	h := &AuthHandler{otpInvalidations: make(map[string][]time.Time)}
+
+	for i := 0; i < otpInvalidationLimitCount; i++ {
+		if wait := h.recordOtpInvalidationAttempt("user@example.com"); wait != 0 {
+			t.Fatalf("attempt %d wait = %v, want 0", i+1, wait)
+		}
+	}
+	if wait := h.recordOtpInvalidationAttempt("user@example.com"); wait <= 0 || wait > otpInvalidationLimitWindow {
+		t.Errorf("attempt over the limit wait = %v, want within (0, %v]", wait, otpInvalidationLimitWindow)
+	}
+
+	// Emails whose attempts all fell out of the window are forgotten on the next attempt for any email
+	h.otpInvalidations["stale@example.com"] = []time.Time{time.Now().Add(-otpInvalidationLimitWindow - time.Minute)}
+	if wait := h.recordOtpInvalidationAttempt("other@example.com"); wait != 0 {
+		t.Errorf("other email wait = %v, want 0", wait)
+	}
+	if _, exists := h.otpInvalidations["stale@example.com"]; exists {
+		t.Errorf("stale email still tracked after pruning")
+	}
+	if _, exists := h.otpInvalidations["user@example.com"]; !exists {
+		t.Errorf("email within the window was pruned")
+	}
+}
+
+func TestIsReservedDisplayName(t *testing.T) {
+	tests := []struct {
+		name      string
+		matchMode string
+		input     string
+		want      bool
+	}{
+		{name: "exact reserved name", matchMode: config.ReservedNameMatchExact, input: "Admin", want: true},
+		{name: "case and whitespace ignored", matchMode: config.ReservedNameMatchExact, input: "  aDMIN ", want: true},
+		{name: "containing name allowed in exact mode", matchMode: config.ReservedNameMatchExact, input: "Admin Alice", want: false},
+		{name: "containing name rejected in contains mode", matchMode: config.ReservedNameMatchContains, input: "Admin Alice", want: true},
+		{name: "ordinary name", matchMode: config.ReservedNameMatchContains, input: "Alice", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.ReservedDisplayNames = []string{"Admin", "Support"}
+			cfg.ReservedDisplayNameMatch = tt.matchMode
+			h, err := NewAuthHandler(cfg, nil)
+			if err != nil {
+				t.Fatalf("NewAuthHandler() error = %v", err)
+			}
+			if got := h.isReservedDisplayName(tt.input); got != tt.want {
+				t.Errorf("isReservedDisplayName(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchProfileRejectsReservedNames(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.ReservedDisplayNames = []string{"Admin"}
+	cfg.ReservedDisplayNameMatch = config.ReservedNameMatchExact
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+
+	reserved := "admin"
+	for _, req := range []PatchProfileRequest{{DisplayName: &reserved}, {Name: &reserved}} {
+		c, recorder := newTestContext(http.MethodPatch, "/api/v1/users/me", req, user.ID)
+		h.PatchProfile(c)
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("PatchProfile(%+v) status = %d, want %d", req, recorder.Code, http.StatusBadRequest)
+		}
+	}
+
+	var stored database.User
+	if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	if stored.Name != user.Name || stored.DisplayName != user.DisplayName {
+		t.Errorf("profile changed to name %q, display name %q", stored.Name, stored.DisplayName)
+	}
+}
+
+func TestPatchProfile(t *testing.T) {
+	db := openTestDB(t)
+	h, err := NewAuthHandler(newTestConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+	if err := db.Model(user).Update("display_name", "Original").Error; err != nil {
+		t.Fatalf("failed to set display name: %v", err)
+	}
+
+	patch := func(req PatchProfileRequest) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPatch, "/api/v1/users/me", req, user.ID)
+		h.PatchProfile(c)
+		return recorder
+	}
+	reload := func() database.User {
+		var stored database.User
+		if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+			t.Fatalf("failed to reload user: %v", err)
+		}
+		return stored
+	}
+
+	// Only the name is sent, so the display name is kept
+	name := "  New Name  "
+	if recorder := patch(PatchProfileRequest{Name: &name}); recorder.Code != http.StatusOK {
+		t.Fatalf("PatchProfile(name) status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if stored := reload(); stored.Name != "New Name" || stored.DisplayName != "Original" {
+		t.Errorf("profile = name %q, display name %q, want New Name, Original", stored.Name, stored.DisplayName)
+	}
+
+	displayName := "Updated"
+	if recorder := patch(PatchProfileRequest{DisplayName: &displayName}); recorder.Code != http.StatusOK {
+		t.Fatalf("PatchProfile(display name) status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if stored := reload(); stored.Name != "New Name" || stored.DisplayName != "Updated" {
+		t.Errorf("profile = name %q, display name %q, want New Name, Updated", stored.Name, stored.DisplayName)
+	}
+
+	blank := "   "
+	for _, req := range []PatchProfileRequest{{}, {Name: &blank}} {
+		if recorder := patch(req); recorder.Code != http.StatusBadRequest {
+			t.Errorf("PatchProfile(%+v) status = %d, want %d", req, recorder.Code, http.StatusBadRequest)
+		}
+	}
+	if stored := reload(); stored.Name != "New Name" || stored.DisplayName != "Updated" {
+		t.Errorf("rejected patch changed profile to name %q, display name %q", stored.Name, stored.DisplayName)
+	}
+}
+
+func TestRequestOtpRateLimitCodes(t *testing.T) {
+	db := openTestDB(t)
+	address := "otp-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() { db.Where("email = ?", address).Delete(&database.OTP{}) })
+
+	requestOtp := func(cooldownSeconds int) *httptest.ResponseRecorder {
+		cfg := newTestConfig()
+		cfg.OTPResendCooldownSeconds = cooldownSeconds
+		h, err := NewAuthHandler(cfg, &recordingEmailClient{})
+		if err != nil {
+			t.Fatalf("NewAuthHandler() error = %v", err)
+		}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/request-otp", RequestOtpRequest{Email: address}, uuid.Nil)
+		h.RequestOtp(c)
+		return recorder
+	}
+	expectLimited := func(recorder *httptest.ResponseRecorder, wantCode string) {
+		t.Helper()
+		if recorder.Code != http.StatusTooManyRequests {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusTooManyRequests, recorder.Body.String())
+		}
+		var response struct {
+			Code              string `json:"code"`
+			RetryAfterSeconds int    `json:"retry_after_seconds"`
+		}
+		decodeResponse(t, recorder, &response)
+		if response.Code != wantCode {
+			t.Errorf("code = %q, want %q", response.Code, wantCode)
+		}
+		if response.RetryAfterSeconds < 1 || recorder.Header().Get("Retry-After") == "" {
+			t.Errorf("retry_after_seconds = %d, Retry-After = %q, want a wait", response.RetryAfterSeconds, recorder.Header().Get("Retry-After"))
+		}
+	}
+
+	if recorder := requestOtp(60); recorder.Code != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	expectLimited(requestOtp(60), errorCodeOTPTooSoon)
+
+	// Without the cooldown, the cap is what stops further requests
+	for i := 1; i < otpRateLimitCount; i++ {
+		if recorder := requestOtp(0); recorder.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d: %s", i+1, recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+	}
+	expectLimited(requestOtp(0), errorCodeOTPTooMany)
+}
+
+func TestNewAuthHandlerRequiresSecretOutsideDevelopment(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.JWTSecret = ""
+	for _, env := range []string{"production", "staging", "test"} {
+		cfg.Environment = env
+		if _, err := NewAuthHandler(cfg, nil); err == nil {
+			t.Errorf("NewAuthHandler() without a secret in %s succeeded, want an error", env)
+		}
+	}
+
+	cfg.Environment = "development"
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() in development error = %v", err)
+	}
+	if len(h.jwtSecret) != 32 {
+		t.Errorf("generated secret is %d bytes, want 32", len(h.jwtSecret))
+	}
+}
+
+func TestGenerateOTP(t *testing.T) {
+	for _, length := range []int{4, 6, 8} {
+		code, err := generateOTP(length)
+		if err != nil {
+			t.Fatalf("generateOTP(%d) error = %v", length, err)
+		}
+		if len(code) != length || strings.Trim(code, "0123456789") != "" {
+			t.Errorf("generateOTP(%d) = %q, want %d digits", length, code, length)
+		}
+	}
+}
+
+func TestVerifyOtpSendsWelcomeEmailOnce(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.SendWelcomeEmail = true
+	emailClient := &recordingEmailClient{}
+	h, err := NewAuthHandler(cfg, emailClient)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+
+	address := "welcome-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() {
+		db.Where("email = ?", address).Delete(&database.OTP{})
+		db.Unscoped().Where("email = ?", address).Delete(&database.User{})
+	})
+
+	login := func(code string) {
+		t.Helper()
+		otp := &database.OTP{Email: address, Code: code, ExpiresAt: time.Now().Add(time.Minute)}
+		if err := db.Create(otp).Error; err != nil {
+			t.Fatalf("failed to create OTP: %v", err)
+		}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/verify-otp", VerifyOtpRequest{Email: address, OTP: code}, uuid.Nil)
+		h.VerifyOtp(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+	}
+
+	login("1234")
+	if sent := emailClient.templatedSent(1); len(sent) != 1 || sent[0] != email.TemplateWelcome {
+		t.Fatalf("sent %v after registering, want one welcome email", sent)
+	}
+
+	// Returning users aren't welcomed again
+	login("5678")
+	if sent := emailClient.templatedSent(2); len(sent) != 1 {
+		t.Errorf("sent %v after logging in again, want only the first welcome email", sent)
+	}
+}
+
+func TestInvalidateOtps(t *testing.T) {
+	db := openTestDB(t)
+	h, err := NewAuthHandler(newTestConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+
+	address := "invalidate-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() { db.Where("email = ?", address).Delete(&database.OTP{}) })
+	for _, code := range []string{"1234", "5678"} {
+		otp := &database.OTP{Email: address, Code: code, ExpiresAt: time.Now().Add(time.Minute)}
+		if err := db.Create(otp).Error; err != nil {
+			t.Fatalf("failed to create OTP: %v", err)
+		}
+	}
+
+	invalidate := func(code string) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/invalidate-otps", InvalidateOtpsRequest{Email: address, OTP: code}, uuid.Nil)
+		h.InvalidateOtps(c)
+		return recorder
+	}
+
+	// Without a valid code nobody can wipe the codes
+	if recorder := invalidate("9999"); recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("invalid code status = %d, want %d", recorder.Code, http.StatusUnauthorized)
+	}
+
+	recorder := invalidate("1234")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response InvalidateOtpsResponse
+	decodeResponse(t, recorder, &response)
+	if response.Invalidated != 2 {
+		t.Errorf("invalidated = %d, want 2", response.Invalidated)
+	}
+
+	// Neither code can be used to log in any more
+	c, verifyRecorder := newTestContext(http.MethodPost, "/api/v1/auth/verify-otp", VerifyOtpRequest{Email: address, OTP: "5678"}, uuid.Nil)
+	h.VerifyOtp(c)
+	if verifyRecorder.Code != http.StatusUnauthorized {
+		t.Errorf("VerifyOtp after invalidation status = %d, want %d", verifyRecorder.Code, http.StatusUnauthorized)
+	}
+
+	// Attempts per email are limited
+	for i := 2; i < otpInvalidationLimitCount; i++ {
+		invalidate("5678")
+	}
+	recorder = invalidate("5678")
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("status over the limit = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header not set")
+	}
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// Bot difficulty levels, stored in a bot play's PlayData as bot_difficulty
+const (
+	botDifficultyEasy    = "easy"   // Random valid guesses, ignoring feedback
+	botDifficultyMedium  = "medium" // Random guesses consistent with all feedback so far
+	botDifficultyHard    = "hard"   // Minimax: the candidate leaving the fewest candidates in the worst case
+	defaultBotDifficulty = botDifficultyMedium
+)
+
+// botOpeningGuess is the hard bot's first guess; with no feedback yet every code without a zero is equally good
+const botOpeningGuess = "1234"
+
+// parseBotDifficulty validates a requested bot difficulty, defaulting when none is given
+func parseBotDifficulty(difficulty string) (string, error) {
+	switch difficulty {
+	case "":
+		return defaultBotDifficulty, nil
+	case botDifficultyEasy, botDifficultyMedium, botDifficultyHard:
+		return difficulty, nil
+	default:
+		return "", fmt.Errorf("difficulty must be one of %s, %s or %s", botDifficultyEasy, botDifficultyMedium, botDifficultyHard)
+	}
+}
+
+// botFeedback is one of the bot's earlier guesses and the bulls and cows it scored
+type botFeedback struct {
+	guess string
+	bulls int
+	cows  int
+}
+
+// botFeedbackFromPlay collects the bot's guesses so far from a play
+func botFeedbackFromPlay(playData database.JSONB, botID uuid.UUID) []botFeedback {
+	var history []botFeedback
+	guesses, _ := playData["guesses"].([]interface{})
+	for _, g := range guesses {
+		guess, ok := g.(map[string]interface{})
+		if !ok || guess["player_id"] != botID.String() {
+			continue
+		}
+		code, _ := guess["guess"].(string)
+		history = append(history, botFeedback{guess: code, bulls: feedbackCount(guess["bulls"]), cows: feedbackCount(guess["cows"])})
+	}
+	return history
+}
+
+// feedbackCount reads a bulls or cows count, which is an int until the play is saved and a float64 once loaded
+func feedbackCount(value interface{}) int {
+	switch count := value.(type) {
+	case int:
+		return count
+	case float64:
+		return int(count)
+	}
+	return 0
+}
+
+// allSecrets lists every valid default-length Bulls and Cows secret (unique digits, no leading zero)
+var allSecrets = func() []string {
+	var secrets []string
+	for n := 1000; n <= 9999; n++ {
+		code := fmt.Sprint(n)
+		if validateSecret(code, defaultSecretLength) == nil {
+			secrets = append(secrets, code)
+		}
+	}
+	return secrets
+}()
+
+// botScore is a fast bulls and cows score (bulls*10 + cows) for codes with unique digits
+// The solvers compare millions of pairs, so this avoids calculateBullsAndCows' allocations
+func botScore(secret, guess string) int {
+	bulls := 0
+	var secretDigits, guessDigits uint16
+	for i := 0; i < 4; i++ {
+		if secret[i] == guess[i] {
+			bulls++
+		}
+		secretDigits |= 1 << (secret[i] - '0')
+		guessDigits |= 1 << (guess[i] - '0')
+	}
+	common := 0
+	for shared := secretDigits & guessDigits; shared != 0; shared &= shared - 1 {
+		common++
+	}
+	return bulls*10 + common - bulls
+}
+
+// botCandidates returns the secrets consistent with all of the bot's feedback so far
+func botCandidates(history []botFeedback) []string {
+	var candidates []string
+	for _, secret := range allSecrets {
+		consistent := true
+		for _, h := range history {
+			if len(h.guess) != 4 || botScore(secret, h.guess) != h.bulls*10+h.cows {
+				consistent = false
+				break
+			}
+		}
+		if consistent {
+			candidates = append(candidates, secret)
+		}
+	}
+	return candidates
+}
+
+// randomSecret picks a random code from the list
+func randomSecret(codes []string) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codes))))
+	if err != nil {
+		return "", err
+	}
+	return codes[n.Int64()], nil
+}
+
+// botGuess picks the bot's next guess for the given difficulty
+func botGuess(difficulty string, history []botFeedback) (string, error) {
+	switch difficulty {
+	case botDifficultyEasy:
+		// Any valid code it hasn't tried yet, whatever the feedback said
+		tried := make(map[string]bool, len(history))
+		for _, h := range history {
+			tried[h.guess] = true
+		}
+		untried := make([]string, 0, len(allSecrets))
+		for _, secret := range allSecrets {
+			if !tried[secret] {
+				untried = append(untried, secret)
+			}
+		}
+		if len(untried) == 0 {
+			return "", fmt.Errorf("no untried codes left")
+		}
+		return randomSecret(untried)
+
+	case botDifficultyMedium, botDifficultyHard:
+		candidates := botCandidates(history)
+		if len(candidates) == 0 {
+			return "", fmt.Errorf("no code is consistent with the feedback")
+		}
+		if difficulty == botDifficultyMedium {
+			return randomSecret(candidates)
+		}
+		if len(history) == 0 {
+			return botOpeningGuess, nil
+		}
+		return minimaxGuess(candidates), nil
+
+	default:
+		return "", fmt.Errorf("unknown bot difficulty %q", difficulty)
+	}
+}
+
+// minimaxGuess returns the candidate whose worst-case feedback leaves the fewest candidates
+// Only candidates are considered so every guess can win outright
+func minimaxGuess(candidates []string) string {
+	best, bestWorst := candidates[0], len(candidates)+1
+	var partitions [41]int
+	for _, guess := range candidates {
+		partitions = [41]int{}
+		worst := 0
+		for _, secret := range candidates {
+			score := botScore(secret, guess)
+			partitions[score]++
+			if partitions[score] > worst {
+				worst = partitions[score]
+			}
+		}
+		if worst < bestWorst {
+			best, bestWorst = guess, worst
+		}
+	}
+	return best
+}
+
+// isBotPlay reports whether a play is against the bot user
+func isBotPlay(play *database.Play) bool {
+	return play.Partner1ID == database.BotUserID || play.Partner2ID == database.BotUserID
+}
+
+// playBotTurn makes the bot's guess in a bot play whose turn has passed to the bot
+// The bot guesses the player's secret, wins if it hits all four bulls, and otherwise hands the turn back
+func (h *GamesHandler) playBotTurn(play *database.Play) error {
+	playData := play.PlayData
+	if playData["current_turn"] != database.BotUserID.String() {
+		return nil
+	}
+
+	playerID, playerSecretKey := play.Partner1ID, "partner1_secret"
+	if play.Partner1ID == database.BotUserID {
+		playerID, playerSecretKey = play.Partner2ID, "partner2_secret"
+	}
+	playerSecret, ok := playData[playerSecretKey].(string)
+	if !ok || playerSecret == "" {
+		return fmt.Errorf("player secret is not set")
+	}
+
+	// The bot learns from every guess it has made, including any already archived
+	fullPlay, err := h.withFullGuessHistory(play)
+	if err != nil {
+		return err
+	}
+	difficulty, _ := playData["bot_difficulty"].(string)
+	if difficulty == "" {
+		difficulty = defaultBotDifficulty
+	}
+	guess, err := botGuess(difficulty, botFeedbackFromPlay(fullPlay.PlayData, database.BotUserID))
+	if err != nil {
+		return err
+	}
+
+	bulls, cows := calculateBullsAndCows(playerSecret, guess)
+	guesses, _ := playData["guesses"].([]interface{})
+	playData["guesses"] = append(guesses, map[string]interface{}{
+		"player_id": database.BotUserID.String(),
+		"guess":     guess,
+		"bulls":     bulls,
+		"cows":      cows,
+		"timestamp": time.Now().Format(time.RFC3339Nano),
+	})
+
+	if bulls == len(playerSecret) {
+		playData["status"] = "completed"
+		playData["winner_id"] = database.BotUserID.String()
+		playData["result"] = database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonSolved, &database.BotUserID)
+		play.IsLive = false
+	} else {
+		playData["current_turn"] = playerID.String()
+	}
+	return nil
+}
+
+// PlayBotRequest represents the request body for starting a play against the bot
+type PlayBotRequest struct {
+	GameID     string `json:"game_id"`    // Optional game ID or slug, defaults to Bulls and Cows
+	Difficulty string `json:"difficulty"` // easy, medium or hard (default medium)
+}
+
+// PlayBotResponse represents the response for starting a play against the bot
+type PlayBotResponse struct {
+	Play    *database.Play `json:"play"`
+	Created bool           `json:"created"` // False when the user's live bot play was returned instead
+}
+
+// PlayBot handles starting (or resuming) a practice play against the bot
+// Anyone can play the bot, partnered or not, with no request to accept. Bot plays aren't backed by a
+// Partnership (each user may only have one) and are practice plays, so they stay out of stats and leaderboards.
+// The bot picks its secret up front and takes its turn as soon as the player has guessed.
+func (h *GamesHandler) PlayBot(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req PlayBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	difficulty, err := parseBotDifficulty(req.Difficulty)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.GameID == "" {
+		req.GameID = bullsAndCowsGameSlug
+	}
+	gameID, ok := h.resolveGameRef(c, req.GameID)
+	if !ok {
+		return
+	}
+
+	game, err := h.gameRepo.FindByID(gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	// The bot only knows how to play Bulls and Cows
+	if !isBullsAndCows(&database.Play{GameID: game.ID, Game: *game}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The bot can only play Bulls and Cows"})
+		return
+	}
+	if secretLength(*game) != defaultSecretLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The bot only plays 4-digit Bulls and Cows"})
+		return
+	}
+
+	// Resume the live bot play of this game if there is one
+	existingPlay, err := h.playRepo.FindLivePlayByPartners(userUUID, database.BotUserID, gameID)
+	if err == nil {
+		hideOpponentSecret(existingPlay, userUUID)
+		c.JSON(http.StatusOK, PlayBotResponse{
+			Play: existingPlay,
+		})
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	if !h.allowNewLivePlay(c, game.ID, false, userUUID, database.BotUserID) {
+		return
+	}
+
+	botSecret, err := generateSecret(defaultSecretLength)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize play: " + err.Error()})
+		return
+	}
+
+	// The player moves first once they've set their secret
+	play := &database.Play{
+		GameID:     game.ID,
+		Partner1ID: userUUID,
+		Partner2ID: database.BotUserID,
+		PlayData: database.JSONB{
+			"status":          "waiting_secrets",
+			"partner2_secret": botSecret,
+			"bot_difficulty":  difficulty,
+		},
+		IsLive:     true,
+		IsPractice: true,
+	}
+	if err := h.playRepo.CreatePlay(play); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+		return
+	}
+
+	play, err = h.playRepo.FindPlayByID(play.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load play"})
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusCreated, PlayBotResponse{
+		Play:    play,
+		Created: true,
+	})
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// simulateBotGame plays the bot against the secret until it wins and returns how many guesses it needed
+func simulateBotGame(t *testing.T, difficulty, secret string) int {
+	t.Helper()
+
+	var history []botFeedback
+	for len(history) < len(allSecrets) {
+		guess, err := botGuess(difficulty, history)
+		if err != nil {
+			t.Fatalf("botGuess(%s) error = %v", difficulty, err)
+		}
+		if err := validateSecret(guess, defaultSecretLength); err != nil {
+			t.Fatalf("botGuess(%s) = %q, which is invalid: %v", difficulty, guess, err)
+		}
+		bulls, cows := calculateBullsAndCows(secret, guess)
+		history = append(history, botFeedback{guess: guess, bulls: bulls, cows: cows})
+		if bulls == defaultSecretLength {
+			return len(history)
+		}
+	}
+	t.Fatalf("%s bot never guessed %s", difficulty, secret)
+	return 0
+}
+
+func TestBotDifficultyStrength(t *testing.T) {
+	// The easy bot needs thousands of guesses a game, so fewer of its games are enough to compare
+	games := map[string]int{botDifficultyEasy: 3, botDifficultyMedium: 20, botDifficultyHard: 20}
+	average := make(map[string]float64)
+	worst := make(map[string]int)
+	for difficulty, count := range games {
+		total := 0
+		for i := 0; i < count; i++ {
+			secret, err := randomSecret(allSecrets)
+			if err != nil {
+				t.Fatalf("randomSecret() error = %v", err)
+			}
+			guesses := simulateBotGame(t, difficulty, secret)
+			total += guesses
+			if guesses > worst[difficulty] {
+				worst[difficulty] = guesses
+			}
+		}
+		average[difficulty] = float64(total) / float64(count)
+	}
+
+	if average[botDifficultyHard] >= average[botDifficultyEasy] {
+		t.Errorf("hard bot averaged %.1f guesses, easy %.1f, want hard to need fewer", average[botDifficultyHard], average[botDifficultyEasy])
+	}
+	if average[botDifficultyMedium] >= average[botDifficultyEasy] {
+		t.Errorf("medium bot averaged %.1f guesses, easy %.1f, want medium to need fewer", average[botDifficultyMedium], average[botDifficultyEasy])
+	}
+	// Minimax over the remaining candidates always solves four digits quickly
+	if worst[botDifficultyHard] > 8 {
+		t.Errorf("hard bot needed %d guesses, want at most 8", worst[botDifficultyHard])
+	}
+}
+
+func TestBotGuessUsesFeedback(t *testing.T) {
+	// Every digit of the opening guess is wrong, so informed bots never play 1, 2, 3 or 4 again
+	history := []botFeedback{{guess: botOpeningGuess, bulls: 0, cows: 0}}
+	for _, difficulty := range []string{botDifficultyMedium, botDifficultyHard} {
+		for i := 0; i < 20; i++ {
+			guess, err := botGuess(difficulty, history)
+			if err != nil {
+				t.Fatalf("botGuess(%s) error = %v", difficulty, err)
+			}
+			if bulls, cows := calculateBullsAndCows(botOpeningGuess, guess); bulls+cows != 0 {
+				t.Fatalf("botGuess(%s) = %q, which ignores the feedback", difficulty, guess)
+			}
+		}
+	}
+
+	if _, err := botGuess("impossible", history); err == nil {
+		t.Error("botGuess() with an unknown difficulty error = nil, want an error")
+	}
+	contradiction := []botFeedback{{guess: "1234", bulls: 4}, {guess: "5678", bulls: 4}}
+	if _, err := botGuess(botDifficultyHard, contradiction); err == nil {
+		t.Error("botGuess() with contradictory feedback error = nil, want an error")
+	}
+}
+
+func TestParseBotDifficulty(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: defaultBotDifficulty},
+		{input: botDifficultyEasy, want: botDifficultyEasy},
+		{input: botDifficultyMedium, want: botDifficultyMedium},
+		{input: botDifficultyHard, want: botDifficultyHard},
+		{input: "Hard", wantErr: true},
+		{input: "expert", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseBotDifficulty(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseBotDifficulty(%q) = %q, %v, want %q (error %v)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// seedBotUser creates the bot user that migrations seed, unless it already exists
+func seedBotUser(t *testing.T, db *gorm.DB) *database.User {
+	t.Helper()
+
+	bot := &database.User{ID: database.BotUserID, Email: database.BotUserEmail, Name: "Bot", IsBot: true}
+	result := db.Where("id = ?", database.BotUserID).FirstOrCreate(bot)
+	if result.Error != nil {
+		t.Fatalf("failed to seed bot user: %v", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		t.Cleanup(func() { db.Unscoped().Delete(bot) })
+	}
+	return bot
+}
+
+func TestPlayBotAlwaysAvailable(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	seedBotUser(t, db)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	partnered := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	loner := createTestUser(t, db)
+	createTestPartnership(t, db, partnered, partner)
+	t.Cleanup(func() {
+		db.Unscoped().Where("partner2_id = ? AND game_id = ?", database.BotUserID, game.ID).Delete(&database.Play{})
+	})
+
+	playBot := func(userID uuid.UUID) (int, PlayBotResponse) {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/games/bot", PlayBotRequest{GameID: game.ID.String(), Difficulty: botDifficultyHard}, userID)
+		h.PlayBot(c)
+		var response PlayBotResponse
+		decodeResponse(t, recorder, &response)
+		return recorder.Code, response
+	}
+
+	// Users play the bot whether or not they have a partner
+	for _, user := range []*database.User{partnered, loner} {
+		code, created := playBot(user.ID)
+		if code != http.StatusCreated || !created.Created {
+			t.Fatalf("PlayBot status = %d, created %v, want %d and a new play", code, created.Created, http.StatusCreated)
+		}
+		play := created.Play
+		if play.Partner1ID != user.ID || play.Partner2ID != database.BotUserID || !play.IsPractice || !play.IsLive {
+			t.Errorf("play = %+v, want a live practice play against the bot", play)
+		}
+		if secret := play.PlayData["partner2_secret"]; secret != nil {
+			t.Errorf("bot secret revealed: %v", secret)
+		}
+		if difficulty := play.PlayData["bot_difficulty"]; difficulty != botDifficultyHard {
+			t.Errorf("bot difficulty = %v, want %s", difficulty, botDifficultyHard)
+		}
+
+		// Asking again resumes the same play
+		code, resumed := playBot(user.ID)
+		if code != http.StatusOK || resumed.Created || resumed.Play.ID != play.ID {
+			t.Errorf("second PlayBot = %d, play %s, created %v, want the existing play %s", code, resumed.Play.ID, resumed.Created, play.ID)
+		}
+	}
+}
+
+func TestBotPlaysExcludedFromStats(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	bot := seedBotUser(t, db)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+
+	// Even a bot play that wasn't marked as practice stays out of the popularity ranking
+	practice := createTestPlay(t, db, game, user, bot, database.JSONB{"status": "completed", "winner_id": user.ID.String()}, false)
+	if err := db.Model(practice).Update("is_practice", true).Error; err != nil {
+		t.Fatalf("failed to mark practice play: %v", err)
+	}
+	createTestPlay(t, db, game, bot, user, database.JSONB{"status": "completed", "winner_id": user.ID.String()}, false)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/users/me/record", nil, user.ID)
+	h.GetRecord(c)
+	var record GetRecordResponse
+	decodeResponse(t, recorder, &record)
+	// Only the unmarked play counts; the practice play is left out of the record
+	for _, gameRecord := range record.Games {
+		if gameRecord.GameID == game.ID && gameRecord.Wins != 1 {
+			t.Errorf("record wins = %d, want 1 without the practice play", gameRecord.Wins)
+		}
+	}
+
+	popular, err := database.NewPlayRepository(db).FindPopularGames(nil)
+	if err != nil {
+		t.Fatalf("FindPopularGames() error = %v", err)
+	}
+	for _, popularGame := range popular {
+		if popularGame.GameID == game.ID {
+			t.Errorf("popular games include %d bot plays", popularGame.PlayCount)
+		}
+	}
+}
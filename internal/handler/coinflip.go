@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// Coin flip outcomes
+const (
+	CoinFlipHeads = "heads"
+	CoinFlipTails = "tails"
+)
+
+// coinFlipSeedBytes is the amount of randomness drawn for each coin flip
+const coinFlipSeedBytes = 32
+
+// flipCoin draws a random seed and derives the outcome from its last bit
+// Heads picks the partnership's first member and tails the second, regardless of who flipped
+func flipCoin(partnership *database.Partnership) (seed string, outcome string, winnerID uuid.UUID, err error) {
+	buf := make([]byte, coinFlipSeedBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", uuid.Nil, err
+	}
+
+	if buf[len(buf)-1]&1 == 0 {
+		return hex.EncodeToString(buf), CoinFlipHeads, partnership.User1ID, nil
+	}
+	return hex.EncodeToString(buf), CoinFlipTails, partnership.User2ID, nil
+}
+
+// CoinFlipRequest represents the request body for flipping a coin with the current partner
+type CoinFlipRequest struct {
+	Label  string `json:"label" binding:"max=100"` // What the flip decides, e.g. "who goes first"
+	Record bool   `json:"record"`                  // Save the flip and notify the partner
+}
+
+// CoinFlipResponse represents the response for flipping a coin
+type CoinFlipResponse struct {
+	ID            *uuid.UUID `json:"id,omitempty"` // Set when the flip was recorded
+	PartnershipID uuid.UUID  `json:"partnership_id"`
+	Label         string     `json:"label"`
+	Seed          string     `json:"seed"` // Hex random bytes; the outcome is heads when the last byte is even
+	Outcome       string     `json:"outcome"`
+	WinnerID      uuid.UUID  `json:"winner_id"`
+}
+
+// FlipCoin handles making a fair random decision between the current partners
+func (h *PartnerHandler) FlipCoin(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req CoinFlipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	label, err := h.textPolicy.sanitize(req.Label, textRules{field: "label", maxLength: 100})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partner found"})
+		return
+	}
+
+	seed, outcome, winnerID, err := flipCoin(partnership)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to flip coin: " + err.Error()})
+		return
+	}
+
+	resp := CoinFlipResponse{
+		PartnershipID: partnership.ID,
+		Label:         label,
+		Seed:          seed,
+		Outcome:       outcome,
+		WinnerID:      winnerID,
+	}
+
+	if req.Record {
+		flip := &database.CoinFlip{
+			PartnershipID: partnership.ID,
+			FlippedBy:     userUUID,
+			Label:         label,
+			Seed:          seed,
+			Outcome:       outcome,
+			WinnerID:      winnerID,
+		}
+		if err := h.coinFlipRepo.Create(flip); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record coin flip: " + err.Error()})
+			return
+		}
+		resp.ID = &flip.ID
+
+		// Let the partner know so they can see the flip wasn't redone until it came out right
+		flipper, partnerID := partnership.User1, partnership.User2ID
+		if partnership.User2ID == userUUID {
+			flipper, partnerID = partnership.User2, partnership.User1ID
+		}
+		message := flipper.Email + " flipped a coin: " + outcome
+		if label != "" {
+			message = flipper.Email + " flipped a coin for \"" + label + "\": " + outcome
+		}
+		notification := &database.Notification{
+			UserID:  partnerID,
+			Type:    "coin_flip",
+			ActorID: &userUUID,
+			Message: message,
+		}
+		if err := h.notificationRepo.Create(notification); err != nil {
+			logging.FromContext(c).Error("failed to create notification", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestFlipCoinUniform(t *testing.T) {
+	partnership := &database.Partnership{User1ID: uuid.New(), User2ID: uuid.New()}
+
+	const flips = 10000
+	heads := 0
+	for i := 0; i < flips; i++ {
+		seed, outcome, winnerID, err := flipCoin(partnership)
+		if err != nil {
+			t.Fatalf("flipCoin() error = %v", err)
+		}
+
+		// The outcome can be checked against the seed
+		raw, err := hex.DecodeString(seed)
+		if err != nil || len(raw) != coinFlipSeedBytes {
+			t.Fatalf("seed = %q, want %d hex bytes", seed, coinFlipSeedBytes)
+		}
+		wantOutcome, wantWinner := CoinFlipHeads, partnership.User1ID
+		if raw[len(raw)-1]&1 == 1 {
+			wantOutcome, wantWinner = CoinFlipTails, partnership.User2ID
+		}
+		if outcome != wantOutcome || winnerID != wantWinner {
+			t.Fatalf("flipCoin() = %s for %s, want %s for %s from seed %s", outcome, winnerID, wantOutcome, wantWinner, seed)
+		}
+
+		if outcome == CoinFlipHeads {
+			heads++
+		}
+	}
+
+	// Eight standard deviations either side of half, so a fair coin essentially never fails
+	if heads < flips*46/100 || heads > flips*54/100 {
+		t.Errorf("%d heads in %d flips, want roughly half", heads, flips)
+	}
+}
+
+func TestFlipCoin(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	loner := createTestUser(t, db)
+	partnership := createTestPartnership(t, db, user, partner)
+	t.Cleanup(func() {
+		db.Where("partnership_id = ?", partnership.ID).Delete(&database.CoinFlip{})
+		db.Where("user_id = ? AND type = ?", partner.ID, "coin_flip").Delete(&database.Notification{})
+	})
+
+	flip := func(userID uuid.UUID, req CoinFlipRequest) (int, CoinFlipResponse) {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/current/coinflip", req, userID)
+		h.FlipCoin(c)
+		var response CoinFlipResponse
+		decodeResponse(t, recorder, &response)
+		return recorder.Code, response
+	}
+
+	if code, _ := flip(loner.ID, CoinFlipRequest{}); code != http.StatusNotFound {
+		t.Errorf("flip without a partner status = %d, want %d", code, http.StatusNotFound)
+	}
+
+	code, unrecorded := flip(user.ID, CoinFlipRequest{Label: "who goes first"})
+	if code != http.StatusOK || unrecorded.ID != nil || unrecorded.PartnershipID != partnership.ID {
+		t.Errorf("unrecorded flip = %d, %+v, want an unsaved flip for partnership %s", code, unrecorded, partnership.ID)
+	}
+
+	code, recorded := flip(user.ID, CoinFlipRequest{Label: "who picks the game", Record: true})
+	if code != http.StatusOK || recorded.ID == nil {
+		t.Fatalf("recorded flip = %d, %+v, want a saved flip", code, recorded)
+	}
+	if recorded.WinnerID != partnership.User1ID && recorded.WinnerID != partnership.User2ID {
+		t.Errorf("winner = %s, want one of the partners", recorded.WinnerID)
+	}
+
+	var saved []database.CoinFlip
+	if err := db.Where("partnership_id = ?", partnership.ID).Find(&saved).Error; err != nil {
+		t.Fatalf("failed to load coin flips: %v", err)
+	}
+	if len(saved) != 1 || saved[0].ID != *recorded.ID || saved[0].Seed != recorded.Seed || saved[0].FlippedBy != user.ID {
+		t.Errorf("saved flips = %+v, want only the recorded flip by %s", saved, user.ID)
+	}
+
+	var notifications int64
+	db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", partner.ID, "coin_flip").Count(&notifications)
+	if notifications != 1 {
+		t.Errorf("partner has %d coin flip notifications, want 1", notifications)
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestDeviceFingerprint(t *testing.T) {
+	fingerprint := func(userAgent, deviceID string) string {
+		c, _ := newTestContext(http.MethodGet, "/", nil, uuid.Nil)
+		c.Request.Header.Set("User-Agent", userAgent)
+		c.Request.Header.Set(DeviceIDHeader, deviceID)
+		return DeviceFingerprint(c)
+	}
+
+	phone := fingerprint("GamesApp/2.1 (iPhone)", "device-1")
+	if phone != fingerprint("GamesApp/2.1 (iPhone)", "device-1") {
+		t.Error("DeviceFingerprint() differs for the same device")
+	}
+	if phone == fingerprint("GamesApp/2.1 (iPhone)", "device-2") {
+		t.Error("DeviceFingerprint() is the same for another device ID")
+	}
+	if phone == fingerprint("GamesApp/2.1 (Android)", "device-1") {
+		t.Error("DeviceFingerprint() is the same for another User-Agent")
+	}
+}
+
+func TestCheckDeviceBinding(t *testing.T) {
+	bound := jwt.MapClaims{deviceFingerprintClaim: "phone"}
+	unbound := jwt.MapClaims{}
+
+	tests := []struct {
+		name        string
+		bindDevice  bool
+		claims      jwt.MapClaims
+		fingerprint string
+		wantErr     bool
+	}{
+		{name: "matching device", bindDevice: true, claims: bound, fingerprint: "phone"},
+		{name: "other device", bindDevice: true, claims: bound, fingerprint: "laptop", wantErr: true},
+		{name: "not presented by a device", bindDevice: true, claims: bound, fingerprint: ""},
+		{name: "issued before binding", bindDevice: true, claims: unbound, fingerprint: "laptop"},
+		{name: "binding disabled", bindDevice: false, claims: bound, fingerprint: "laptop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.JWTBindDevice = tt.bindDevice
+			h := &AuthHandler{config: cfg}
+
+			err := h.checkDeviceBinding(tt.claims, tt.fingerprint)
+			if tt.wantErr && !errors.Is(err, errDeviceMismatch) {
+				t.Errorf("checkDeviceBinding() error = %v, want %v", err, errDeviceMismatch)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("checkDeviceBinding() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestVerifyJWTDeviceBinding(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.JWTBindDevice = true
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+
+	token, err := h.generateJWT(user.ID, user.Email, "phone")
+	if err != nil {
+		t.Fatalf("generateJWT() error = %v", err)
+	}
+
+	if userID, _, err := h.VerifyJWT(token, "phone"); err != nil || userID != user.ID {
+		t.Errorf("VerifyJWT() from the same device = %s, %v, want %s", userID, err, user.ID)
+	}
+	if _, _, err := h.VerifyJWT(token, "laptop"); !errors.Is(err, errDeviceMismatch) {
+		t.Errorf("VerifyJWT() from another device error = %v, want %v", err, errDeviceMismatch)
+	}
+
+	// With binding off, tokens carry no fingerprint and work from any device
+	cfg.JWTBindDevice = false
+	token, err = h.generateJWT(user.ID, user.Email, "phone")
+	if err != nil {
+		t.Fatalf("generateJWT() error = %v", err)
+	}
+	cfg.JWTBindDevice = true
+	if _, _, err := h.VerifyJWT(token, "laptop"); err != nil {
+		t.Errorf("VerifyJWT() of an unbound token error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// defaultDisposableDomains is the built-in list of disposable email domains
+// Overridden entirely by DISPOSABLE_EMAIL_DOMAINS_PATH
+var defaultDisposableDomains = []string{
+	"10minutemail.com",
+	"discard.email",
+	"dispostable.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"maildrop.cc",
+	"mailinator.com",
+	"mailnesia.com",
+	"mintemail.com",
+	"mohmal.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// loadDisposableDomains reads the blocked domain list, one domain per line
+// Blank lines and lines starting with # are ignored; an empty path returns the built-in list
+func loadDisposableDomains(path string) (map[string]bool, error) {
+	domains := make(map[string]bool)
+	if path == "" {
+		for _, domain := range defaultDisposableDomains {
+			domains[domain] = true
+		}
+		return domains, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[line] = true
+	}
+	return domains, scanner.Err()
+}
+
+// isDisposableDomain reports whether a domain, or any domain it is a subdomain of, is blocked
+func isDisposableDomain(blocked map[string]bool, domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for domain != "" {
+		if blocked[domain] {
+			return true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestIsDisposableDomain(t *testing.T) {
+	blocked, err := loadDisposableDomains("")
+	if err != nil {
+		t.Fatalf("loadDisposableDomains() error = %v", err)
+	}
+
+	tests := []struct {
+		domain string
+		want   bool
+	}{
+		{domain: "mailinator.com", want: true},
+		{domain: "MailInator.COM", want: true},
+		{domain: "mailinator.com.", want: true},
+		{domain: "eu.mailinator.com", want: true},
+		{domain: "gmail.com", want: false},
+		{domain: "notmailinator.com", want: false},
+		{domain: "mailinator.com.example.org", want: false},
+		{domain: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := isDisposableDomain(blocked, tt.domain); got != tt.want {
+			t.Errorf("isDisposableDomain(%q) = %v, want %v", tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDisposableDomainsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	if err := os.WriteFile(path, []byte("# Blocked domains\n\n  Throwaway.Example  \nburner.test\n"), 0o600); err != nil {
+		t.Fatalf("failed to write domain list: %v", err)
+	}
+
+	blocked, err := loadDisposableDomains(path)
+	if err != nil {
+		t.Fatalf("loadDisposableDomains() error = %v", err)
+	}
+	// The file replaces the built-in list
+	want := map[string]bool{"throwaway.example": true, "burner.test": true}
+	if len(blocked) != len(want) {
+		t.Errorf("loaded %v, want %v", blocked, want)
+	}
+	for domain := range want {
+		if !blocked[domain] {
+			t.Errorf("domain %q not loaded", domain)
+		}
+	}
+
+	if _, err := loadDisposableDomains(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("loadDisposableDomains() for a missing file error = nil, want an error")
+	}
+}
+
+func TestRequestOtpBlocksDisposableEmails(t *testing.T) {
+	db := openTestDB(t)
+	existing := &database.User{Email: "existing-" + uuid.NewString() + "@mailinator.com", Name: "Existing User"}
+	if err := db.Create(existing).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(existing) })
+
+	newDisposable := "new-" + uuid.NewString() + "@mailinator.com"
+	permanent := "new-" + uuid.NewString() + "@example.com"
+	t.Cleanup(func() {
+		db.Where("email IN ?", []string{existing.Email, newDisposable, permanent}).Delete(&database.OTP{})
+	})
+
+	requestOtp := func(block bool, address string) int {
+		cfg := newTestConfig()
+		cfg.BlockDisposableEmails = block
+		h, err := NewAuthHandler(cfg, &recordingEmailClient{})
+		if err != nil {
+			t.Fatalf("NewAuthHandler() error = %v", err)
+		}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/request-otp", RequestOtpRequest{Email: address}, uuid.Nil)
+		h.RequestOtp(c)
+		return recorder.Code
+	}
+
+	tests := []struct {
+		name    string
+		block   bool
+		address string
+		want    int
+	}{
+		{name: "new disposable address", block: true, address: newDisposable, want: http.StatusBadRequest},
+		{name: "existing disposable address", block: true, address: existing.Email, want: http.StatusOK},
+		{name: "permanent address", block: true, address: permanent, want: http.StatusOK},
+		{name: "disabled", block: false, address: newDisposable, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := requestOtp(tt.block, tt.address); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// GetPlayFinalResponse represents the consolidated game over payload of a finished Bulls and Cows play
+type GetPlayFinalResponse struct {
+	PlayID         uuid.UUID              `json:"play_id"`
+	Status         string                 `json:"status"`
+	Result         interface{}            `json:"result"`                    // The standardized result recorded when the play ended
+	WinnerID       *uuid.UUID             `json:"winner_id"`                 // Nil if nobody won
+	Partner1Secret string                 `json:"partner1_secret,omitempty"` // Unset in shared mode
+	Partner2Secret string                 `json:"partner2_secret,omitempty"` // Unset in shared mode
+	SharedSecret   string                 `json:"shared_secret,omitempty"`   // Only set in shared mode
+	WinningGuess   map[string]interface{} `json:"winning_guess"`             // The guess that solved the secret; nil unless the play was solved
+	Guesses        []interface{}          `json:"guesses"`                   // Every guess in order, including archived ones
+	TotalGuesses   int                    `json:"total_guesses"`
+}
+
+// solvedPlay reports whether a play's result records the secret being guessed
+// Plays won any other way (e.g. by forfeit) have no winning guess
+func solvedPlay(playData map[string]interface{}) bool {
+	result, _ := playData["result"].(map[string]interface{})
+	return result["reason"] == database.PlayEndReasonSolved
+}
+
+// winningGuess returns the winner's last guess, which is the one that solved the secret
+func winningGuess(guesses []interface{}, winnerID string) map[string]interface{} {
+	for i := len(guesses) - 1; i >= 0; i-- {
+		guess, ok := guesses[i].(map[string]interface{})
+		if ok && guess["player_id"] == winnerID {
+			return guess
+		}
+	}
+	return nil
+}
+
+// GetPlayFinal handles getting both secrets, the outcome and the full guess list of a finished play in one call
+// Only participants can see it, and only once the play has ended
+func (h *GamesHandler) GetPlayFinal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	if !isBullsAndCows(play) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This game doesn't use secrets"})
+		return
+	}
+
+	// Secrets are only revealed once the play can't continue: completed, or ended without a winner
+	status, _ := play.PlayData["status"].(string)
+	if status != "completed" && play.IsLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Play hasn't ended yet"})
+		return
+	}
+
+	fullPlay, err := h.withFullGuessHistory(play)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load guess history: " + err.Error()})
+		return
+	}
+	guesses, _ := fullPlay.PlayData["guesses"].([]interface{})
+	if guesses == nil {
+		guesses = []interface{}{}
+	}
+
+	response := GetPlayFinalResponse{
+		PlayID:       play.ID,
+		Status:       status,
+		Result:       play.PlayData["result"],
+		Guesses:      guesses,
+		TotalGuesses: len(guesses),
+	}
+	response.Partner1Secret, _ = play.PlayData["partner1_secret"].(string)
+	response.Partner2Secret, _ = play.PlayData["partner2_secret"].(string)
+	response.SharedSecret, _ = play.PlayData["shared_secret"].(string)
+
+	if winnerIDStr, ok := play.PlayData["winner_id"].(string); ok {
+		if winnerID, err := uuid.Parse(winnerIDStr); err == nil {
+			response.WinnerID = &winnerID
+			if solvedPlay(play.PlayData) {
+				response.WinningGuess = winningGuess(guesses, winnerIDStr)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
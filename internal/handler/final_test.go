@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestSolvedPlay(t *testing.T) {
+	winnerID := uuid.New()
+
+	tests := []struct {
+		name     string
+		playData map[string]interface{}
+		want     bool
+	}{
+		{name: "solved", playData: map[string]interface{}{"result": database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonSolved, &winnerID)}, want: true},
+		{name: "forfeited", playData: map[string]interface{}{"result": database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonForfeited, &winnerID)}, want: false},
+		{name: "abandoned", playData: map[string]interface{}{"result": database.PlayResult(database.PlayOutcomeAbandoned, database.PlayEndReasonReplaced, nil)}, want: false},
+		{name: "no result", playData: map[string]interface{}{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := solvedPlay(tt.playData); got != tt.want {
+				t.Errorf("solvedPlay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWinningGuess(t *testing.T) {
+	winner, loser := uuid.New().String(), uuid.New().String()
+	guesses := []interface{}{
+		map[string]interface{}{"player_id": winner, "guess": "1234"},
+		map[string]interface{}{"player_id": loser, "guess": "5678"},
+		map[string]interface{}{"player_id": winner, "guess": "4321"},
+		map[string]interface{}{"player_id": loser, "guess": "8765"},
+	}
+
+	got := winningGuess(guesses, winner)
+	if got == nil || got["guess"] != "4321" {
+		t.Errorf("winningGuess() = %v, want the winner's last guess 4321", got)
+	}
+	if got := winningGuess(guesses, uuid.New().String()); got != nil {
+		t.Errorf("winningGuess() for a non-player = %v, want nil", got)
+	}
+}
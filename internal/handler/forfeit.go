@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// ForfeitPlayResponse represents the response for forfeiting a play
+type ForfeitPlayResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// ForfeitPlay handles a participant conceding an in-progress play, making their opponent the winner
+func (h *GamesHandler) ForfeitPlay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	// Serialize mutations of this play so a forfeit can't race a winning guess
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	// Practice plays have nobody to concede to
+	if play.IsPractice {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Practice plays can't be forfeited"})
+		return
+	}
+
+	playData := play.PlayData
+	if status, _ := playData["status"].(string); status != "playing" || !play.IsLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only plays in progress can be forfeited"})
+		return
+	}
+
+	winnerID := play.Partner1ID
+	if winnerID == userUUID {
+		winnerID = play.Partner2ID
+	}
+
+	playData["status"] = "completed"
+	playData["winner_id"] = winnerID.String()
+	playData["forfeited_by"] = userUUID.String()
+	playData["result"] = database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonForfeited, &winnerID)
+	play.PlayData = playData
+	play.IsLive = false
+
+	if err := h.playRepo.UpdatePlay(play); err != nil {
+		respondPlayUpdateError(c, err, "Failed to update play")
+		return
+	}
+	h.playHub.publish(play.ID)
+
+	// Reload play
+	play, err = h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusOK, ForfeitPlayResponse{
+		Play: play,
+	})
+}
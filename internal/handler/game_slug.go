@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bullsAndCowsGameSlug is the slug of the seeded Bulls and Cows game
+const bullsAndCowsGameSlug = "bulls-and-cows"
+
+// resolveGameRef resolves a game reference given as either an ID or a slug to the game's ID
+// IDs are returned as is without a lookup, as before slugs existed; slugs must name an existing game
+// Writes the error response and returns false if the reference can't be resolved
+func (h *GamesHandler) resolveGameRef(c *gin.Context, ref string) (uuid.UUID, bool) {
+	if gameID, err := uuid.Parse(ref); err == nil {
+		return gameID, true
+	}
+
+	if ref == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return uuid.Nil, false
+	}
+
+	game, err := h.gameRepo.FindBySlug(ref)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch game: " + err.Error()})
+		}
+		return uuid.Nil, false
+	}
+	return game.ID, true
+}
+
+// GetGameBySlug handles looking up a game by its slug
+func (h *GamesHandler) GetGameBySlug(c *gin.Context) {
+	game, err := h.gameRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch game: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, game)
+}
@@ -1,32 +1,218 @@
 package handler
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
 
+	"github.com/games-app/backend/internal/config"
 	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
+	"github.com/games-app/backend/internal/logging"
 )
 
 // GamesHandler handles game-related requests
 type GamesHandler struct {
-	partnershipRepo *database.PartnershipRepository
-	gameRepo        *database.GameRepository
-	gameRequestRepo *database.GameRequestRepository
-	playRepo        *database.PlayRepository
+	userRepo         *database.UserRepository
+	partnershipRepo  *database.PartnershipRepository
+	gameRepo         *database.GameRepository
+	gameRequestRepo  *database.GameRequestRepository
+	playRepo         *database.PlayRepository
+	prefsRepo        *database.UserPreferencesRepository
+	notificationRepo *database.NotificationRepository
+	playMessageRepo  *database.PlayMessageRepository
+	playStatRepo     *database.PlayStatRepository
+	emailClient      email.EmailClient
+
+	// Sends a partner invite when a user without a partner starts a game with an invitee email
+	partnerHandler       *PartnerHandler
+	inviteWithoutPartner bool
+
+	// Last summary email sent per user+play, used for rate limiting; entries expire after summaryEmailCooldown
+	summaryEmailsMu sync.Mutex
+	summaryEmails   map[string]time.Time
+
+	// Global stats per game, recomputed after globalStatsTTL
+	globalStatsMu sync.Mutex
+	globalStats   map[uuid.UUID]cachedGlobalStats
+
+	// Popular game rankings per since window, recomputed after popularGamesTTL
+	popularGamesMu sync.Mutex
+	popularGames   map[time.Duration]cachedPopularGames
+
+	// Invalid secret attempts per user+play, used to throttle probing of the validation rules
+	secretAttemptLimit    int
+	secretAttemptCooldown time.Duration
+	secretAttemptsMu      sync.Mutex
+	secretAttempts        map[string]*secretAttempts
+
+	// Heuristic cheat scoring of won plays
+	cheatDetection          bool
+	cheatSuspicionThreshold int
+
+	// Guesses kept in a play row before older ones are archived (0 keeps them all in the row)
+	guessArchiveThreshold int
+
+	// Live plays a user may have at once (0 is unlimited)
+	maxLivePlaysPerUser int
+
+	// Page size policy of list endpoints
+	pageLimits pageLimits
+
+	// Cleaning and profanity policy of user-supplied text
+	textPolicy textPolicy
+
+	// WebSocket watchers of plays, notified after each play update
+	playHub      *playHub
+	playUpgrader websocket.Upgrader
 }
 
 // NewGamesHandler creates a new games handler
-func NewGamesHandler() *GamesHandler {
+// The partner handler sends the invites created by PlayGame for users without a partner
+func NewGamesHandler(cfg *config.Config, emailClient email.EmailClient, partnerHandler *PartnerHandler) *GamesHandler {
 	return &GamesHandler{
-		partnershipRepo: database.NewPartnershipRepository(database.DB),
-		gameRepo:        database.NewGameRepository(database.DB),
-		gameRequestRepo: database.NewGameRequestRepository(database.DB),
-		playRepo:        database.NewPlayRepository(database.DB),
+		userRepo:         database.NewUserRepository(database.DB),
+		partnershipRepo:  database.NewPartnershipRepository(database.DB),
+		gameRepo:         database.NewGameRepository(database.DB),
+		gameRequestRepo:  database.NewGameRequestRepository(database.DB),
+		playRepo:         database.NewPlayRepository(database.DB),
+		prefsRepo:        database.NewUserPreferencesRepository(database.DB),
+		notificationRepo: newNotificationRepository(cfg),
+		playMessageRepo:  database.NewPlayMessageRepository(database.DB),
+		playStatRepo:     database.NewPlayStatRepository(database.DB),
+		emailClient:      emailClient,
+		summaryEmails:    make(map[string]time.Time),
+		globalStats:      make(map[uuid.UUID]cachedGlobalStats),
+		popularGames:     make(map[time.Duration]cachedPopularGames),
+
+		partnerHandler:       partnerHandler,
+		inviteWithoutPartner: cfg.InviteOnPlayWithoutPartner,
+
+		secretAttemptLimit:    cfg.SecretAttemptLimit,
+		secretAttemptCooldown: time.Duration(cfg.SecretAttemptCooldownSeconds) * time.Second,
+		secretAttempts:        make(map[string]*secretAttempts),
+
+		cheatDetection:          cfg.CheatDetectionEnabled,
+		cheatSuspicionThreshold: cfg.CheatSuspicionThreshold,
+
+		guessArchiveThreshold: cfg.GuessArchiveThreshold,
+
+		maxLivePlaysPerUser: cfg.MaxLivePlaysPerUser,
+
+		pageLimits: newPageLimits(cfg),
+		textPolicy: newTextPolicy(cfg),
+
+		playHub:      newPlayHub(),
+		playUpgrader: newPlayUpgrader(cfg.CORSAllowedOrigins),
+	}
+}
+
+// allowNewLivePlay checks that starting a live play of the game keeps both players within the live play limit,
+// writing a 429 if it doesn't
+// With replacesLive, the new play ends the players' live play of the same game, which then doesn't count
+// The bot has no limit
+func (h *GamesHandler) allowNewLivePlay(c *gin.Context, gameID uuid.UUID, replacesLive bool, callerID, opponentID uuid.UUID) bool {
+	if h.maxLivePlaysPerUser == 0 {
+		return true
+	}
+
+	replaced := int64(0)
+	if replacesLive {
+		if _, err := h.playRepo.FindLivePlayByPartners(callerID, opponentID, gameID); err == nil {
+			replaced = 1
+		}
+	}
+
+	players := []uuid.UUID{callerID}
+	if opponentID != callerID && opponentID != database.BotUserID {
+		players = append(players, opponentID)
+	}
+	for _, playerID := range players {
+		count, err := h.playRepo.CountLivePlaysByUser(playerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count live plays: " + err.Error()})
+			return false
+		}
+		if count-replaced >= int64(h.maxLivePlaysPerUser) {
+			message := "You have too many games in progress. Finish one before starting another."
+			if playerID != callerID {
+				message = "Your partner has too many games in progress. They need to finish one first."
+			}
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": message})
+			return false
+		}
+	}
+	return true
+}
+
+// Error codes returned alongside partnership errors; messages are in errorMessages
+const (
+	errorCodeNoPartner    = "NO_PARTNER"    // The action needs a partner and the user has none
+	errorCodeNotPartnered = "NOT_PARTNERED" // The action names a user the caller isn't partnered with
+)
+
+// respondNoPartner writes the coded error for a user without a partner
+func respondNoPartner(c *gin.Context) {
+	respondError(c, http.StatusBadRequest, errorCodeNoPartner)
+}
+
+// resolvePartnership finds the partnership a game action applies to
+// Uses the named partner if given, otherwise the user's default partnership, otherwise their only partnership
+func (h *GamesHandler) resolvePartnership(userID uuid.UUID, partnerIDStr string) (*database.Partnership, error) {
+	if partnerIDStr != "" {
+		partnerID, err := uuid.Parse(partnerIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", errInvalidPartnerID, partnerIDStr)
+		}
+		partnership, err := h.partnershipRepo.FindPartnershipBetween(userID, partnerID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errNotPartnered
+		}
+		return partnership, err
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err == nil && user.DefaultPartnershipID != nil {
+		partnership, err := h.partnershipRepo.FindPartnershipByID(*user.DefaultPartnershipID)
+		if err == nil && (partnership.User1ID == userID || partnership.User2ID == userID) {
+			return partnership, nil
+		}
+	}
+
+	return h.partnershipRepo.FindPartnershipByUser(userID)
+}
+
+// errNotPartnered is returned when a game action names a user the caller isn't partnered with
+var errNotPartnered = errors.New("not partnered with this user")
+
+// errInvalidPartnerID is returned when a game action names a partner by a malformed ID
+var errInvalidPartnerID = errors.New("invalid partner_id")
+
+// respondPartnershipError writes the error for a partnership that couldn't be resolved
+func respondPartnershipError(c *gin.Context, err error) {
+	if errors.Is(err, errInvalidPartnerID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, errNotPartnered) {
+		respondError(c, http.StatusForbidden, errorCodeNotPartnered)
+		return
 	}
+	respondNoPartner(c)
 }
 
 // ListGamesResponse represents the response for listing games
@@ -47,25 +233,106 @@ func (h *GamesHandler) ListGames(c *gin.Context) {
 	})
 }
 
+// GameWithStatus represents a game annotated with the user's activity in it
+type GameWithStatus struct {
+	database.Game
+	HasLivePlay       bool `json:"has_live_play"`
+	HasPendingRequest bool `json:"has_pending_request"`
+}
+
+// ListGamesWithStatusResponse represents the response for listing games with status
+type ListGamesWithStatusResponse struct {
+	Games []GameWithStatus `json:"games"`
+}
+
+// ListGamesWithStatus handles listing all games annotated with the user's live plays and pending requests
+func (h *GamesHandler) ListGamesWithStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	games, err := h.gameRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch games: " + err.Error()})
+		return
+	}
+
+	liveGames := make(map[uuid.UUID]bool)
+	pendingGames := make(map[uuid.UUID]bool)
+
+	// Users without a partner simply get every game marked as idle
+	partnership, err := h.resolvePartnership(userUUID, c.Query("partner_id"))
+	if errors.Is(err, errInvalidPartnerID) {
+		respondPartnershipError(c, err)
+		return
+	}
+	if err == nil {
+		plays, err := h.playRepo.FindLivePlaysByPartners(partnership.User1ID, partnership.User2ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+			return
+		}
+		for _, play := range plays {
+			liveGames[play.GameID] = true
+		}
+
+		requests, err := h.gameRequestRepo.FindPendingRequestsBetweenPartners(partnership.User1ID, partnership.User2ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch requests: " + err.Error()})
+			return
+		}
+		for _, request := range requests {
+			pendingGames[request.GameID] = true
+		}
+	}
+
+	result := make([]GameWithStatus, 0, len(games))
+	for _, game := range games {
+		result = append(result, GameWithStatus{
+			Game:              game,
+			HasLivePlay:       liveGames[game.ID],
+			HasPendingRequest: pendingGames[game.ID],
+		})
+	}
+
+	c.JSON(http.StatusOK, ListGamesWithStatusResponse{
+		Games: result,
+	})
+}
+
 // CreateGameRequestRequest represents the request body for creating a game request
 type CreateGameRequestRequest struct {
-	GameID string `json:"game_id" binding:"required"`
+	GameID    string `json:"game_id" binding:"required"` // Game ID or slug
+	PartnerID string `json:"partner_id"`                 // Optional, defaults to the user's default partner
 }
 
 // CreateGameRequestResponse represents the response for creating a game request
+// Play is set instead of Request when the partners auto-accept each other's requests
 type CreateGameRequestResponse struct {
 	Request *database.GameRequest `json:"request"`
+	Play    *database.Play        `json:"play,omitempty"`
 }
 
 // PlayGameRequest represents the request body for playing a game
 type PlayGameRequest struct {
-	GameID string `json:"game_id" binding:"required"`
+	GameID      string `json:"game_id" binding:"required"`                     // Game ID or slug
+	PartnerID   string `json:"partner_id"`                                     // Optional, defaults to the user's default partner
+	InviteEmail string `json:"invite_email" binding:"omitempty,email,max=255"` // Invited as a partner if the user has none (when enabled)
 }
 
 // PlayGameResponse represents the response for playing a game
 type PlayGameResponse struct {
-	Play    *database.Play        `json:"play,omitempty"`
-	Request *database.GameRequest `json:"request,omitempty"`
+	Play           *database.Play           `json:"play,omitempty"`
+	Request        *database.GameRequest    `json:"request,omitempty"`
+	PartnerRequest *database.PartnerRequest `json:"partner_request,omitempty"` // Set when a partner invite was sent instead; accepting it requests the game
 }
 
 // PlayGame handles starting or joining a game
@@ -89,55 +356,66 @@ func (h *GamesHandler) PlayGame(c *gin.Context) {
 		return
 	}
 
-	gameID, err := uuid.Parse(req.GameID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+	gameID, ok := h.resolveGameRef(c, req.GameID)
+	if !ok {
 		return
 	}
 
 	// Verify game exists
-	_, err = h.gameRepo.FindByID(gameID)
+	game, err := h.gameRepo.FindByID(gameID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
 		return
 	}
 
-	// Get user's partnership
-	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	plan, err := h.planPlayGame(userUUID, gameID, req.PartnerID, req.InviteEmail)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You don't have a partner"})
+		respondPartnershipError(c, err)
 		return
 	}
 
-	// Determine partner ID
-	var partnerID uuid.UUID
-	if partnership.User1ID == userUUID {
-		partnerID = partnership.User2ID
-	} else {
-		partnerID = partnership.User1ID
+	// Without a partner, invite the given email instead, so the game can start once they accept
+	if plan.state == playPreviewWouldInvite {
+		partnerRequest, err := h.partnerHandler.createPartnerRequest(c, userUUID, req.InviteEmail, &gameID)
+		if err != nil {
+			respondPartnerRequestRefusal(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, PlayGameResponse{
+			PartnerRequest: partnerRequest,
+		})
+		return
 	}
 
-	// First, check if there's already a live play for this game
-	play, err := h.playRepo.FindLivePlayByPartners(partnership.User1ID, partnership.User2ID, gameID)
-	if err == nil && play != nil {
-		// There's a live play, return it
+	partnerID := plan.partnerID
+
+	switch plan.state {
+	case playPreviewLivePlay:
+		hideOpponentSecret(plan.play, userUUID)
 		c.JSON(http.StatusOK, PlayGameResponse{
-			Play: play,
+			Play: plan.play,
+		})
+		return
+	case playPreviewPendingRequest:
+		c.JSON(http.StatusOK, PlayGameResponse{
+			Request: plan.request,
 		})
 		return
 	}
 
-	// No live play exists, check if there's already a pending request
-	pendingRequests, err := h.gameRequestRepo.FindPendingRequestsByRequester(userUUID)
-	if err == nil {
-		for _, pr := range pendingRequests {
-			if pr.GameID == gameID && pr.PartnerID == partnerID {
-				c.JSON(http.StatusOK, PlayGameResponse{
-					Request: &pr,
-				})
-				return
-			}
+	if plan.state == playPreviewWouldStartPlay {
+		if !h.allowNewLivePlay(c, game.ID, true, userUUID, partnerID) {
+			return
+		}
+		play, err := h.startAutoAcceptedPlay(c, game, userUUID, partnerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+			return
 		}
+		c.JSON(http.StatusOK, PlayGameResponse{
+			Play: play,
+		})
+		return
 	}
 
 	// Create game request (valid for 24 hours)
@@ -154,6 +432,9 @@ func (h *GamesHandler) PlayGame(c *gin.Context) {
 		return
 	}
 
+	// Notify the partner (failures shouldn't block the request)
+	h.notifyGameRequest(c, request, game)
+
 	// Load request with relations
 	request, err = h.gameRequestRepo.FindRequestByID(request.ID)
 	if err != nil {
@@ -166,6 +447,127 @@ func (h *GamesHandler) PlayGame(c *gin.Context) {
 	})
 }
 
+// What playing a game does, as decided by planPlayGame and reported by PlayGamePreview
+const (
+	playPreviewLivePlay           = "live_play"
+	playPreviewPendingRequest     = "pending_request_exists"
+	playPreviewWouldCreateRequest = "would_create_request"
+	playPreviewWouldStartPlay     = "would_start_play"     // The partners auto-accept each other's requests
+	playPreviewWouldInvite        = "would_invite_partner" // The user has no partner and gave an invite email
+	playPreviewNoPartner          = "no_partner"
+)
+
+// playGamePlan is what PlayGame does for a user and game
+type playGamePlan struct {
+	state     string
+	partnerID uuid.UUID
+	play      *database.Play        // The live play, for playPreviewLivePlay
+	request   *database.GameRequest // The pending request, for playPreviewPendingRequest
+}
+
+// planPlayGame decides what playing a game does for the user, without changing anything
+// Shared by PlayGame and PlayGamePreview so the preview always matches what playing does
+// Returns the partnership lookup error if there is no partner to play and no invite applies
+func (h *GamesHandler) planPlayGame(userID, gameID uuid.UUID, partnerIDStr, inviteEmail string) (*playGamePlan, error) {
+	partnership, err := h.resolvePartnership(userID, partnerIDStr)
+	if err != nil {
+		if h.inviteWithoutPartner && inviteEmail != "" && partnerIDStr == "" {
+			return &playGamePlan{state: playPreviewWouldInvite}, nil
+		}
+		return nil, err
+	}
+
+	plan := &playGamePlan{partnerID: partnership.User1ID}
+	if plan.partnerID == userID {
+		plan.partnerID = partnership.User2ID
+	}
+
+	// First, check if there's already a live play for this game
+	play, err := h.playRepo.FindLivePlayByPartners(partnership.User1ID, partnership.User2ID, gameID)
+	if err == nil && play != nil {
+		plan.state = playPreviewLivePlay
+		plan.play = play
+		return plan, nil
+	}
+
+	// No live play exists, check if there's already a pending request
+	pendingRequests, err := h.gameRequestRepo.FindPendingRequestsByRequester(userID)
+	if err == nil {
+		for i, pr := range pendingRequests {
+			if pr.GameID == gameID && pr.PartnerID == plan.partnerID {
+				plan.state = playPreviewPendingRequest
+				plan.request = &pendingRequests[i]
+				return plan, nil
+			}
+		}
+	}
+
+	// Partners who both opted in skip the request and start playing right away
+	if autoAcceptEnabled(partnership) {
+		plan.state = playPreviewWouldStartPlay
+		return plan, nil
+	}
+
+	plan.state = playPreviewWouldCreateRequest
+	return plan, nil
+}
+
+// PlayGamePreviewResponse represents the response for previewing what playing a game would do
+type PlayGamePreviewResponse struct {
+	State     string     `json:"state"`
+	PlayID    *uuid.UUID `json:"play_id,omitempty"`
+	RequestID *uuid.UUID `json:"request_id,omitempty"`
+}
+
+// PlayGamePreview handles reporting what PlayGame would do for a game, without mutating anything
+// Takes PlayGame's partner_id and invite_email as query parameters
+func (h *GamesHandler) PlayGamePreview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
+	if !ok {
+		return
+	}
+
+	// Verify game exists
+	_, err := h.gameRepo.FindByID(gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	plan, err := h.planPlayGame(userUUID, gameID, c.Query("partner_id"), c.Query("invite_email"))
+	if errors.Is(err, errInvalidPartnerID) {
+		respondPartnershipError(c, err)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, PlayGamePreviewResponse{
+			State: playPreviewNoPartner,
+		})
+		return
+	}
+
+	response := PlayGamePreviewResponse{State: plan.state}
+	if plan.play != nil {
+		response.PlayID = &plan.play.ID
+	}
+	if plan.request != nil {
+		response.RequestID = &plan.request.ID
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // CreateGameRequest handles creating a new game request
 func (h *GamesHandler) CreateGameRequest(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -186,23 +588,22 @@ func (h *GamesHandler) CreateGameRequest(c *gin.Context) {
 		return
 	}
 
-	gameID, err := uuid.Parse(req.GameID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+	gameID, ok := h.resolveGameRef(c, req.GameID)
+	if !ok {
 		return
 	}
 
 	// Verify game exists
-	_, err = h.gameRepo.FindByID(gameID)
+	game, err := h.gameRepo.FindByID(gameID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
 		return
 	}
 
 	// Get user's partnership
-	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	partnership, err := h.resolvePartnership(userUUID, req.PartnerID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You don't have a partner"})
+		respondPartnershipError(c, err)
 		return
 	}
 
@@ -225,6 +626,22 @@ func (h *GamesHandler) CreateGameRequest(c *gin.Context) {
 		}
 	}
 
+	// Partners who both opted in skip the request and start playing right away
+	if autoAcceptEnabled(partnership) {
+		if !h.allowNewLivePlay(c, game.ID, true, userUUID, partnerID) {
+			return
+		}
+		play, err := h.startAutoAcceptedPlay(c, game, userUUID, partnerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, CreateGameRequestResponse{
+			Play: play,
+		})
+		return
+	}
+
 	// Create game request (valid for 24 hours)
 	request := &database.GameRequest{
 		GameID:      gameID,
@@ -239,6 +656,9 @@ func (h *GamesHandler) CreateGameRequest(c *gin.Context) {
 		return
 	}
 
+	// Notify the partner (failures shouldn't block the request)
+	h.notifyGameRequest(c, request, game)
+
 	// Load request with relations
 	request, err = h.gameRequestRepo.FindRequestByID(request.ID)
 	if err != nil {
@@ -251,6 +671,63 @@ func (h *GamesHandler) CreateGameRequest(c *gin.Context) {
 	})
 }
 
+// notifyGameRequest creates a notification for the partner receiving a game request
+func (h *GamesHandler) notifyGameRequest(c *gin.Context, request *database.GameRequest, game *database.Game) {
+	requesterID := request.RequesterID
+	gameID := request.GameID
+	notification := &database.Notification{
+		UserID:  request.PartnerID,
+		Type:    "game_request",
+		ActorID: &requesterID,
+		GameID:  &gameID,
+		Message: "Your partner wants to play " + game.Name,
+	}
+	if err := h.notificationRepo.Create(notification); err != nil {
+		logging.FromContext(c).Error("failed to create notification", "error", err)
+	}
+}
+
+// startAutoAcceptedPlay starts a live play between partners who auto-accept each other's game requests
+// Any live play of the same game between them is ended first, as when a request is accepted
+// The returned play is redacted for the requester
+func (h *GamesHandler) startAutoAcceptedPlay(c *gin.Context, game *database.Game, requesterID, partnerID uuid.UUID) (*database.Play, error) {
+	playData, err := initialPlayData(*game, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	play := &database.Play{
+		GameID:     game.ID,
+		Partner1ID: requesterID,
+		Partner2ID: partnerID,
+		PlayData:   playData,
+		IsLive:     true,
+	}
+	if err := h.playRepo.ReplaceLivePlay(play); err != nil {
+		return nil, err
+	}
+
+	// Let the partner know a game started without them accepting (failures shouldn't block the play)
+	gameID := game.ID
+	notification := &database.Notification{
+		UserID:  partnerID,
+		Type:    "game_started",
+		ActorID: &requesterID,
+		GameID:  &gameID,
+		Message: "Your partner started a game of " + game.Name,
+	}
+	if err := h.notificationRepo.Create(notification); err != nil {
+		logging.FromContext(c).Error("failed to create notification", "error", err)
+	}
+
+	play, err = h.playRepo.FindPlayByID(play.ID)
+	if err != nil {
+		return nil, err
+	}
+	hideOpponentSecret(play, requesterID)
+	return play, nil
+}
+
 // GetPendingGameRequestsResponse represents the response for getting pending game requests
 type GetPendingGameRequestsResponse struct {
 	Requests []database.GameRequest `json:"requests"`
@@ -285,6 +762,49 @@ func (h *GamesHandler) GetPendingGameRequests(c *gin.Context) {
 	})
 }
 
+// GetGameRequestResponse represents the response for getting a single game request
+type GetGameRequestResponse struct {
+	Request *database.GameRequest `json:"request"`
+}
+
+// GetGameRequest handles getting a single game request sent or received by the current user
+func (h *GamesHandler) GetGameRequest(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	requestIDStr := c.Param("id")
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	// Unrelated users get the same 404 as a missing request so IDs can't be probed
+	request, err := h.gameRequestRepo.FindRequestByID(requestID)
+	if err != nil || (request.RequesterID != userUUID && request.PartnerID != userUUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Request not found"})
+		return
+	}
+
+	// Report lapsed requests as expired even if no sweep has marked them yet; the sweep persists the status
+	if request.Status == "pending" && request.IsExpired() {
+		request.Status = "expired"
+	}
+
+	c.JSON(http.StatusOK, GetGameRequestResponse{
+		Request: request,
+	})
+}
+
 // RespondToGameRequestRequest represents the request body for responding to a game request
 type RespondToGameRequestRequest struct {
 	Accept bool `json:"accept"`
@@ -339,7 +859,10 @@ func (h *GamesHandler) RespondToGameRequest(c *gin.Context) {
 	// Check if request is expired
 	if request.IsExpired() {
 		request.Status = "expired"
-		h.gameRequestRepo.UpdateRequest(request)
+		if err := h.gameRequestRepo.UpdateRequest(request); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update request: " + err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "This request has expired"})
 		return
 	}
@@ -351,30 +874,32 @@ func (h *GamesHandler) RespondToGameRequest(c *gin.Context) {
 	}
 
 	if req.Accept {
-		// Accept the request
-		request.Status = "accepted"
-		if err := h.gameRequestRepo.UpdateRequest(request); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update request: " + err.Error()})
+		if !h.allowNewLivePlay(c, request.GameID, true, userUUID, request.RequesterID) {
 			return
 		}
 
-		// End any existing live plays for this partner combination
-		_ = h.playRepo.EndAllLivePlaysByPartners(request.RequesterID, request.PartnerID)
+		playData, err := initialPlayData(request.Game, request.RequesterID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize play: " + err.Error()})
+			return
+		}
 
-		// Create a new play
 		play := &database.Play{
 			GameID:     request.GameID,
 			Partner1ID: request.RequesterID,
 			Partner2ID: request.PartnerID,
-			PlayData:   database.JSONB{},
+			PlayData:   playData,
 			IsLive:     true,
 		}
 
-		if err := h.playRepo.CreatePlay(play); err != nil {
-			// Rollback request status
-			request.Status = "pending"
-			h.gameRequestRepo.UpdateRequest(request)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+		// Accepting the request, ending any live play of this game for this partner combination (other games
+		// keep going) and creating the new play happen together or not at all
+		if err := h.gameRequestRepo.AcceptRequest(request, play); err != nil {
+			if errors.Is(err, database.ErrRequestNotPending) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Request has already been responded to"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept request: " + err.Error()})
 			return
 		}
 
@@ -383,6 +908,8 @@ func (h *GamesHandler) RespondToGameRequest(c *gin.Context) {
 		if err != nil {
 			// Play created but failed to load, still return success
 			play = nil
+		} else {
+			hideOpponentSecret(play, userUUID)
 		}
 
 		c.JSON(http.StatusOK, RespondToGameRequestResponse{
@@ -405,7 +932,9 @@ func (h *GamesHandler) RespondToGameRequest(c *gin.Context) {
 
 // GetLivePlayResponse represents the response for getting a live play
 type GetLivePlayResponse struct {
-	Play *database.Play `json:"play"`
+	Play         *database.Play `json:"play"`
+	TotalGuesses int            `json:"total_guesses"`
+	OpponentBest int            `json:"opponent_best"` // Opponent's highest bulls count so far
 }
 
 // GetLivePlay handles getting the live play for a game and partnership
@@ -422,37 +951,143 @@ func (h *GamesHandler) GetLivePlay(c *gin.Context) {
 		return
 	}
 
-	gameIDStr := c.Param("gameId")
-	gameID, err := uuid.Parse(gameIDStr)
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
+	if !ok {
+		return
+	}
+
+	guessesLimit, err := parseGuessesLimit(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Get user's partnership
-	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	partnership, err := h.resolvePartnership(userUUID, c.Query("partner_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You don't have a partner"})
+		respondPartnershipError(c, err)
 		return
 	}
 
 	// Find live play
 	play, err := h.playRepo.FindLivePlayByPartners(partnership.User1ID, partnership.User2ID, gameID)
 	if err != nil {
+		respondPlayLookupError(c, err, "No live play found")
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	opponentBest, err := h.opponentBestBulls(play, userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch guesses: " + err.Error()})
+		return
+	}
+	totalGuesses := limitGuesses(play, guessesLimit)
+
+	c.JSON(http.StatusOK, GetLivePlayResponse{
+		Play:         play,
+		TotalGuesses: totalGuesses,
+		OpponentBest: opponentBest,
+	})
+}
+
+// GetLivePlayWithOpponent handles getting the live play for a game against a specific opponent
+func (h *GamesHandler) GetLivePlayWithOpponent(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
+	if !ok {
+		return
+	}
+
+	opponentIDStr := c.Param("opponentId")
+	opponentID, err := uuid.Parse(opponentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid opponent ID"})
+		return
+	}
+
+	guessesLimit, err := parseGuessesLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Verify the caller is partnered with the opponent
+	// Not being partnered is reported the same as no play so partnerships can't be probed
+	if _, err := h.partnershipRepo.FindPartnershipBetween(userUUID, opponentID); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "No live play found"})
 		return
 	}
 
+	// Find live play (lookup normalizes partner ordering)
+	play, err := h.playRepo.FindLivePlayByPartners(userUUID, opponentID, gameID)
+	if err != nil {
+		respondPlayLookupError(c, err, "No live play found")
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	opponentBest, err := h.opponentBestBulls(play, userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch guesses: " + err.Error()})
+		return
+	}
+	totalGuesses := limitGuesses(play, guessesLimit)
+
 	c.JSON(http.StatusOK, GetLivePlayResponse{
-		Play: play,
+		Play:         play,
+		TotalGuesses: totalGuesses,
+		OpponentBest: opponentBest,
 	})
 }
 
 // UpdatePlayRequest represents the request body for updating a play
 type UpdatePlayRequest struct {
-	PlayData database.JSONB `json:"play_data" binding:"required"`
+	PlayData json.RawMessage `json:"play_data" binding:"required"`
+}
+
+// parsePlayData validates that raw play_data is a non-empty JSON object
+func parsePlayData(raw json.RawMessage) (database.JSONB, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, fmt.Errorf("play_data is required")
+	}
+	if trimmed[0] != '{' {
+		return nil, fmt.Errorf("play_data must be a JSON object")
+	}
+
+	var playData database.JSONB
+	if err := json.Unmarshal(trimmed, &playData); err != nil {
+		return nil, fmt.Errorf("play_data must be a JSON object")
+	}
+	if len(playData) == 0 {
+		return nil, fmt.Errorf("play_data must not be empty")
+	}
+	return playData, nil
 }
 
+// serverOwnedPlayKeys are the PlayData keys UpdatePlay never takes from the client
+// Outcomes, turns, guesses, secrets and scores are written only by the move endpoints, which validate them;
+// pause bookkeeping is owned by the pause/resume endpoints, and the archive count by the archive
+var serverOwnedPlayKeys = append([]string{
+	"status", "winner_id", "result", "score", "forfeited_by", "current_turn", "guesses",
+	"partner1_secret", "partner2_secret", "shared_secret", "bot_difficulty",
+	database.ArchivedGuessCountKey,
+}, pauseKeys...)
+
 // UpdatePlayResponse represents the response for updating a play
 type UpdatePlayResponse struct {
 	Play *database.Play `json:"play"`
@@ -485,10 +1120,20 @@ func (h *GamesHandler) UpdatePlay(c *gin.Context) {
 		return
 	}
 
+	playData, err := parsePlayData(req.PlayData)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
 	// Get play
 	play, err := h.playRepo.FindPlayByID(playID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Play not found"})
+		respondPlayLookupError(c, err, "Play not found")
 		return
 	}
 
@@ -498,12 +1143,40 @@ func (h *GamesHandler) UpdatePlay(c *gin.Context) {
 		return
 	}
 
+	// Ended plays are final
+	if !play.IsLive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Play has ended"})
+		return
+	}
+
+	// Games with server-side rules change only through their own endpoints, which enforce those rules
+	if isBullsAndCows(play) || isTicTacToe(play) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This game's plays can't be updated directly; use its move endpoints"})
+		return
+	}
+
+	// No moves can be made while the play is paused
+	if isPlayPaused(play.PlayData) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Play is paused"})
+		return
+	}
+
+	// Server-owned keys keep their stored values, so clients can't forge results, turns or secrets
+	for _, key := range serverOwnedPlayKeys {
+		if value, exists := play.PlayData[key]; exists {
+			playData[key] = value
+		} else {
+			delete(playData, key)
+		}
+	}
+
 	// Update play data
-	play.PlayData = req.PlayData
+	play.PlayData = playData
 	if err := h.playRepo.UpdatePlay(play); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update play: " + err.Error()})
+		respondPlayUpdateError(c, err, "Failed to update play")
 		return
 	}
+	h.playHub.publish(play.ID)
 
 	// Reload play
 	play, err = h.playRepo.FindPlayByID(playID)
@@ -512,18 +1185,24 @@ func (h *GamesHandler) UpdatePlay(c *gin.Context) {
 		return
 	}
 
+	hideOpponentSecret(play, userUUID)
+
 	c.JSON(http.StatusOK, UpdatePlayResponse{
 		Play: play,
 	})
 }
 
-// GetPlayByIdResponse represents the response for getting a play by ID
-type GetPlayByIdResponse struct {
-	Play *database.Play `json:"play"`
+// GetPlayHistoryResponse represents the response for getting the user's play history
+type GetPlayHistoryResponse struct {
+	Plays  []database.Play `json:"plays"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
 }
 
-// GetPlayById handles getting a play by ID
-func (h *GamesHandler) GetPlayById(c *gin.Context) {
+// GetPlayHistory handles listing the plays the current user took part in
+// Supports limit/offset pagination and from/to (RFC3339) filtering on creation time
+func (h *GamesHandler) GetPlayHistory(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -536,94 +1215,117 @@ func (h *GamesHandler) GetPlayById(c *gin.Context) {
 		return
 	}
 
-	playIDStr := c.Param("id")
-	playID, err := uuid.Parse(playIDStr)
+	page, err := parsePagination(c, h.pageLimits)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get play
-	play, err := h.playRepo.FindPlayByID(playID)
+	from, to, err := parseDateRange(c)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Play not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Verify user is part of this play
-	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+	plays, total, err := h.playRepo.FindPlaysByUser(userUUID, database.PlayFilter{
+		From:       from,
+		To:         to,
+		Pagination: page,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
 		return
 	}
 
-	// For Bulls and Cows, hide opponent's secret until game is completed
-	if play.GameID.String() == "550e8400-e29b-41d4-a716-446655440001" {
-		playData := play.PlayData
-		if playData != nil {
-			// Determine which partner the user is
-			isPartner1 := play.Partner1ID == userUUID
-
-			// Hide opponent's secret if game is not completed
-			if status, exists := playData["status"]; exists && status != "completed" {
-				if isPartner1 {
-					// Hide partner2's secret
-					playData["partner2_secret"] = nil
-				} else {
-					// Hide partner1's secret
-					playData["partner1_secret"] = nil
-				}
-				play.PlayData = playData
-			}
-		}
+	// Never expose the opponent's secret for unfinished plays
+	for i := range plays {
+		hideOpponentSecret(&plays[i], userUUID)
 	}
 
-	c.JSON(http.StatusOK, GetPlayByIdResponse{
-		Play: play,
+	c.JSON(http.StatusOK, GetPlayHistoryResponse{
+		Plays:  plays,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
 	})
 }
 
-// SetSecretRequest represents the request body for setting a secret
-type SetSecretRequest struct {
-	Secret string `json:"secret" binding:"required,len=4"`
+// GetMyTurnsResponse represents the response for getting the live plays waiting on the user
+type GetMyTurnsResponse struct {
+	Count int             `json:"count"`
+	Plays []database.Play `json:"plays"`
 }
 
-// SetSecretResponse represents the response for setting a secret
-type SetSecretResponse struct {
-	Play *database.Play `json:"play"`
-}
+// GetMyTurns handles listing the live plays, across all partnerships, where it is the current user's turn
+func (h *GamesHandler) GetMyTurns(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
 
-// validateSecret validates a 4-digit secret number
-func validateSecret(secret string) error {
-	if len(secret) != 4 {
-		return fmt.Errorf("secret must be exactly 4 digits")
+	plays, err := h.playRepo.FindPlaysAwaitingTurn(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+		return
 	}
 
-	// Check for leading zero
-	if secret[0] == '0' {
-		return fmt.Errorf("secret cannot start with 0")
+	// Never expose the opponent's secret for unfinished plays
+	for i := range plays {
+		hideOpponentSecret(&plays[i], userUUID)
 	}
 
-	// Check all characters are digits
-	for _, char := range secret {
-		if char < '0' || char > '9' {
-			return fmt.Errorf("secret must contain only digits")
-		}
+	c.JSON(http.StatusOK, GetMyTurnsResponse{
+		Count: len(plays),
+		Plays: plays,
+	})
+}
+
+// GetPlayedGamesResponse represents the response for getting the games a user has played
+type GetPlayedGamesResponse struct {
+	Games []database.PlayedGame `json:"games"`
+}
+
+// GetPlayedGames handles listing the distinct games the current user has played across all partners
+func (h *GamesHandler) GetPlayedGames(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
 	}
 
-	// Check for unique digits
-	digits := make(map[rune]bool)
-	for _, char := range secret {
-		if digits[char] {
-			return fmt.Errorf("secret must have unique digits")
-		}
-		digits[char] = true
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
 	}
 
-	return nil
+	playedGames, err := h.playRepo.FindPlayedGamesByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch played games: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, GetPlayedGamesResponse{
+		Games: playedGames,
+	})
 }
 
-// SetSecret handles setting a player's secret number
-func (h *GamesHandler) SetSecret(c *gin.Context) {
+// GetPlayByIdResponse represents the response for getting a play by ID
+type GetPlayByIdResponse struct {
+	Play         *database.Play `json:"play"`
+	TotalGuesses int            `json:"total_guesses"`
+	OpponentBest int            `json:"opponent_best"` // Opponent's highest bulls count so far
+}
+
+// GetPlayById handles getting a play by ID
+func (h *GamesHandler) GetPlayById(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -643,14 +1345,8 @@ func (h *GamesHandler) SetSecret(c *gin.Context) {
 		return
 	}
 
-	var req SetSecretRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
-		return
-	}
-
-	// Validate secret
-	if err := validateSecret(req.Secret); err != nil {
+	guessesLimit, err := parseGuessesLimit(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -658,7 +1354,7 @@ func (h *GamesHandler) SetSecret(c *gin.Context) {
 	// Get play
 	play, err := h.playRepo.FindPlayByID(playID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Play not found"})
+		respondPlayLookupError(c, err, "Play not found")
 		return
 	}
 
@@ -668,116 +1364,690 @@ func (h *GamesHandler) SetSecret(c *gin.Context) {
 		return
 	}
 
-	// Get play data
-	playData := play.PlayData
-	if playData == nil {
-		playData = make(database.JSONB)
-	}
-
-	// Determine which partner the user is
-	var secretKey string
-	if play.Partner1ID == userUUID {
-		secretKey = "partner1_secret"
-	} else {
-		secretKey = "partner2_secret"
-	}
+	// For Bulls and Cows, hide opponent's secret until game is completed
+	hideOpponentSecret(play, userUUID)
 
-	// Check if secret already set
-	if existingSecret, exists := playData[secretKey]; exists && existingSecret != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You have already set your secret"})
+	opponentBest, err := h.opponentBestBulls(play, userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch guesses: " + err.Error()})
 		return
 	}
+	totalGuesses := limitGuesses(play, guessesLimit)
 
-	// Set the secret
-	playData[secretKey] = req.Secret
+	c.JSON(http.StatusOK, GetPlayByIdResponse{
+		Play:         play,
+		TotalGuesses: totalGuesses,
+		OpponentBest: opponentBest,
+	})
+}
 
-	// Initialize status if not set
-	if _, exists := playData["status"]; !exists {
-		playData["status"] = "waiting_secrets"
+// parseGuessesLimit reads the optional guesses_limit query parameter (0 means return all guesses)
+func parseGuessesLimit(c *gin.Context) (int, error) {
+	limitStr := c.Query("guesses_limit")
+	if limitStr == "" {
+		return 0, nil
 	}
-
-	// Check if both secrets are set
-	partner1Secret, hasPartner1 := playData["partner1_secret"]
-	partner2Secret, hasPartner2 := playData["partner2_secret"]
-
-	if hasPartner1 && partner1Secret != nil && hasPartner2 && partner2Secret != nil {
-		// Both secrets set, start the game
-		playData["status"] = "playing"
-		// Set initial turn to partner1
-		if _, exists := playData["current_turn"]; !exists {
-			playData["current_turn"] = play.Partner1ID.String()
-		}
-		// Initialize guesses array if not exists
-		if _, exists := playData["guesses"]; !exists {
-			playData["guesses"] = []interface{}{}
-		}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("guesses_limit must be a positive integer")
 	}
+	return limit, nil
+}
 
-	// Update play
-	play.PlayData = playData
-	if err := h.playRepo.UpdatePlay(play); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update play: " + err.Error()})
-		return
+// limitGuesses trims the play's guesses in the response to the most recent limit entries
+// The stored play is not modified; returns the total number of guesses, archived ones included
+func limitGuesses(play *database.Play, limit int) int {
+	archived := database.ArchivedGuessCount(play.PlayData)
+	guesses, ok := play.PlayData["guesses"].([]interface{})
+	if !ok {
+		return archived
+	}
+	total := len(guesses)
+	if limit > 0 && total > limit {
+		play.PlayData["guesses"] = guesses[total-limit:]
 	}
+	return archived + total
+}
 
-	// Reload play
-	play, err = h.playRepo.FindPlayByID(playID)
+// opponentBestBulls returns the highest bulls count the caller's opponent has achieved in a play
+// Archived guesses count too; only the count is derived from the guesses, no secret is involved
+func (h *GamesHandler) opponentBestBulls(play *database.Play, userID uuid.UUID) (int, error) {
+	fullPlay, err := h.withFullGuessHistory(play)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
-		return
+		return 0, err
 	}
-
-	c.JSON(http.StatusOK, SetSecretResponse{
-		Play: play,
-	})
+	return bestOpponentBulls(fullPlay, userID), nil
 }
 
-// calculateBullsAndCows calculates bulls and cows for a guess
-func calculateBullsAndCows(secret, guess string) (int, int) {
-	bulls := 0
-	cows := 0
-
-	secretDigits := []rune(secret)
-	guessDigits := []rune(guess)
+// bestOpponentBulls returns the highest bulls count among the guesses not made by userID
+func bestOpponentBulls(play *database.Play, userID uuid.UUID) int {
+	guesses, ok := play.PlayData["guesses"].([]interface{})
+	if !ok {
+		return 0
+	}
 
-	// Count bulls (correct digit in correct position)
-	for i := 0; i < 4; i++ {
-		if secretDigits[i] == guessDigits[i] {
-			bulls++
+	best := 0
+	for _, g := range guesses {
+		guess, ok := g.(map[string]interface{})
+		if !ok || guess["player_id"] == userID.String() {
+			continue
+		}
+		// Guesses loaded from the database hold JSON numbers as float64
+		if bulls, ok := guess["bulls"].(float64); ok && int(bulls) > best {
+			best = int(bulls)
 		}
 	}
+	return best
+}
 
-	// Count cows (correct digit in wrong position)
-	secretCount := make(map[rune]int)
-	guessCount := make(map[rune]int)
+// bullsAndCowsGameID is the ID of the seeded Bulls and Cows game
+const bullsAndCowsGameID = "550e8400-e29b-41d4-a716-446655440001"
 
-	for i := 0; i < 4; i++ {
-		if secretDigits[i] != guessDigits[i] {
-			secretCount[secretDigits[i]]++
-			guessCount[guessDigits[i]]++
-		}
-	}
+// Bulls and Cows modes, set via the game's details "mode"
+const (
+	bullsAndCowsModeDual   = "dual"   // Each player sets a secret and guesses the other's (default)
+	bullsAndCowsModeShared = "shared" // The server picks one secret and both players race to guess it
+)
 
-	// Count matching digits (excluding bulls)
-	for digit, count := range guessCount {
-		if secretCount[digit] > 0 {
-			cows += min(count, secretCount[digit])
-		}
+// isBullsAndCows reports whether a play is a Bulls and Cows game
+func isBullsAndCows(play *database.Play) bool {
+	if play.GameID.String() == bullsAndCowsGameID {
+		return true
 	}
+	gameType, _ := play.Game.Details["type"].(string)
+	return gameType == "bulls_and_cows"
+}
 
-	return bulls, cows
+// isSharedSecretMode reports whether a game uses the shared-secret Bulls and Cows variant
+func isSharedSecretMode(game database.Game) bool {
+	mode, _ := game.Details["mode"].(string)
+	return mode == bullsAndCowsModeShared
 }
 
-// MakeGuessRequest represents the request body for making a guess
-type MakeGuessRequest struct {
-	Guess string `json:"guess" binding:"required,len=4"`
+// usesSharedSecret reports whether every guess in a play targets a single shared secret
+// Imported practice plays are always played against the imported secret; bot plays never are
+func usesSharedSecret(play *database.Play) bool {
+	if isBotPlay(play) {
+		return false
+	}
+	return play.IsPractice || isSharedSecretMode(play.Game)
 }
 
-// MakeGuessResponse represents the response for making a guess
-type MakeGuessResponse struct {
-	Play  *database.Play `json:"play"`
-	Bulls int            `json:"bulls"`
-	Cows  int            `json:"cows"`
+// initialPlayData returns the starting play data for a new play of the game
+// firstPlayerID moves first in games that start with a turn
+func initialPlayData(game database.Game, firstPlayerID uuid.UUID) (database.JSONB, error) {
+	if isTicTacToe(&database.Play{GameID: game.ID, Game: game}) {
+		return initialTicTacToeData(firstPlayerID), nil
+	}
+	if !isSharedSecretMode(game) {
+		return database.JSONB{}, nil
+	}
+
+	secret, err := generateSecret(secretLength(game))
+	if err != nil {
+		return nil, err
+	}
+	return database.JSONB{
+		"status":        "playing",
+		"shared_secret": secret,
+		"guesses":       []interface{}{},
+	}, nil
+}
+
+// generateSecret generates a random valid secret of the given length (unique digits, no leading zero)
+func generateSecret(length int) (string, error) {
+	digits := []byte("0123456789")
+	secret := make([]byte, 0, length)
+	for len(secret) < length {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(digits))))
+		if err != nil {
+			return "", err
+		}
+		idx := int(n.Int64())
+		if len(secret) == 0 && digits[idx] == '0' {
+			continue
+		}
+		secret = append(secret, digits[idx])
+		digits = append(digits[:idx], digits[idx+1:]...)
+	}
+	return string(secret), nil
+}
+
+// hideOpponentSecret removes the opponent's Bulls and Cows secret from a play until it is completed
+// and sets the play's secrets status; every endpoint returning a play to a participant passes it through here
+func hideOpponentSecret(play *database.Play, userID uuid.UUID) {
+	if !isBullsAndCows(play) {
+		return
+	}
+
+	playData := play.PlayData
+	if playData == nil {
+		return
+	}
+
+	// Report which secrets are set before any are hidden (shared mode has no per-player secrets)
+	if !usesSharedSecret(play) && (play.Partner1ID == userID || play.Partner2ID == userID) {
+		ownKey, opponentKey := "partner1_secret", "partner2_secret"
+		if play.Partner1ID != userID {
+			ownKey, opponentKey = opponentKey, ownKey
+		}
+		ownSecret, _ := playData[ownKey].(string)
+		opponentSecret, _ := playData[opponentKey].(string)
+		play.SecretsStatus = &database.SecretsStatus{
+			YouSet:      ownSecret != "",
+			OpponentSet: opponentSecret != "",
+		}
+	}
+
+	// Hide opponent's secret if game is not completed
+	if status, exists := playData["status"]; exists && status != "completed" {
+		if play.Partner1ID == userID {
+			// Hide partner2's secret
+			playData["partner2_secret"] = nil
+		} else {
+			// Hide partner1's secret
+			playData["partner1_secret"] = nil
+		}
+		// Hide the server-generated secret in shared mode
+		if _, exists := playData["shared_secret"]; exists {
+			playData["shared_secret"] = nil
+		}
+		play.PlayData = playData
+	}
+}
+
+// PlayRoleResponse represents the caller's role in a play
+type PlayRoleResponse struct {
+	Role              string    `json:"role"` // partner1 or partner2
+	OpponentID        uuid.UUID `json:"opponent_id"`
+	SecretKey         string    `json:"secret_key"`          // PlayData key holding the caller's secret
+	OpponentSecretKey string    `json:"opponent_secret_key"` // PlayData key holding the opponent's secret
+}
+
+// playRole returns the caller's role in a play, or nil if they aren't part of it
+func playRole(play *database.Play, userID uuid.UUID) *PlayRoleResponse {
+	switch userID {
+	case play.Partner1ID:
+		return &PlayRoleResponse{
+			Role:              "partner1",
+			OpponentID:        play.Partner2ID,
+			SecretKey:         "partner1_secret",
+			OpponentSecretKey: "partner2_secret",
+		}
+	case play.Partner2ID:
+		return &PlayRoleResponse{
+			Role:              "partner2",
+			OpponentID:        play.Partner1ID,
+			SecretKey:         "partner2_secret",
+			OpponentSecretKey: "partner1_secret",
+		}
+	default:
+		return nil
+	}
+}
+
+// GetPlayRole handles getting the caller's role in a play
+func (h *GamesHandler) GetPlayRole(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	// Get play
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	role := playRole(play, userUUID)
+	if role == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// SetSecretRequest represents the request body for setting a secret
+type SetSecretRequest struct {
+	Secret string `json:"secret" binding:"required"` // Length is checked against the game's secret length
+}
+
+// SetSecretResponse represents the response for setting a secret
+type SetSecretResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// normalizeDigits strips whitespace (e.g. spaces injected by mobile keyboards) from a secret or guess
+// and rejects any remaining non-digit character
+func normalizeDigits(input, field string) (string, error) {
+	normalized := strings.Join(strings.Fields(input), "")
+	for _, char := range normalized {
+		if char < '0' || char > '9' {
+			return "", fmt.Errorf("%s contains invalid character %q, only digits are allowed", field, char)
+		}
+	}
+	return normalized, nil
+}
+
+// Bulls and Cows secret lengths, set via the game's details "secret_length"
+const (
+	defaultSecretLength = 4
+	minSecretLength     = 3
+	maxSecretLength     = 10 // Digits must be unique, so there are only 10 to choose from
+)
+
+// secretLength returns the number of digits in secrets and guesses of a Bulls and Cows game
+// Missing or out of range settings use the default
+func secretLength(game database.Game) int {
+	length := gameDetailInt(game, "secret_length")
+	if length < minSecretLength || length > maxSecretLength {
+		return defaultSecretLength
+	}
+	return length
+}
+
+// validateSecret validates a secret number of the given length
+func validateSecret(secret string, length int) error {
+	if len(secret) != length {
+		return fmt.Errorf("secret must be exactly %d digits", length)
+	}
+
+	// Check for leading zero
+	if secret[0] == '0' {
+		return fmt.Errorf("secret cannot start with 0")
+	}
+
+	// Check all characters are digits
+	for _, char := range secret {
+		if char < '0' || char > '9' {
+			return fmt.Errorf("secret must contain only digits")
+		}
+	}
+
+	// Check for unique digits
+	digits := make(map[rune]bool)
+	for _, char := range secret {
+		if digits[char] {
+			return fmt.Errorf("secret must have unique digits")
+		}
+		digits[char] = true
+	}
+
+	return nil
+}
+
+// ExampleSecretResponse represents the response for getting an example secret
+type ExampleSecretResponse struct {
+	Secret  string `json:"secret"`
+	Example bool   `json:"example"` // Always true: the secret isn't tied to any play
+}
+
+// GetExampleSecret handles generating a valid example secret for tutorials
+// Uses the same generator and validator as real plays so examples always follow the rules
+func (h *GamesHandler) GetExampleSecret(c *gin.Context) {
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
+	if !ok {
+		return
+	}
+
+	game, err := h.gameRepo.FindByID(gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	if !isBullsAndCows(&database.Play{GameID: game.ID, Game: *game}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This game doesn't use secrets"})
+		return
+	}
+
+	length := secretLength(*game)
+	secret, err := generateSecret(length)
+	if err == nil {
+		err = validateSecret(secret, length)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate example secret"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ExampleSecretResponse{
+		Secret:  secret,
+		Example: true,
+	})
+}
+
+// secretAttempts tracks a player's invalid secret attempts on one play
+type secretAttempts struct {
+	count       int
+	lockedUntil time.Time
+	lastAttempt time.Time
+}
+
+// secretAttemptWait returns how long the player must wait before setting a secret again (0 if not blocked)
+func (h *GamesHandler) secretAttemptWait(key string) time.Duration {
+	if h.secretAttemptLimit <= 0 {
+		return 0
+	}
+
+	h.secretAttemptsMu.Lock()
+	defer h.secretAttemptsMu.Unlock()
+	attempts, exists := h.secretAttempts[key]
+	if !exists {
+		return 0
+	}
+	return time.Until(attempts.lockedUntil)
+}
+
+// recordInvalidSecretAttempt counts an invalid secret and starts the cooldown once the limit is reached
+func (h *GamesHandler) recordInvalidSecretAttempt(key string) {
+	if h.secretAttemptLimit <= 0 {
+		return
+	}
+
+	h.secretAttemptsMu.Lock()
+	defer h.secretAttemptsMu.Unlock()
+	h.pruneSecretAttempts()
+	attempts, exists := h.secretAttempts[key]
+	if !exists {
+		attempts = &secretAttempts{}
+		h.secretAttempts[key] = attempts
+	}
+	attempts.lastAttempt = time.Now()
+	attempts.count++
+	if attempts.count >= h.secretAttemptLimit {
+		attempts.count = 0
+		attempts.lockedUntil = time.Now().Add(h.secretAttemptCooldown)
+	}
+}
+
+// pruneSecretAttempts forgets players who are past their cooldown and made no attempt for a cooldown period,
+// so abandoned plays don't keep entries forever
+// Must be called with secretAttemptsMu held
+func (h *GamesHandler) pruneSecretAttempts() {
+	now := time.Now()
+	for key, attempts := range h.secretAttempts {
+		if now.After(attempts.lockedUntil) && now.Sub(attempts.lastAttempt) > h.secretAttemptCooldown {
+			delete(h.secretAttempts, key)
+		}
+	}
+}
+
+// clearSecretAttempts forgets a player's invalid attempts once they submit a valid secret
+func (h *GamesHandler) clearSecretAttempts(key string) {
+	if h.secretAttemptLimit <= 0 {
+		return
+	}
+
+	h.secretAttemptsMu.Lock()
+	defer h.secretAttemptsMu.Unlock()
+	delete(h.secretAttempts, key)
+}
+
+// SetSecret handles setting a player's secret number
+func (h *GamesHandler) SetSecret(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	attemptKey := userUUID.String() + ":" + playID.String()
+	if wait := h.secretAttemptWait(attemptKey); wait > 0 {
+		respondTooManyRequests(c, "Too many invalid secrets. Please wait before trying again.", wait)
+		return
+	}
+
+	var req SetSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	// Another server may save the play between our load and save, in which case the checks are redone on the new state
+	var play *database.Play
+	if !retryStalePlay(c, func() error {
+		// Get play
+		play, err = h.playRepo.FindPlayByID(playID)
+		if err != nil {
+			respondPlayLookupError(c, err, "Play not found")
+			return errPlayResponded
+		}
+
+		// Verify user is part of this play
+		if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+			return errPlayResponded
+		}
+
+		if isTicTacToe(play) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "This game doesn't use secrets"})
+			return errPlayResponded
+		}
+
+		// Normalize and validate secret against the game's secret length
+		req.Secret, err = normalizeDigits(req.Secret, "secret")
+		if err == nil {
+			err = validateSecret(req.Secret, secretLength(play.Game))
+		}
+		if err != nil {
+			h.recordInvalidSecretAttempt(attemptKey)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return errPlayResponded
+		}
+		h.clearSecretAttempts(attemptKey)
+
+		// No moves can be made while the play is paused
+		if isPlayPaused(play.PlayData) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Play is paused"})
+			return errPlayResponded
+		}
+
+		// The server picks the secret in shared mode
+		if usesSharedSecret(play) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Secrets cannot be set in shared mode"})
+			return errPlayResponded
+		}
+
+		// Get play data
+		playData := play.PlayData
+		if playData == nil {
+			playData = make(database.JSONB)
+		}
+
+		// Determine which partner the user is
+		var secretKey string
+		if play.Partner1ID == userUUID {
+			secretKey = "partner1_secret"
+		} else {
+			secretKey = "partner2_secret"
+		}
+
+		// Treat a retry with the same secret as success so clients can safely
+		// resubmit after a network error where the first call went through
+		if existingSecret, ok := playData[secretKey].(string); ok && existingSecret == req.Secret {
+			hideOpponentSecret(play, userUUID)
+			c.JSON(http.StatusOK, SetSecretResponse{
+				Play: play,
+			})
+			return errPlayResponded
+		}
+
+		// Secrets can only be set before the game starts, even if a player's secret is somehow missing later
+		if status, _ := playData["status"].(string); (status != "" && status != "waiting_secrets") || !play.IsLive {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Secrets can only be set before the game starts"})
+			return errPlayResponded
+		}
+
+		// Check if secret already set
+		if existingSecret, exists := playData[secretKey]; exists && existingSecret != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You have already set your secret"})
+			return errPlayResponded
+		}
+
+		// Set the secret
+		playData[secretKey] = req.Secret
+
+		// Initialize status if not set
+		if _, exists := playData["status"]; !exists {
+			playData["status"] = "waiting_secrets"
+		}
+
+		// Check if both secrets are set
+		partner1Secret, hasPartner1 := playData["partner1_secret"]
+		partner2Secret, hasPartner2 := playData["partner2_secret"]
+
+		if hasPartner1 && partner1Secret != nil && hasPartner2 && partner2Secret != nil {
+			// Both secrets set, start the game
+			playData["status"] = "playing"
+			// Set initial turn to partner1
+			if _, exists := playData["current_turn"]; !exists {
+				playData["current_turn"] = play.Partner1ID.String()
+			}
+			// Initialize guesses array if not exists
+			if _, exists := playData["guesses"]; !exists {
+				playData["guesses"] = []interface{}{}
+			}
+		}
+
+		// Update play
+		play.PlayData = playData
+		return h.playRepo.UpdatePlay(play)
+	}) {
+		return
+	}
+	h.playHub.publish(playID)
+
+	// Reload play
+	play, err = h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusOK, SetSecretResponse{
+		Play: play,
+	})
+}
+
+// calculateBullsAndCows calculates bulls and cows for a guess
+func calculateBullsAndCows(secret, guess string) (int, int) {
+	bulls := 0
+	cows := 0
+
+	secretDigits := []rune(secret)
+	guessDigits := []rune(guess)
+
+	// Secrets and guesses are validated to the same length; anything longer is ignored
+	length := min(len(secretDigits), len(guessDigits))
+
+	// Count bulls (correct digit in correct position)
+	for i := 0; i < length; i++ {
+		if secretDigits[i] == guessDigits[i] {
+			bulls++
+		}
+	}
+
+	// Count cows (correct digit in wrong position)
+	secretCount := make(map[rune]int)
+	guessCount := make(map[rune]int)
+
+	for i := 0; i < length; i++ {
+		if secretDigits[i] != guessDigits[i] {
+			secretCount[secretDigits[i]]++
+			guessCount[guessDigits[i]]++
+		}
+	}
+
+	// Count matching digits (excluding bulls)
+	for digit, count := range guessCount {
+		if secretCount[digit] > 0 {
+			cows += min(count, secretCount[digit])
+		}
+	}
+
+	return bulls, cows
+}
+
+// gameDetailInt reads an integer setting from a game's details, returning 0 if absent or invalid
+func gameDetailInt(game database.Game, key string) int {
+	value, ok := game.Details[key].(float64)
+	if !ok || value < 0 {
+		return 0
+	}
+	return int(value)
+}
+
+// lastGuessTime returns the timestamp of the most recent guess made by the player
+func lastGuessTime(playData database.JSONB, playerID uuid.UUID) (time.Time, bool) {
+	guesses, ok := playData["guesses"].([]interface{})
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for i := len(guesses) - 1; i >= 0; i-- {
+		guess, ok := guesses[i].(map[string]interface{})
+		if !ok || guess["player_id"] != playerID.String() {
+			continue
+		}
+		timestampStr, ok := guess["timestamp"].(string)
+		if !ok {
+			return time.Time{}, false
+		}
+		timestamp, err := time.Parse(time.RFC3339, timestampStr)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return timestamp, true
+	}
+	return time.Time{}, false
+}
+
+// MakeGuessRequest represents the request body for making a guess
+type MakeGuessRequest struct {
+	Guess string `json:"guess" binding:"required"` // Length is checked against the game's secret length
+}
+
+// MakeGuessResponse represents the response for making a guess
+type MakeGuessResponse struct {
+	Play  *database.Play `json:"play"`
+	Bulls int            `json:"bulls"`
+	Cows  int            `json:"cows"`
 }
 
 // MakeGuess handles making a guess in Bulls and Cows
@@ -807,16 +2077,269 @@ func (h *GamesHandler) MakeGuess(c *gin.Context) {
 		return
 	}
 
-	// Validate guess
-	if err := validateSecret(req.Guess); err != nil {
+	// Normalize guess
+	req.Guess, err = normalizeDigits(req.Guess, "guess")
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	// Another server may save the play between our load and save, in which case the guess is scored against the new state
+	var play *database.Play
+	var bulls, cows int
+	if !retryStalePlay(c, func() error {
+		// Get play
+		play, err = h.playRepo.FindPlayByID(playID)
+		if err != nil {
+			respondPlayLookupError(c, err, "Play not found")
+			return errPlayResponded
+		}
+
+		// Verify user is part of this play
+		if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+			return errPlayResponded
+		}
+
+		if isTicTacToe(play) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "This game doesn't use guesses"})
+			return errPlayResponded
+		}
+
+		// Validate guess against the game's secret length
+		length := secretLength(play.Game)
+		if err := validateSecret(req.Guess, length); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return errPlayResponded
+		}
+
+		// No moves can be made while the play is paused
+		if isPlayPaused(play.PlayData) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Play is paused"})
+			return errPlayResponded
+		}
+
+		// Get play data
+		playData := play.PlayData
+		if playData == nil {
+			respondPlayLookupError(c, database.ErrCorruptedJSONB, "Play not found")
+			return errPlayResponded
+		}
+
+		// Check game status
+		status, exists := playData["status"]
+		if !exists || status != "playing" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Game is not in playing state"})
+			return errPlayResponded
+		}
+
+		// In shared mode both players race against the same secret, so there are no turns
+		sharedMode := usesSharedSecret(play)
+
+		// Check if it's user's turn
+		if !sharedMode {
+			currentTurn, exists := playData["current_turn"]
+			if !exists {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
+				return errPlayResponded
+			}
+
+			currentTurnStr, ok := currentTurn.(string)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
+				return errPlayResponded
+			}
+
+			if currentTurnStr != userUUID.String() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "It's not your turn"})
+				return errPlayResponded
+			}
+		}
+
+		// Enforce the game's minimum time between guesses, if configured
+		if minInterval := gameDetailInt(play.Game, "min_guess_interval_seconds"); minInterval > 0 {
+			if lastGuessAt, found := lastGuessTime(playData, userUUID); found {
+				wait := time.Duration(minInterval)*time.Second - time.Since(lastGuessAt)
+				if wait > 0 {
+					respondTooManyRequests(c, "You're guessing too fast. Please wait before guessing again.", wait)
+					return errPlayResponded
+				}
+			}
+		}
+
+		// Determine which partner the user is and get the secret being guessed
+		var isPartner1 bool
+		var opponentSecret string
+		if sharedMode {
+			isPartner1 = play.Partner1ID == userUUID
+			opponentSecret, ok = playData["shared_secret"].(string)
+			if !ok || opponentSecret == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
+				return errPlayResponded
+			}
+		} else if play.Partner1ID == userUUID {
+			isPartner1 = true
+			opponentSecretRaw, exists := playData["partner2_secret"]
+			if !exists {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
+				return errPlayResponded
+			}
+			opponentSecret, ok = opponentSecretRaw.(string)
+			if !ok || opponentSecret == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
+				return errPlayResponded
+			}
+		} else {
+			opponentSecretRaw, exists := playData["partner1_secret"]
+			if !exists {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
+				return errPlayResponded
+			}
+			opponentSecret, ok = opponentSecretRaw.(string)
+			if !ok || opponentSecret == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
+				return errPlayResponded
+			}
+		}
+
+		// Calculate bulls and cows
+		bulls, cows = calculateBullsAndCows(opponentSecret, req.Guess)
+
+		// Get guesses array
+		guesses, exists := playData["guesses"]
+		if !exists {
+			guesses = []interface{}{}
+		}
+		guessesArray, ok := guesses.([]interface{})
+		if !ok {
+			guessesArray = []interface{}{}
+		}
+
+		// Add new guess
+		newGuess := map[string]interface{}{
+			"player_id": userUUID.String(),
+			"guess":     req.Guess,
+			"bulls":     bulls,
+			"cows":      cows,
+			"timestamp": time.Now().Format(time.RFC3339Nano), // Sub-second precision for timing analysis
+		}
+		guessesArray = append(guessesArray, newGuess)
+		playData["guesses"] = guessesArray
+
+		// Check if game is won (4 bulls)
+		if bulls == length {
+			playData["status"] = "completed"
+			playData["winner_id"] = userUUID.String()
+			playData["result"] = database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonSolved, &userUUID)
+			play.IsLive = false
+			play.PlayData = playData
+
+			// Scoring and cheat checks need every guess, including any already archived
+			fullPlay, err := h.withFullGuessHistory(play)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load guess history: " + err.Error()})
+				return errPlayResponded
+			}
+			if score, ok := scorePlay(fullPlay, userUUID); ok {
+				playData["score"] = score
+				play.Score = &score
+			}
+			if h.cheatDetection && !play.IsPractice {
+				h.assessCheatSuspicion(c, fullPlay, userUUID)
+				play.Suspicion = fullPlay.Suspicion
+			}
+		} else if !sharedMode {
+			// Switch turn
+			if isPartner1 {
+				playData["current_turn"] = play.Partner2ID.String()
+			} else {
+				playData["current_turn"] = play.Partner1ID.String()
+			}
+		}
+
+		// The bot answers straight away, so the player never waits on it
+		if play.IsLive && isBotPlay(play) {
+			play.PlayData = playData
+			if err := h.playBotTurn(play); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Bot failed to move: " + err.Error()})
+				return errPlayResponded
+			}
+		}
+
+		// Update play, archiving the oldest guesses once the row holds more than the threshold
+		play.PlayData = playData
+		if h.guessArchiveThreshold > 0 {
+			return h.playRepo.UpdatePlayArchivingGuesses(play, h.guessArchiveThreshold)
+		}
+		return h.playRepo.UpdatePlay(play)
+	}) {
+		return
+	}
+	h.playHub.publish(playID)
+
+	// Reload play
+	play, err = h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusOK, MakeGuessResponse{
+		Play:  play,
+		Bulls: bulls,
+		Cows:  cows,
+	})
+}
+
+// summaryEmailCooldown is the minimum time between summary emails for the same user and play
+const summaryEmailCooldown = 10 * time.Minute
+
+// pruneSummaryEmails forgets summary emails sent longer than the cooldown ago, which no longer limit anything
+// Must be called with summaryEmailsMu held
+func (h *GamesHandler) pruneSummaryEmails() {
+	for key, lastSent := range h.summaryEmails {
+		if time.Since(lastSent) >= summaryEmailCooldown {
+			delete(h.summaryEmails, key)
+		}
+	}
+}
+
+// EmailPlaySummaryResponse represents the response for emailing a play summary
+type EmailPlaySummaryResponse struct {
+	Message string `json:"message"`
+}
+
+// EmailPlaySummary handles sending the caller an email summarizing a finished play
+func (h *GamesHandler) EmailPlaySummary(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
 	// Get play
 	play, err := h.playRepo.FindPlayByID(playID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Play not found"})
+		respondPlayLookupError(c, err, "Play not found")
 		return
 	}
 
@@ -826,121 +2349,354 @@ func (h *GamesHandler) MakeGuess(c *gin.Context) {
 		return
 	}
 
-	// Get play data
-	playData := play.PlayData
-	if playData == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play data"})
+	// Only finished plays have a result to summarize
+	if status, _ := play.PlayData["status"].(string); status != "completed" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is not finished yet"})
 		return
 	}
 
-	// Check game status
-	status, exists := playData["status"]
-	if !exists || status != "playing" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is not in playing state"})
+	// Respect the caller's opt-out even though they asked for the summary themselves
+	if emailOptedOut(c, h.prefsRepo, userUUID, database.EmailCategorySummaries) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Summary emails are turned off in your preferences"})
 		return
 	}
 
-	// Check if it's user's turn
-	currentTurn, exists := playData["current_turn"]
-	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
+	// Rate limit per user and play
+	limitKey := userUUID.String() + ":" + playID.String()
+	h.summaryEmailsMu.Lock()
+	h.pruneSummaryEmails()
+	if lastSent, exists := h.summaryEmails[limitKey]; exists && time.Since(lastSent) < summaryEmailCooldown {
+		h.summaryEmailsMu.Unlock()
+		respondTooManyRequests(c, "A summary was sent recently. Please try again later.", summaryEmailCooldown-time.Since(lastSent))
+		return
+	}
+	h.summaryEmails[limitKey] = time.Now()
+	h.summaryEmailsMu.Unlock()
+
+	player, opponent := play.Partner1, play.Partner2
+	if play.Partner2ID == userUUID {
+		player, opponent = play.Partner2, play.Partner1
+	}
+
+	summary := email.GameSummary{
+		GameName:     play.Game.Name,
+		PlayerName:   userDisplayName(player),
+		OpponentName: userDisplayName(opponent),
+	}
+	summary.GuessCount = database.ArchivedGuessCount(play.PlayData)
+	if guesses, ok := play.PlayData["guesses"].([]interface{}); ok {
+		summary.GuessCount += len(guesses)
+	}
+	if winnerID, ok := play.PlayData["winner_id"].(string); ok {
+		summary.Won = winnerID == userUUID.String()
+		if summary.Won {
+			summary.WinnerName = summary.PlayerName
+		} else {
+			summary.WinnerName = summary.OpponentName
+		}
+	}
+
+	if err := h.emailClient.SendTemplated(player.Email, email.TemplateGameSummary, summary.TemplateData()); err != nil {
+		// Allow an immediate retry since nothing was delivered
+		h.summaryEmailsMu.Lock()
+		delete(h.summaryEmails, limitKey)
+		h.summaryEmailsMu.Unlock()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send summary email"})
 		return
 	}
 
-	currentTurnStr, ok := currentTurn.(string)
+	c.JSON(http.StatusOK, EmailPlaySummaryResponse{
+		Message: "Game summary has been sent to your email",
+	})
+}
+
+// userDisplayName returns the name to show for a user, preferring their display name
+func userDisplayName(user database.User) string {
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Name
+}
+
+// globalStatsTTL is how long computed global stats are served from cache
+const globalStatsTTL = 5 * time.Minute
+
+// cachedGlobalStats holds computed global stats and when they were computed
+type cachedGlobalStats struct {
+	stats      GameGlobalStatsResponse
+	computedAt time.Time
+}
+
+// GameGlobalStatsResponse represents aggregate outcomes of a game across all users
+type GameGlobalStatsResponse struct {
+	GameID              uuid.UUID `json:"game_id"`
+	CompletedPlays      int       `json:"completed_plays"`
+	AverageGuessesToWin *float64  `json:"average_guesses_to_win"` // Null if no play has a winner yet
+	FewestGuessesToWin  *int      `json:"fewest_guesses_to_win"`
+	FastestWinSeconds   *int64    `json:"fastest_win_seconds"` // From play start to the winning guess
+}
+
+// GetGameGlobalStats handles getting aggregate outcomes of a game across all completed plays
+func (h *GamesHandler) GetGameGlobalStats(c *gin.Context) {
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
 		return
 	}
 
-	if currentTurnStr != userUUID.String() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "It's not your turn"})
+	h.globalStatsMu.Lock()
+	cached, exists := h.globalStats[gameID]
+	h.globalStatsMu.Unlock()
+	if exists && time.Since(cached.computedAt) < globalStatsTTL {
+		c.JSON(http.StatusOK, cached.stats)
 		return
 	}
 
-	// Determine which partner the user is and get opponent's secret
-	var isPartner1 bool
-	var opponentSecret string
-	if play.Partner1ID == userUUID {
-		isPartner1 = true
-		opponentSecretRaw, exists := playData["partner2_secret"]
-		if !exists {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
-			return
-		}
-		opponentSecret, ok = opponentSecretRaw.(string)
-		if !ok || opponentSecret == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
-			return
-		}
-	} else {
-		opponentSecretRaw, exists := playData["partner1_secret"]
-		if !exists {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
-			return
-		}
-		opponentSecret, ok = opponentSecretRaw.(string)
-		if !ok || opponentSecret == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Opponent has not set their secret yet"})
-			return
+	if _, err := h.gameRepo.FindByID(gameID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	outcomes, err := h.playRepo.FindGameOutcomeStats(gameID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute stats: " + err.Error()})
+		return
+	}
+	stats := GameGlobalStatsResponse{
+		GameID:              gameID,
+		CompletedPlays:      outcomes.CompletedPlays,
+		AverageGuessesToWin: outcomes.AverageGuessesToWin,
+		FewestGuessesToWin:  outcomes.FewestGuessesToWin,
+		FastestWinSeconds:   outcomes.FastestWinSeconds,
+	}
+
+	h.globalStatsMu.Lock()
+	h.globalStats[gameID] = cachedGlobalStats{
+		stats:      stats,
+		computedAt: time.Now(),
+	}
+	h.globalStatsMu.Unlock()
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// respondPlayLookupError writes the error response for a failed play lookup
+// Corrupted play data is a server-side problem, so it is logged and reported as a 500 rather than a 404
+func respondPlayLookupError(c *gin.Context, err error, notFoundMessage string) {
+	if errors.Is(err, database.ErrCorruptedJSONB) {
+		logging.FromContext(c).Error("corrupted play data", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Corrupted play data"})
+		return
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": notFoundMessage})
+}
+
+// respondPlayUpdateError writes the error response for a failed play save
+// A play changed by a concurrent request is a conflict the client resolves by reloading
+func respondPlayUpdateError(c *gin.Context, err error, failureMessage string) {
+	if errors.Is(err, database.ErrStalePlay) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The play changed before your update was saved. Please reload and try again."})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": failureMessage + ": " + err.Error()})
+}
+
+// playUpdateAttempts is how many times retryStalePlay runs a play mutation before reporting a conflict
+const playUpdateAttempts = 3
+
+// errPlayResponded is returned by play mutations that already wrote the response
+var errPlayResponded = errors.New("play response already written")
+
+// retryStalePlay runs a mutation that loads, checks and saves a play, running it again against a freshly
+// loaded play if the play was changed concurrently (the play lock only covers this process)
+// Writes the error response unless the mutation already did; returns whether the mutation succeeded
+func retryStalePlay(c *gin.Context, mutate func() error) bool {
+	var err error
+	for attempt := 0; attempt < playUpdateAttempts; attempt++ {
+		if err = mutate(); !errors.Is(err, database.ErrStalePlay) {
+			break
 		}
 	}
+	if err != nil && !errors.Is(err, errPlayResponded) {
+		respondPlayUpdateError(c, err, "Failed to update play")
+	}
+	return err == nil
+}
+
+// pauseKeys are the PlayData keys recording a play's pause state
+var pauseKeys = []string{"paused", "paused_at", "paused_by", "paused_seconds"}
+
+// isPlayPaused reports whether a play is currently paused
+func isPlayPaused(playData database.JSONB) bool {
+	paused, _ := playData["paused"].(bool)
+	return paused
+}
+
+// pausedSeconds returns the total time a play has spent paused, excluding a pause still in progress
+func pausedSeconds(playData database.JSONB) float64 {
+	seconds, _ := playData["paused_seconds"].(float64)
+	return seconds
+}
+
+// PausePlayResponse represents the response for pausing or resuming a play
+type PausePlayResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// PausePlay handles pausing a live play, freezing its timers until it is resumed
+func (h *GamesHandler) PausePlay(c *gin.Context) {
+	h.setPlayPaused(c, true)
+}
 
-	// Calculate bulls and cows
-	bulls, cows := calculateBullsAndCows(opponentSecret, req.Guess)
+// ResumePlay handles resuming a paused play
+func (h *GamesHandler) ResumePlay(c *gin.Context) {
+	h.setPlayPaused(c, false)
+}
 
-	// Get guesses array
-	guesses, exists := playData["guesses"]
+// setPlayPaused pauses or resumes a play on behalf of one of its participants
+func (h *GamesHandler) setPlayPaused(c *gin.Context, pause bool) {
+	userID, exists := c.Get("user_id")
 	if !exists {
-		guesses = []interface{}{}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
 	}
-	guessesArray, ok := guesses.([]interface{})
+
+	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
-		guessesArray = []interface{}{}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Either participant can pause and resume
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
 	}
 
-	// Add new guess
-	newGuess := map[string]interface{}{
-		"player_id": userUUID.String(),
-		"guess":     req.Guess,
-		"bulls":     bulls,
-		"cows":      cows,
-		"timestamp": time.Now().Format(time.RFC3339),
+	if !play.IsLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Play is not live"})
+		return
+	}
+	if status, _ := play.PlayData["status"].(string); status == "completed" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is already completed"})
+		return
 	}
-	guessesArray = append(guessesArray, newGuess)
-	playData["guesses"] = guessesArray
 
-	// Check if game is won (4 bulls)
-	if bulls == 4 {
-		playData["status"] = "completed"
-		playData["winner_id"] = userUUID.String()
-		play.IsLive = false
+	playData := play.PlayData
+	if pause {
+		if isPlayPaused(playData) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Play is already paused"})
+			return
+		}
+		playData["paused"] = true
+		playData["paused_at"] = time.Now().Format(time.RFC3339)
+		playData["paused_by"] = userUUID.String()
 	} else {
-		// Switch turn
-		if isPartner1 {
-			playData["current_turn"] = play.Partner2ID.String()
-		} else {
-			playData["current_turn"] = play.Partner1ID.String()
+		if !isPlayPaused(playData) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Play is not paused"})
+			return
+		}
+
+		// Accumulate how long this pause lasted so timers can exclude it
+		total := pausedSeconds(playData)
+		if pausedAtStr, ok := playData["paused_at"].(string); ok {
+			if pausedAt, err := time.Parse(time.RFC3339, pausedAtStr); err == nil {
+				total += math.Floor(time.Since(pausedAt).Seconds())
+			}
 		}
+		playData["paused_seconds"] = total
+		playData["paused"] = false
+		delete(playData, "paused_at")
+		delete(playData, "paused_by")
 	}
 
-	// Update play
 	play.PlayData = playData
 	if err := h.playRepo.UpdatePlay(play); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update play: " + err.Error()})
+		respondPlayUpdateError(c, err, "Failed to update play")
 		return
 	}
+	h.playHub.publish(play.ID)
 
-	// Reload play
-	play, err = h.playRepo.FindPlayByID(playID)
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusOK, PausePlayResponse{
+		Play: play,
+	})
+}
+
+// LeaderboardPlayer is the public view of a player on a leaderboard
+// Only the one name shown for the player (see userDisplayName) is included
+type LeaderboardPlayer struct {
+	ID          uuid.UUID `json:"id"`
+	DisplayName string    `json:"display_name"`
+}
+
+// LeaderboardEntry is a single scored play on a game's leaderboard
+type LeaderboardEntry struct {
+	PlayID      uuid.UUID         `json:"play_id"`
+	Player      LeaderboardPlayer `json:"player"`
+	Score       int               `json:"score"`
+	CompletedAt time.Time         `json:"completed_at"`
+}
+
+// GetLeaderboardResponse represents the response for getting a game's score leaderboard
+type GetLeaderboardResponse struct {
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// GetLeaderboard handles listing a game's highest scoring completed plays
+func (h *GamesHandler) GetLeaderboard(c *gin.Context) {
+	gameID, ok := h.resolveGameRef(c, c.Param("gameId"))
+	if !ok {
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, MakeGuessResponse{
-		Play:  play,
-		Bulls: bulls,
-		Cows:  cows,
+	plays, err := h.playRepo.FindTopScoresByGame(gameID, page.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leaderboard: " + err.Error()})
+		return
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(plays))
+	for _, play := range plays {
+		winner := play.Partner1
+		if winnerID, _ := play.PlayData["winner_id"].(string); winnerID == play.Partner2ID.String() {
+			winner = play.Partner2
+		}
+		entries = append(entries, LeaderboardEntry{
+			PlayID: play.ID,
+			Player: LeaderboardPlayer{
+				ID:          winner.ID,
+				DisplayName: userDisplayName(winner),
+			},
+			Score:       *play.Score,
+			CompletedAt: play.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, GetLeaderboardResponse{
+		Entries: entries,
 	})
 }
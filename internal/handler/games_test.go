@@ -0,0 +1,1299 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestRetryStalePlay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	errFailed := errors.New("database unavailable")
+
+	tests := []struct {
+		name         string
+		results      []error // Returned by successive attempts; the last repeats
+		wantOK       bool
+		wantAttempts int
+		wantStatus   int // 0 when no response should be written
+	}{
+		{name: "first attempt succeeds", results: []error{nil}, wantOK: true, wantAttempts: 1},
+		{name: "stale then fresh succeeds", results: []error{database.ErrStalePlay, nil}, wantOK: true, wantAttempts: 2},
+		{name: "always stale reports a conflict", results: []error{database.ErrStalePlay}, wantAttempts: playUpdateAttempts, wantStatus: http.StatusConflict},
+		{name: "other error is not retried", results: []error{errFailed}, wantAttempts: 1, wantStatus: http.StatusInternalServerError},
+		{name: "already responded is not retried or overwritten", results: []error{errPlayResponded}, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+
+			attempts := 0
+			ok := retryStalePlay(c, func() error {
+				result := tt.results[min(attempts, len(tt.results)-1)]
+				attempts++
+				return result
+			})
+
+			if ok != tt.wantOK {
+				t.Errorf("retryStalePlay() = %v, want %v", ok, tt.wantOK)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("mutation ran %d times, want %d", attempts, tt.wantAttempts)
+			}
+			if c.Writer.Written() != (tt.wantStatus != 0) {
+				t.Fatalf("response written = %v, want %v", c.Writer.Written(), tt.wantStatus != 0)
+			}
+			if tt.wantStatus != 0 && recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+func TestNormalizeDigits(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "digits unchanged", input: "1234", want: "1234"},
+		{name: "surrounding whitespace stripped", input: "  1234\n", want: "1234"},
+		{name: "inner spaces stripped", input: "12 34", want: "1234"},
+		{name: "tabs and non-breaking spaces stripped", input: "1\t2\u00a034", want: "1234"},
+		{name: "empty input", input: "", want: ""},
+		{name: "letter rejected", input: "12a4", wantErr: true},
+		{name: "sign rejected", input: "-123", wantErr: true},
+		{name: "non-ASCII digit rejected", input: "12\u06634", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeDigits(tt.input, "guess")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeDigits(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeDigits(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHideOpponentSecret(t *testing.T) {
+	partner1ID, partner2ID := uuid.New(), uuid.New()
+	sharedGame := database.Game{
+		ID:      uuid.New(),
+		Details: database.JSONB{"type": "bulls_and_cows", "mode": bullsAndCowsModeShared},
+	}
+	classicGame := database.Game{
+		ID:      uuid.New(),
+		Details: database.JSONB{"type": "bulls_and_cows"},
+	}
+
+	tests := []struct {
+		name              string
+		game              database.Game
+		viewerID          uuid.UUID
+		playData          database.JSONB
+		wantVisible       []string // PlayData keys that must keep their value
+		wantHidden        []string // PlayData keys that must be cleared
+		wantSecretsStatus *database.SecretsStatus
+	}{
+		{
+			name:        "shared secret hidden while playing",
+			game:        sharedGame,
+			viewerID:    partner1ID,
+			playData:    database.JSONB{"status": "playing", "shared_secret": "1234"},
+			wantHidden:  []string{"shared_secret"},
+			wantVisible: []string{"status"},
+		},
+		{
+			name:        "shared secret hidden from partner2 too",
+			game:        sharedGame,
+			viewerID:    partner2ID,
+			playData:    database.JSONB{"status": "playing", "shared_secret": "1234"},
+			wantHidden:  []string{"shared_secret"},
+			wantVisible: []string{"status"},
+		},
+		{
+			name:        "shared secret revealed once completed",
+			game:        sharedGame,
+			viewerID:    partner1ID,
+			playData:    database.JSONB{"status": "completed", "shared_secret": "1234"},
+			wantVisible: []string{"shared_secret"},
+		},
+		{
+			name:              "opponent's secret hidden while playing",
+			game:              classicGame,
+			viewerID:          partner1ID,
+			playData:          database.JSONB{"status": "playing", "partner1_secret": "1234", "partner2_secret": "5678"},
+			wantVisible:       []string{"partner1_secret"},
+			wantHidden:        []string{"partner2_secret"},
+			wantSecretsStatus: &database.SecretsStatus{YouSet: true, OpponentSet: true},
+		},
+		{
+			name:              "secrets status reports a missing opponent secret",
+			game:              classicGame,
+			viewerID:          partner2ID,
+			playData:          database.JSONB{"status": "waiting_for_secrets", "partner2_secret": "5678"},
+			wantVisible:       []string{"partner2_secret"},
+			wantHidden:        []string{"partner1_secret"},
+			wantSecretsStatus: &database.SecretsStatus{YouSet: true, OpponentSet: false},
+		},
+		{
+			name:              "both secrets revealed once completed",
+			game:              classicGame,
+			viewerID:          partner1ID,
+			playData:          database.JSONB{"status": "completed", "partner1_secret": "1234", "partner2_secret": "5678"},
+			wantVisible:       []string{"partner1_secret", "partner2_secret"},
+			wantSecretsStatus: &database.SecretsStatus{YouSet: true, OpponentSet: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := database.JSONB{}
+			for key, value := range tt.playData {
+				original[key] = value
+			}
+			play := &database.Play{
+				GameID:     tt.game.ID,
+				Game:       tt.game,
+				Partner1ID: partner1ID,
+				Partner2ID: partner2ID,
+				PlayData:   tt.playData,
+			}
+
+			hideOpponentSecret(play, tt.viewerID)
+
+			for _, key := range tt.wantVisible {
+				if play.PlayData[key] != original[key] {
+					t.Errorf("PlayData[%q] = %v, want %v", key, play.PlayData[key], original[key])
+				}
+			}
+			for _, key := range tt.wantHidden {
+				if play.PlayData[key] != nil {
+					t.Errorf("PlayData[%q] = %v, want it hidden", key, play.PlayData[key])
+				}
+			}
+			switch {
+			case tt.wantSecretsStatus == nil && play.SecretsStatus != nil:
+				t.Errorf("SecretsStatus = %+v, want nil", *play.SecretsStatus)
+			case tt.wantSecretsStatus != nil && (play.SecretsStatus == nil || *play.SecretsStatus != *tt.wantSecretsStatus):
+				t.Errorf("SecretsStatus = %+v, want %+v", play.SecretsStatus, *tt.wantSecretsStatus)
+			}
+		})
+	}
+}
+
+func TestUpdatePlay(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "drawing"})
+	bullsAndCows := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	ticTacToe := createTestGame(t, db, database.JSONB{"type": "tic_tac_toe"})
+
+	tests := []struct {
+		name       string
+		game       *database.Game
+		isLive     bool
+		stored     database.JSONB
+		update     database.JSONB
+		wantStatus int
+		want       database.JSONB // Stored values expected afterwards
+	}{
+		{
+			name:       "client keys are replaced",
+			game:       game,
+			isLive:     true,
+			stored:     database.JSONB{"status": "playing", "canvas": "old"},
+			update:     database.JSONB{"canvas": "new"},
+			wantStatus: http.StatusOK,
+			want:       database.JSONB{"status": "playing", "canvas": "new"},
+		},
+		{
+			name:   "server-owned keys keep their stored values",
+			game:   game,
+			isLive: true,
+			stored: database.JSONB{"status": "playing", "current_turn": "partner1", "canvas": "old"},
+			update: database.JSONB{
+				"status": "completed", "winner_id": uuid.NewString(), "result": map[string]interface{}{"outcome": "win"},
+				"current_turn": "partner2", "score": 100, "canvas": "new",
+			},
+			wantStatus: http.StatusOK,
+			want:       database.JSONB{"status": "playing", "current_turn": "partner1", "winner_id": nil, "result": nil, "score": nil, "canvas": "new"},
+		},
+		{
+			name:       "ended play is refused",
+			game:       game,
+			isLive:     false,
+			stored:     database.JSONB{"status": "completed", "canvas": "old"},
+			update:     database.JSONB{"canvas": "new"},
+			wantStatus: http.StatusConflict,
+			want:       database.JSONB{"status": "completed", "canvas": "old"},
+		},
+		{
+			name:       "Bulls and Cows play is refused",
+			game:       bullsAndCows,
+			isLive:     true,
+			stored:     database.JSONB{"status": "playing", "guesses": []interface{}{}},
+			update:     database.JSONB{"status": "playing", "guesses": []interface{}{}, "note": "x"},
+			wantStatus: http.StatusBadRequest,
+			want:       database.JSONB{"status": "playing", "note": nil},
+		},
+		{
+			name:       "Tic-Tac-Toe play is refused",
+			game:       ticTacToe,
+			isLive:     true,
+			stored:     database.JSONB{"status": "playing", "board": []interface{}{"X", "", "", "", "", "", "", "", ""}},
+			update:     database.JSONB{"status": "playing", "board": []interface{}{"X", "X", "X", "", "", "", "", "", ""}},
+			wantStatus: http.StatusBadRequest,
+			want:       database.JSONB{"board": []interface{}{"X", "", "", "", "", "", "", "", ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partner1, partner2 := createTestUser(t, db), createTestUser(t, db)
+			play := createTestPlay(t, db, tt.game, partner1, partner2, tt.stored, tt.isLive)
+
+			c, recorder := newTestContext(http.MethodPut, "/api/v1/plays/"+play.ID.String(), UpdatePlayRequest{PlayData: mustMarshal(t, tt.update)}, partner1.ID)
+			c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+			h.UpdatePlay(c)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+
+			var stored database.Play
+			if err := db.First(&stored, "id = ?", play.ID).Error; err != nil {
+				t.Fatalf("failed to reload play: %v", err)
+			}
+			for key, want := range tt.want {
+				if got := stored.PlayData[key]; fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("PlayData[%q] = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePlayData(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr bool
+	}{
+		{name: "object", raw: ` {"canvas": "new"} `},
+		{name: "null", raw: `null`, wantErr: true},
+		{name: "empty", raw: ` `, wantErr: true},
+		{name: "array", raw: `[{"canvas": "new"}]`, wantErr: true},
+		{name: "string", raw: `"canvas"`, wantErr: true},
+		{name: "empty object", raw: `{}`, wantErr: true},
+		{name: "truncated object", raw: `{"canvas": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playData, err := parsePlayData(json.RawMessage(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePlayData(%s) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && playData["canvas"] != "new" {
+				t.Errorf("parsePlayData(%s) = %v, want the canvas", tt.raw, playData)
+			}
+		})
+	}
+}
+
+func TestLimitGuesses(t *testing.T) {
+	guesses := func(n int) []interface{} {
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = map[string]interface{}{"guess": fmt.Sprintf("%04d", i)}
+		}
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		playData  database.JSONB
+		limit     int
+		wantTotal int
+		wantKept  int
+	}{
+		{name: "no guesses", playData: database.JSONB{}, wantTotal: 0, wantKept: 0},
+		{name: "no limit keeps all", playData: database.JSONB{"guesses": guesses(5)}, wantTotal: 5, wantKept: 5},
+		{name: "limit trims to the most recent", playData: database.JSONB{"guesses": guesses(5)}, limit: 2, wantTotal: 5, wantKept: 2},
+		{name: "archived guesses count toward the total", playData: database.JSONB{"guesses": guesses(3), database.ArchivedGuessCountKey: float64(40)}, limit: 2, wantTotal: 43, wantKept: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			play := &database.Play{PlayData: tt.playData}
+			if total := limitGuesses(play, tt.limit); total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+			kept, _ := play.PlayData["guesses"].([]interface{})
+			if len(kept) != tt.wantKept {
+				t.Errorf("kept %d guesses, want %d", len(kept), tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestParseGuessesLimit(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{query: "", want: 0},
+		{query: "guesses_limit=10", want: 10},
+		{query: "guesses_limit=0", wantErr: true},
+		{query: "guesses_limit=-3", wantErr: true},
+		{query: "guesses_limit=ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		c, _ := newTestContext(http.MethodGet, "/api/v1/games/plays/live?"+tt.query, nil, uuid.Nil)
+		got, err := parseGuessesLimit(c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGuessesLimit(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseGuessesLimit(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestBestOpponentBulls(t *testing.T) {
+	me, opponent := uuid.New(), uuid.New()
+	play := &database.Play{PlayData: database.JSONB{"guesses": []interface{}{
+		map[string]interface{}{"player_id": opponent.String(), "bulls": float64(1)},
+		map[string]interface{}{"player_id": me.String(), "bulls": float64(3)},
+		map[string]interface{}{"player_id": opponent.String(), "bulls": float64(2)},
+		"malformed",
+	}}}
+
+	if best := bestOpponentBulls(play, me); best != 2 {
+		t.Errorf("bestOpponentBulls() = %d, want 2", best)
+	}
+	if best := bestOpponentBulls(play, opponent); best != 3 {
+		t.Errorf("bestOpponentBulls() for the opponent = %d, want 3", best)
+	}
+}
+
+func TestOpponentBestBullsIncludesArchivedGuesses(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	opponent := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, opponent, database.JSONB{
+		"status": "playing",
+		"guesses": []interface{}{
+			map[string]interface{}{"player_id": opponent.ID.String(), "bulls": float64(1)},
+		},
+		database.ArchivedGuessCountKey: float64(1),
+	}, true)
+
+	archived := &database.GuessArchive{PlayID: play.ID, Seq: 0, Guess: database.JSONB{"player_id": opponent.ID.String(), "bulls": float64(3)}}
+	if err := db.Create(archived).Error; err != nil {
+		t.Fatalf("failed to archive guess: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(archived) })
+
+	best, err := h.opponentBestBulls(play, user.ID)
+	if err != nil {
+		t.Fatalf("opponentBestBulls() error = %v", err)
+	}
+	if best != 3 {
+		t.Errorf("opponentBestBulls() = %d, want the archived guess's 3", best)
+	}
+}
+
+func TestPlayGameWithoutPartner(t *testing.T) {
+	db := openTestDB(t)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+
+	playGame := func(h *GamesHandler, req PlayGameRequest) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/games/play", req, user.ID)
+		h.PlayGame(c)
+		return recorder
+	}
+
+	t.Run("coded error by default", func(t *testing.T) {
+		h := NewGamesHandler(newTestConfig(), nil, nil)
+		recorder := playGame(h, PlayGameRequest{GameID: game.ID.String(), InviteEmail: "friend@example.com"})
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+		}
+		var response struct {
+			Code string `json:"code"`
+		}
+		decodeResponse(t, recorder, &response)
+		if response.Code != errorCodeNoPartner {
+			t.Errorf("code = %q, want %q", response.Code, errorCodeNoPartner)
+		}
+	})
+
+	t.Run("invite then request the game", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.InviteOnPlayWithoutPartner = true
+		authHandler, err := NewAuthHandler(cfg, nil)
+		if err != nil {
+			t.Fatalf("NewAuthHandler() error = %v", err)
+		}
+		partnerHandler := NewPartnerHandler(cfg, &recordingEmailClient{}, authHandler)
+		h := NewGamesHandler(cfg, nil, partnerHandler)
+		friend := createTestUser(t, db)
+
+		recorder := playGame(h, PlayGameRequest{GameID: game.ID.String(), InviteEmail: friend.Email})
+		if recorder.Code != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusAccepted, recorder.Body.String())
+		}
+		var response PlayGameResponse
+		decodeResponse(t, recorder, &response)
+		if response.PartnerRequest == nil || response.PartnerRequest.GameID == nil || *response.PartnerRequest.GameID != game.ID {
+			t.Fatalf("partner request = %+v, want one for game %s", response.PartnerRequest, game.ID)
+		}
+		t.Cleanup(func() {
+			users := []uuid.UUID{user.ID, friend.ID}
+			db.Where("requester_id IN ?", users).Delete(&database.GameRequest{})
+			db.Where("user1_id IN ? OR user2_id IN ?", users, users).Delete(&database.Partnership{})
+			db.Where("sender_id IN ?", users).Delete(&database.PartnerRequest{})
+		})
+
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/accept/"+response.PartnerRequest.ID.String(), nil, friend.ID)
+		c.Params = gin.Params{{Key: "id", Value: response.PartnerRequest.ID.String()}}
+		partnerHandler.AcceptPartnerRequest(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("accept status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+
+		var requests []database.GameRequest
+		if err := db.Where("requester_id = ? AND partner_id = ? AND status = ?", user.ID, friend.ID, "pending").Find(&requests).Error; err != nil {
+			t.Fatalf("failed to load game requests: %v", err)
+		}
+		if len(requests) != 1 || requests[0].GameID != game.ID {
+			t.Errorf("game requests = %+v, want one pending request for game %s", requests, game.ID)
+		}
+	})
+}
+
+func TestGetLeaderboardShowsOnlyDisplayNames(t *testing.T) {
+	db := openTestDB(t)
+	game := createTestGame(t, db, database.JSONB{})
+	winner := createTestUser(t, db)
+	loser := createTestUser(t, db)
+	if err := db.Model(winner).Update("display_name", "Champ").Error; err != nil {
+		t.Fatalf("failed to set display name: %v", err)
+	}
+
+	play := createTestPlay(t, db, game, winner, loser, database.JSONB{"status": "completed", "winner_id": winner.ID.String()}, false)
+	if err := db.Model(play).Update("score", 90).Error; err != nil {
+		t.Fatalf("failed to score play: %v", err)
+	}
+
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/games/"+game.ID.String()+"/leaderboard", nil, uuid.Nil)
+	c.Params = gin.Params{{Key: "gameId", Value: game.ID.String()}}
+	h.GetLeaderboard(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response struct {
+		Entries []struct {
+			Player map[string]interface{} `json:"player"`
+		} `json:"entries"`
+	}
+	decodeResponse(t, recorder, &response)
+	if len(response.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(response.Entries))
+	}
+	player := response.Entries[0].Player
+	if player["display_name"] != "Champ" {
+		t.Errorf("display_name = %v, want Champ", player["display_name"])
+	}
+	if _, ok := player["name"]; ok {
+		t.Errorf("player = %v, want no name field", player)
+	}
+}
+
+func TestGameActionsRequirePartnership(t *testing.T) {
+	db := openTestDB(t)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+
+	expectNotPartnered := func(t *testing.T, recorder *httptest.ResponseRecorder) {
+		t.Helper()
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusForbidden, recorder.Body.String())
+		}
+		var response struct {
+			Code string `json:"code"`
+		}
+		decodeResponse(t, recorder, &response)
+		if response.Code != errorCodeNotPartnered {
+			t.Errorf("code = %q, want %q", response.Code, errorCodeNotPartnered)
+		}
+	}
+
+	t.Run("game request to a non-partner", func(t *testing.T) {
+		req := CreateGameRequestRequest{GameID: game.ID.String(), PartnerID: stranger.ID.String()}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/game-requests", req, user.ID)
+		h.CreateGameRequest(c)
+		expectNotPartnered(t, recorder)
+	})
+
+	t.Run("play with a non-partner", func(t *testing.T) {
+		req := PlayGameRequest{GameID: game.ID.String(), PartnerID: stranger.ID.String()}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/games/play", req, user.ID)
+		h.PlayGame(c)
+		expectNotPartnered(t, recorder)
+	})
+
+	t.Run("invalid partner ID", func(t *testing.T) {
+		req := CreateGameRequestRequest{GameID: game.ID.String(), PartnerID: "not-a-uuid"}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/game-requests", req, user.ID)
+		h.CreateGameRequest(c)
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("game request to the partner", func(t *testing.T) {
+		partner := createTestUser(t, db)
+		createTestPartnership(t, db, user, partner)
+		t.Cleanup(func() { db.Where("requester_id = ?", user.ID).Delete(&database.GameRequest{}) })
+
+		req := CreateGameRequestRequest{GameID: game.ID.String(), PartnerID: partner.ID.String()}
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/game-requests", req, user.ID)
+		h.CreateGameRequest(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response CreateGameRequestResponse
+		decodeResponse(t, recorder, &response)
+		if response.Request == nil || response.Request.PartnerID != partner.ID {
+			t.Errorf("request = %+v, want one to %s", response.Request, partner.ID)
+		}
+	})
+
+	t.Run("rematch after unpartnering", func(t *testing.T) {
+		play := createTestPlay(t, db, game, user, stranger, database.JSONB{}, false)
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/rematch", nil, user.ID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.Rematch(c)
+		expectNotPartnered(t, recorder)
+	})
+}
+
+func TestSetSecretRetry(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "waiting_secrets"}, true)
+
+	setSecret := func(secret string) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/secret", SetSecretRequest{Secret: secret}, user.ID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.SetSecret(c)
+		return recorder
+	}
+
+	if recorder := setSecret("1234"); recorder.Code != http.StatusOK {
+		t.Fatalf("first SetSecret status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if recorder := setSecret("1234"); recorder.Code != http.StatusOK {
+		t.Errorf("retried SetSecret status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if recorder := setSecret("5678"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("SetSecret with a different secret status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+
+	var stored database.Play
+	if err := db.First(&stored, "id = ?", play.ID).Error; err != nil {
+		t.Fatalf("failed to reload play: %v", err)
+	}
+	if secret := stored.PlayData["partner1_secret"]; secret != "1234" {
+		t.Errorf("stored secret = %v, want 1234", secret)
+	}
+}
+
+func TestListGamesWithStatus(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	liveGame := createTestGame(t, db, database.JSONB{})
+	requestedGame := createTestGame(t, db, database.JSONB{})
+	idleGame := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	createTestPartnership(t, db, user, partner)
+	createTestPlay(t, db, liveGame, user, partner, database.JSONB{"status": "playing"}, true)
+
+	request := &database.GameRequest{GameID: requestedGame.ID, RequesterID: partner.ID, PartnerID: user.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(request).Error; err != nil {
+		t.Fatalf("failed to create game request: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(request) })
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/games/with-status", nil, user.ID)
+	h.ListGamesWithStatus(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response ListGamesWithStatusResponse
+	decodeResponse(t, recorder, &response)
+
+	want := map[uuid.UUID][2]bool{
+		liveGame.ID:      {true, false},
+		requestedGame.ID: {false, true},
+		idleGame.ID:      {false, false},
+	}
+	for _, game := range response.Games {
+		wantStatus, ok := want[game.ID]
+		if !ok {
+			continue
+		}
+		delete(want, game.ID)
+		if game.HasLivePlay != wantStatus[0] || game.HasPendingRequest != wantStatus[1] {
+			t.Errorf("game %s has_live_play = %v, has_pending_request = %v, want %v, %v",
+				game.ID, game.HasLivePlay, game.HasPendingRequest, wantStatus[0], wantStatus[1])
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("games %v missing from the response", want)
+	}
+}
+
+func TestGetLivePlayWithOpponent(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	createTestPartnership(t, db, user, partner)
+	// The play's partner order is the reverse of the partnership's
+	play := createTestPlay(t, db, game, partner, user, database.JSONB{"status": "playing"}, true)
+
+	lookup := func(userID, opponentID uuid.UUID) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/"+game.ID.String()+"/play/with/"+opponentID.String(), nil, userID)
+		c.Params = gin.Params{{Key: "gameId", Value: game.ID.String()}, {Key: "opponentId", Value: opponentID.String()}}
+		h.GetLivePlayWithOpponent(c)
+		return recorder
+	}
+
+	for _, pair := range [][2]uuid.UUID{{user.ID, partner.ID}, {partner.ID, user.ID}} {
+		recorder := lookup(pair[0], pair[1])
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("lookup by %s status = %d, want %d: %s", pair[0], recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response GetLivePlayResponse
+		decodeResponse(t, recorder, &response)
+		if response.Play == nil || response.Play.ID != play.ID {
+			t.Errorf("lookup by %s play = %+v, want %s", pair[0], response.Play, play.ID)
+		}
+	}
+
+	// Someone who isn't partnered with the opponent can't tell whether a play exists
+	if recorder := lookup(stranger.ID, user.ID); recorder.Code != http.StatusNotFound {
+		t.Errorf("lookup by a stranger status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestPlayGamePreview(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+
+	preview := func() PlayGamePreviewResponse {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/"+game.ID.String()+"/play-preview", nil, user.ID)
+		c.Params = gin.Params{{Key: "gameId", Value: game.ID.String()}}
+		h.PlayGamePreview(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response PlayGamePreviewResponse
+		decodeResponse(t, recorder, &response)
+		return response
+	}
+
+	if response := preview(); response.State != playPreviewNoPartner {
+		t.Errorf("state without a partner = %q, want %q", response.State, playPreviewNoPartner)
+	}
+
+	createTestPartnership(t, db, user, partner)
+	if response := preview(); response.State != playPreviewWouldCreateRequest {
+		t.Errorf("state with a partner = %q, want %q", response.State, playPreviewWouldCreateRequest)
+	}
+	var requests int64
+	if err := db.Model(&database.GameRequest{}).Where("requester_id = ?", user.ID).Count(&requests).Error; err != nil {
+		t.Fatalf("failed to count game requests: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("preview created %d game requests", requests)
+	}
+
+	request := &database.GameRequest{GameID: game.ID, RequesterID: user.ID, PartnerID: partner.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(request).Error; err != nil {
+		t.Fatalf("failed to create game request: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(request) })
+	if response := preview(); response.State != playPreviewPendingRequest || response.RequestID == nil || *response.RequestID != request.ID {
+		t.Errorf("preview with a pending request = %+v, want %q for request %s", response, playPreviewPendingRequest, request.ID)
+	}
+
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing"}, true)
+	if response := preview(); response.State != playPreviewLivePlay || response.PlayID == nil || *response.PlayID != play.ID {
+		t.Errorf("preview with a live play = %+v, want %q for play %s", response, playPreviewLivePlay, play.ID)
+	}
+}
+
+func TestPauseAndResumePlay(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "waiting_secrets"}, true)
+
+	call := func(handle gin.HandlerFunc, userID uuid.UUID, action string, body interface{}) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/games/plays/"+play.ID.String()+"/"+action, body, userID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		handle(c)
+		return recorder
+	}
+
+	if recorder := call(h.PausePlay, user.ID, "pause", nil); recorder.Code != http.StatusOK {
+		t.Fatalf("pause status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if recorder := call(h.PausePlay, partner.ID, "pause", nil); recorder.Code != http.StatusConflict {
+		t.Errorf("second pause status = %d, want %d", recorder.Code, http.StatusConflict)
+	}
+	if recorder := call(h.SetSecret, partner.ID, "secret", SetSecretRequest{Secret: "1234"}); recorder.Code != http.StatusConflict {
+		t.Errorf("SetSecret while paused status = %d, want %d", recorder.Code, http.StatusConflict)
+	}
+
+	// Either participant can resume
+	recorder := call(h.ResumePlay, partner.ID, "resume", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("resume status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response PausePlayResponse
+	decodeResponse(t, recorder, &response)
+	if isPlayPaused(response.Play.PlayData) || response.Play.PlayData["paused_at"] != nil {
+		t.Errorf("play data after resuming = %v, want it unpaused", response.Play.PlayData)
+	}
+	if _, ok := response.Play.PlayData["paused_seconds"].(float64); !ok {
+		t.Errorf("paused_seconds = %v, want the time spent paused", response.Play.PlayData["paused_seconds"])
+	}
+	if recorder := call(h.ResumePlay, user.ID, "resume", nil); recorder.Code != http.StatusConflict {
+		t.Errorf("second resume status = %d, want %d", recorder.Code, http.StatusConflict)
+	}
+}
+
+func TestSetSecretAttemptCooldown(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.SecretAttemptLimit = 2
+	cfg.SecretAttemptCooldownSeconds = 60
+	h := NewGamesHandler(cfg, nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "waiting_secrets"}, true)
+
+	setSecret := func(secret string) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/secret", SetSecretRequest{Secret: secret}, user.ID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.SetSecret(c)
+		return recorder
+	}
+
+	for i := 0; i < cfg.SecretAttemptLimit; i++ {
+		if recorder := setSecret("1123"); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("invalid SetSecret %d status = %d, want %d", i, recorder.Code, http.StatusBadRequest)
+		}
+	}
+
+	// Even a valid secret is refused during the cooldown
+	recorder := setSecret("1234")
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("SetSecret during cooldown status = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter == "" {
+		t.Error("Retry-After header not set")
+	}
+
+	// Once the cooldown is over the valid secret is accepted
+	attemptKey := user.ID.String() + ":" + play.ID.String()
+	h.secretAttemptsMu.Lock()
+	h.secretAttempts[attemptKey].lockedUntil = time.Now()
+	h.secretAttemptsMu.Unlock()
+	if recorder := setSecret("1234"); recorder.Code != http.StatusOK {
+		t.Fatalf("SetSecret after cooldown status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if wait := h.secretAttemptWait(attemptKey); wait != 0 {
+		t.Errorf("wait after a valid secret = %v, want 0", wait)
+	}
+}
+
+func TestSecretAttemptsUnlimitedByDefault(t *testing.T) {
+	h := &GamesHandler{secretAttempts: make(map[string]*secretAttempts)}
+	for i := 0; i < 10; i++ {
+		h.recordInvalidSecretAttempt("player:play")
+	}
+	if wait := h.secretAttemptWait("player:play"); wait != 0 {
+		t.Errorf("wait = %v, want 0 with no attempt limit", wait)
+	}
+	if len(h.secretAttempts) != 0 {
+		t.Errorf("tracked %d players, want none with no attempt limit", len(h.secretAttempts))
+	}
+}
+
+func TestLockPlaySerializesUpdates(t *testing.T) {
+	playID := uuid.New()
+	counter := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := lockPlay(playID)
+			defer unlock()
+			// Read and write in separate steps, as a load-modify-save does
+			current := counter
+			time.Sleep(time.Microsecond)
+			counter = current + 1
+		}()
+	}
+	wg.Wait()
+
+	if counter != 50 {
+		t.Errorf("counter = %d, want 50", counter)
+	}
+	playLocksMu.Lock()
+	defer playLocksMu.Unlock()
+	if _, exists := playLocks[playID]; exists {
+		t.Error("play lock was not removed after the last unlock")
+	}
+}
+
+func TestMakeGuessConcurrent(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	// Shared mode has no turns, so both players can guess at the same time
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows", "mode": bullsAndCowsModeShared})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing", "shared_secret": "1234"}, true)
+
+	const guessesPerPlayer = 10
+	var wg sync.WaitGroup
+	codes := make(chan int, 2*guessesPerPlayer)
+	for _, player := range []*database.User{user, partner} {
+		for i := 0; i < guessesPerPlayer; i++ {
+			wg.Add(1)
+			go func(playerID uuid.UUID) {
+				defer wg.Done()
+				c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/guess", MakeGuessRequest{Guess: "5678"}, playerID)
+				c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+				h.MakeGuess(c)
+				codes <- recorder.Code
+			}(player.ID)
+		}
+	}
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("MakeGuess status = %d, want %d", code, http.StatusOK)
+		}
+	}
+
+	var stored database.Play
+	if err := db.First(&stored, "id = ?", play.ID).Error; err != nil {
+		t.Fatalf("failed to reload play: %v", err)
+	}
+	guesses, _ := stored.PlayData["guesses"].([]interface{})
+	if len(guesses) != 2*guessesPerPlayer {
+		t.Errorf("stored %d guesses, want %d", len(guesses), 2*guessesPerPlayer)
+	}
+}
+
+func TestGetGameRequest(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	requester := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+
+	createRequest := func(expiresAt time.Time) *database.GameRequest {
+		request := &database.GameRequest{GameID: game.ID, RequesterID: requester.ID, PartnerID: partner.ID, Status: "pending", ExpiresAt: expiresAt}
+		if err := db.Create(request).Error; err != nil {
+			t.Fatalf("failed to create game request: %v", err)
+		}
+		t.Cleanup(func() { db.Delete(request) })
+		return request
+	}
+	request := createRequest(time.Now().Add(time.Hour))
+	lapsed := createRequest(time.Now().Add(-time.Minute))
+
+	getRequest := func(requestID string, userID uuid.UUID) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/requests/"+requestID, nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: requestID}}
+		h.GetGameRequest(c)
+		return recorder
+	}
+
+	for _, user := range []*database.User{requester, partner} {
+		recorder := getRequest(request.ID.String(), user.ID)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status for %s = %d, want %d", user.ID, recorder.Code, http.StatusOK)
+		}
+		var response GetGameRequestResponse
+		decodeResponse(t, recorder, &response)
+		if response.Request.ID != request.ID || response.Request.Status != "pending" {
+			t.Errorf("request = %+v, want pending %s", response.Request, request.ID)
+		}
+		if response.Request.Game.ID != game.ID || response.Request.Requester.ID != requester.ID || response.Request.Partner.ID != partner.ID {
+			t.Errorf("request relations not loaded: %+v", response.Request)
+		}
+	}
+
+	recorder := getRequest(lapsed.ID.String(), partner.ID)
+	var response GetGameRequestResponse
+	decodeResponse(t, recorder, &response)
+	if response.Request == nil || response.Request.Status != "expired" {
+		t.Errorf("lapsed request = %+v, want it reported as expired", response.Request)
+	}
+
+	if recorder := getRequest(request.ID.String(), stranger.ID); recorder.Code != http.StatusNotFound {
+		t.Errorf("unrelated user status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+	if recorder := getRequest(uuid.NewString(), requester.ID); recorder.Code != http.StatusNotFound {
+		t.Errorf("missing request status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+	if recorder := getRequest("not-a-uuid", requester.ID); recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid ID status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestCreateGameRequestAutoAccept(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	gamesHandler := NewGamesHandler(cfg, nil, nil)
+	partnerHandler := NewPartnerHandler(cfg, nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	partnership := createTestPartnership(t, db, user, partner)
+	t.Cleanup(func() {
+		users := []uuid.UUID{user.ID, partner.ID}
+		db.Where("requester_id IN ?", users).Delete(&database.GameRequest{})
+		db.Unscoped().Where("partner1_id IN ?", users).Delete(&database.Play{})
+	})
+
+	setAutoAccept := func(userID uuid.UUID, enabled bool) {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodPut, "/api/v1/partners/"+partnership.ID.String()+"/auto-accept", SetAutoAcceptRequest{Enabled: &enabled}, userID)
+		c.Params = gin.Params{{Key: "id", Value: partnership.ID.String()}}
+		partnerHandler.SetAutoAccept(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("SetAutoAccept status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+	}
+	createRequest := func() CreateGameRequestResponse {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/games/requests", CreateGameRequestRequest{GameID: game.ID.String()}, user.ID)
+		gamesHandler.CreateGameRequest(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("CreateGameRequest status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response CreateGameRequestResponse
+		decodeResponse(t, recorder, &response)
+		return response
+	}
+
+	// Only one partner opted in, so the partner still has to accept
+	setAutoAccept(user.ID, true)
+	response := createRequest()
+	if response.Request == nil || response.Play != nil {
+		t.Fatalf("response = %+v, want a pending request", response)
+	}
+	if err := db.Delete(&database.GameRequest{}, "id = ?", response.Request.ID).Error; err != nil {
+		t.Fatalf("failed to delete game request: %v", err)
+	}
+
+	setAutoAccept(partner.ID, true)
+	response = createRequest()
+	if response.Play == nil || response.Request != nil {
+		t.Fatalf("response = %+v, want a play started directly", response)
+	}
+	if !response.Play.IsLive || response.Play.GameID != game.ID {
+		t.Errorf("play = %+v, want a live play of the game", response.Play)
+	}
+}
+
+func TestGetMyTurns(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	otherPartner := createTestUser(t, db)
+
+	turnData := func(turn *database.User) database.JSONB {
+		return database.JSONB{"status": "playing", "current_turn": turn.ID.String(), "partner1_secret": "1234", "partner2_secret": "5678"}
+	}
+	myTurn := createTestPlay(t, db, game, user, partner, turnData(user), true)
+	otherMyTurn := createTestPlay(t, db, game, otherPartner, user, turnData(user), true)
+	createTestPlay(t, db, game, user, partner, turnData(partner), true)
+	createTestPlay(t, db, game, user, otherPartner, turnData(user), false)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/games/my-turns", nil, user.ID)
+	h.GetMyTurns(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response GetMyTurnsResponse
+	decodeResponse(t, recorder, &response)
+	// Plays waiting on the partner and ended plays aren't counted
+	want := map[uuid.UUID]bool{myTurn.ID: true, otherMyTurn.ID: true}
+	if response.Count != len(want) || len(response.Plays) != len(want) {
+		t.Fatalf("count = %d with %d plays, want %d", response.Count, len(response.Plays), len(want))
+	}
+	for _, play := range response.Plays {
+		if !want[play.ID] {
+			t.Errorf("unexpected play %s", play.ID)
+		}
+		opponentKey := "partner2_secret"
+		if play.Partner2ID == user.ID {
+			opponentKey = "partner1_secret"
+		}
+		if secret := play.PlayData[opponentKey]; secret != nil {
+			t.Errorf("play %s reveals the opponent's secret %v", play.ID, secret)
+		}
+	}
+}
+
+func TestMakeGuessUpdatesCurrentTurnUserID(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{
+		"status":          "playing",
+		"current_turn":    user.ID.String(),
+		"partner1_secret": "1234",
+		"partner2_secret": "5678",
+	}, true)
+
+	guess := func(userID uuid.UUID, value string) {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/guess", MakeGuessRequest{Guess: value}, userID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.MakeGuess(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("MakeGuess status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+	}
+	currentTurn := func() *uuid.UUID {
+		t.Helper()
+		var stored database.Play
+		if err := db.First(&stored, "id = ?", play.ID).Error; err != nil {
+			t.Fatalf("failed to reload play: %v", err)
+		}
+		if turn, _ := stored.PlayData["current_turn"].(string); stored.CurrentTurnUserID != nil && stored.CurrentTurnUserID.String() != turn {
+			t.Errorf("current_turn_user_id = %s, play data current_turn = %q", stored.CurrentTurnUserID, turn)
+		}
+		return stored.CurrentTurnUserID
+	}
+
+	if turn := currentTurn(); turn == nil || *turn != user.ID {
+		t.Fatalf("initial turn = %v, want %s", turn, user.ID)
+	}
+	guess(user.ID, "1678")
+	if turn := currentTurn(); turn == nil || *turn != partner.ID {
+		t.Errorf("turn after a miss = %v, want %s", turn, partner.ID)
+	}
+	guess(partner.ID, "1234")
+	if turn := currentTurn(); turn != nil {
+		t.Errorf("turn after the play was won = %v, want none", turn)
+	}
+}
+
+func TestGenerateSecretFollowsRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		details database.JSONB
+		want    int
+	}{
+		{name: "default length", details: database.JSONB{}, want: defaultSecretLength},
+		{name: "shortest", details: database.JSONB{"secret_length": float64(minSecretLength)}, want: minSecretLength},
+		{name: "six digits", details: database.JSONB{"secret_length": float64(6)}, want: 6},
+		{name: "every digit", details: database.JSONB{"secret_length": float64(maxSecretLength)}, want: maxSecretLength},
+		{name: "too long", details: database.JSONB{"secret_length": float64(maxSecretLength + 1)}, want: defaultSecretLength},
+		{name: "too short", details: database.JSONB{"secret_length": float64(minSecretLength - 1)}, want: defaultSecretLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			length := secretLength(database.Game{Details: tt.details})
+			if length != tt.want {
+				t.Fatalf("secretLength() = %d, want %d", length, tt.want)
+			}
+			// Generation is random, so check enough secrets to hit the leading-digit and uniqueness rules
+			for i := 0; i < 200; i++ {
+				secret, err := generateSecret(length)
+				if err != nil {
+					t.Fatalf("generateSecret(%d) error = %v", length, err)
+				}
+				if err := validateSecret(secret, length); err != nil {
+					t.Fatalf("generateSecret(%d) = %q, which is invalid: %v", length, secret, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetExampleSecret(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows", "secret_length": float64(6)})
+	ticTacToe := createTestGame(t, db, database.JSONB{"type": "tic_tac_toe"})
+	user := createTestUser(t, db)
+
+	getExample := func(gameID uuid.UUID) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/"+gameID.String()+"/example-secret", nil, user.ID)
+		c.Params = gin.Params{{Key: "gameId", Value: gameID.String()}}
+		h.GetExampleSecret(c)
+		return recorder
+	}
+
+	recorder := getExample(game.ID)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response ExampleSecretResponse
+	decodeResponse(t, recorder, &response)
+	if !response.Example {
+		t.Error("example flag not set")
+	}
+	if err := validateSecret(response.Secret, 6); err != nil {
+		t.Errorf("example secret %q is invalid for the game: %v", response.Secret, err)
+	}
+
+	if recorder := getExample(ticTacToe.ID); recorder.Code != http.StatusBadRequest {
+		t.Errorf("game without secrets status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	if recorder := getExample(uuid.New()); recorder.Code != http.StatusNotFound {
+		t.Errorf("missing game status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestLivePlayLimitAcrossPartnerships(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.MaxLivePlaysPerUser = 2
+	h := NewGamesHandler(cfg, nil, nil)
+	seedBotUser(t, db)
+	first := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	second := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	third := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	fourth := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partnerA := createTestUser(t, db)
+	partnerB := createTestUser(t, db)
+	partnerC := createTestUser(t, db)
+	for _, partner := range []*database.User{partnerA, partnerB, partnerC} {
+		createTestPartnership(t, db, user, partner)
+	}
+	createTestPlay(t, db, first, user, partnerA, database.JSONB{"status": "playing"}, true)
+	createTestPlay(t, db, second, partnerB, user, database.JSONB{"status": "playing"}, true)
+	t.Cleanup(func() {
+		db.Unscoped().Where("game_id IN ?", []uuid.UUID{first.ID, second.ID, third.ID, fourth.ID}).Delete(&database.Play{})
+	})
+
+	newRequest := func(requester, recipient *database.User, game *database.Game) *database.GameRequest {
+		request := &database.GameRequest{GameID: game.ID, RequesterID: requester.ID, PartnerID: recipient.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := db.Create(request).Error; err != nil {
+			t.Fatalf("failed to create game request: %v", err)
+		}
+		t.Cleanup(func() { db.Delete(request) })
+		return request
+	}
+	accept := func(request *database.GameRequest) int {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/game-requests/"+request.ID.String()+"/respond", RespondToGameRequestRequest{Accept: true}, request.PartnerID)
+		c.Params = gin.Params{{Key: "id", Value: request.ID.String()}}
+		h.RespondToGameRequest(c)
+		if err := db.First(request, "id = ?", request.ID).Error; err != nil {
+			t.Fatalf("failed to reload game request: %v", err)
+		}
+		return recorder.Code
+	}
+
+	// Two live plays with different partners put the user at the limit
+	refused := newRequest(partnerC, user, third)
+	if code := accept(refused); code != http.StatusTooManyRequests || refused.Status != "pending" {
+		t.Errorf("accept at the limit = %d, request %s, want %d and the request left pending", code, refused.Status, http.StatusTooManyRequests)
+	}
+
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/games/bot", PlayBotRequest{GameID: third.ID.String()}, user.ID)
+	h.PlayBot(c)
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("PlayBot at the limit = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+	}
+
+	// A play that replaces a live play of the same game doesn't add to the count
+	if code := accept(newRequest(partnerA, user, first)); code != http.StatusOK {
+		t.Errorf("accept replacing a live play = %d, want %d", code, http.StatusOK)
+	}
+
+	// One below the limit, a new play is allowed
+	h.maxLivePlaysPerUser = 3
+	if code := accept(refused); code != http.StatusOK {
+		t.Errorf("accept below the limit = %d, want %d", code, http.StatusOK)
+	}
+	count, err := database.NewPlayRepository(db).CountLivePlaysByUser(user.ID)
+	if err != nil {
+		t.Fatalf("CountLivePlaysByUser() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("live plays = %d, want 3", count)
+	}
+
+	// Accepting is refused when the requester, rather than the one accepting, is at the limit
+	request := newRequest(user, partnerB, fourth)
+	if code := accept(request); code != http.StatusTooManyRequests || request.Status != "pending" {
+		t.Errorf("accept with the requester at the limit = %d, request %s, want %d and the request left pending", code, request.Status, http.StatusTooManyRequests)
+	}
+}
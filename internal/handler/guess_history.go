@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// withFullGuessHistory returns the play itself when none of its guesses are archived,
+// otherwise a copy whose PlayData guesses hold the full history
+func (h *GamesHandler) withFullGuessHistory(play *database.Play) (*database.Play, error) {
+	if database.ArchivedGuessCount(play.PlayData) == 0 {
+		return play, nil
+	}
+
+	history, err := h.playRepo.FindGuessHistory(play)
+	if err != nil {
+		return nil, err
+	}
+
+	full := *play
+	full.PlayData = make(database.JSONB, len(play.PlayData))
+	for key, value := range play.PlayData {
+		full.PlayData[key] = value
+	}
+	full.PlayData["guesses"] = history
+	return &full, nil
+}
+
+// GetPlayMoveHistoryResponse represents the response for getting a page of a play's guesses
+type GetPlayMoveHistoryResponse struct {
+	PlayID  uuid.UUID                `json:"play_id"`
+	Guesses []map[string]interface{} `json:"guesses"` // Moves, for Tic-Tac-Toe
+	Total   int                      `json:"total"`
+	Limit   int                      `json:"limit"`
+	Offset  int                      `json:"offset"`
+}
+
+// GetPlayMoveHistory handles paging through a play's guesses in timestamp order, without the rest of the play data
+func (h *GamesHandler) GetPlayMoveHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	var history []interface{}
+	if isTicTacToe(play) {
+		history, _ = play.PlayData["moves"].([]interface{})
+	} else {
+		history, err = h.playRepo.FindGuessHistory(play)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch guesses: " + err.Error()})
+			return
+		}
+	}
+	entries := sortHistoryEntries(history)
+
+	total := len(entries)
+	start := page.Offset
+	if start > total {
+		start = total
+	}
+	end := start + page.Limit
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, GetPlayMoveHistoryResponse{
+		PlayID:  play.ID,
+		Guesses: entries[start:end],
+		Total:   total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// sortHistoryEntries drops malformed history entries and orders the rest by timestamp
+// Entries without a readable timestamp keep their place after the entry stored before them
+func sortHistoryEntries(history []interface{}) []map[string]interface{} {
+	type timedEntry struct {
+		entry map[string]interface{}
+		at    time.Time
+	}
+
+	timed := make([]timedEntry, 0, len(history))
+	var last time.Time
+	for _, raw := range history {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if timestampStr, ok := entry["timestamp"].(string); ok {
+			if at, err := time.Parse(time.RFC3339Nano, timestampStr); err == nil {
+				last = at
+			}
+		}
+		timed = append(timed, timedEntry{entry: entry, at: last})
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].at.Before(timed[j].at)
+	})
+
+	entries := make([]map[string]interface{}, len(timed))
+	for i, t := range timed {
+		entries[i] = t.entry
+	}
+	return entries
+}
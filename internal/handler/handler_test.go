@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+)
+
+// openTestDB points database.DB at the database named by TEST_DATABASE_URL and migrates it, skipping the test when unset
+// Handlers must be created after this, since they take their repositories from database.DB
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// createTestUser creates a user with a unique email, removed when the test finishes
+func createTestUser(t *testing.T, db *gorm.DB) *database.User {
+	t.Helper()
+
+	user := &database.User{Email: uuid.NewString() + "@example.com", Name: "Test User"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(user) })
+	return user
+}
+
+// newTestConfig returns the configuration handlers are tested with
+func newTestConfig() *config.Config {
+	return &config.Config{
+		Environment:      "test",
+		JWTSecret:        "test-secret",
+		JWTExpiry:        "1h",
+		JWTRefreshExpiry: "24h",
+		OTPExpiryMinutes: 5,
+		OTPLength:        4,
+		PageDefaultLimit: 20,
+		PageMaxLimit:     100,
+	}
+}
+
+// newTestContext returns a context for a request with the given JSON body (nil for none), recording the response
+// userID, if not uuid.Nil, is set as the authenticated user
+func newTestContext(method, path string, body interface{}, userID uuid.UUID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	var reader *bytes.Reader
+	if body != nil {
+		encoded, _ := json.Marshal(body)
+		reader = bytes.NewReader(encoded)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	c.Request = httptest.NewRequest(method, path, reader)
+	c.Request.Header.Set("Content-Type", "application/json")
+	if userID != uuid.Nil {
+		c.Set("user_id", userID)
+	}
+	return c, recorder
+}
+
+// decodeResponse decodes a recorded JSON response into v, failing the test on error
+func decodeResponse(t *testing.T, recorder *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to decode response %q: %v", recorder.Body.String(), err)
+	}
+}
+
+// createTestGame creates a game with the given details, removed when the test finishes
+func createTestGame(t *testing.T, db *gorm.DB, details database.JSONB) *database.Game {
+	t.Helper()
+
+	game := &database.Game{Name: "Test Game", Slug: "test-" + uuid.NewString(), Details: details}
+	if err := db.Create(game).Error; err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(game) })
+	return game
+}
+
+// createTestPlay creates a play of the game between two users, removed when the test finishes
+func createTestPlay(t *testing.T, db *gorm.DB, game *database.Game, partner1, partner2 *database.User, playData database.JSONB, isLive bool) *database.Play {
+	t.Helper()
+
+	play := &database.Play{GameID: game.ID, Partner1ID: partner1.ID, Partner2ID: partner2.ID, PlayData: playData, IsLive: isLive}
+	if err := db.Create(play).Error; err != nil {
+		t.Fatalf("failed to create play: %v", err)
+	}
+	// Plays created as ended still take the column default, so end them explicitly
+	if !isLive {
+		if err := db.Model(play).Update("is_live", false).Error; err != nil {
+			t.Fatalf("failed to end play: %v", err)
+		}
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(play) })
+	return play
+}
+
+// createTestPartnership partners two users, removed when the test finishes
+func createTestPartnership(t *testing.T, db *gorm.DB, user1, user2 *database.User) *database.Partnership {
+	t.Helper()
+
+	partnership := &database.Partnership{User1ID: user1.ID, User2ID: user2.ID}
+	if err := db.Create(partnership).Error; err != nil {
+		t.Fatalf("failed to create partnership: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(partnership) })
+	return partnership
+}
+
+// mustMarshal encodes v as JSON, failing the test on error
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode %v: %v", v, err)
+	}
+	return encoded
+}
+
+// recordingEmailClient is an email client that records what it's asked to send instead of sending it
+type recordingEmailClient struct {
+	mu        sync.Mutex
+	otps      []string // Recipients of OTP emails
+	templated []string // Template names of templated emails, in order
+}
+
+func (r *recordingEmailClient) SendOTPEmail(toEmail, otpCode string, expiryMinutes int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.otps = append(r.otps, toEmail)
+	return nil
+}
+
+func (r *recordingEmailClient) SendTemplated(toEmail, templateName string, data map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templated = append(r.templated, templateName)
+	return nil
+}
+
+// templatedSent returns the template names sent so far, waiting up to a second for at least want of them
+// Some emails are sent in the background, after the handler returns
+func (r *recordingEmailClient) templatedSent(want int) []string {
+	deadline := time.Now().Add(time.Second)
+	for {
+		r.mu.Lock()
+		sent := append([]string(nil), r.templated...)
+		r.mu.Unlock()
+		if len(sent) >= want || time.Now().After(deadline) {
+			return sent
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
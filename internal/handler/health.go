@@ -8,27 +8,62 @@ import (
 )
 
 // HealthHandler handles health check requests
-type HealthHandler struct{}
+type HealthHandler struct {
+	buildVersion string
+	buildTime    string
+	startTime    time.Time
+}
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(buildVersion, buildTime string) *HealthHandler {
+	return &HealthHandler{
+		buildVersion: buildVersion,
+		buildTime:    buildTime,
+		startTime:    time.Now(),
+	}
 }
 
 // HealthCheckResponse represents the health check response
 type HealthCheckResponse struct {
-	Message   string    `json:"message"`
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
+	Message       string    `json:"message"`
+	Status        string    `json:"status"`
+	Timestamp     time.Time `json:"timestamp"`
+	Version       string    `json:"version"`
+	BuildTime     string    `json:"build_time"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
 }
 
 // HealthCheck handles GET /api/v1/health-check
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	response := HealthCheckResponse{
-		Message:   "Service is healthy",
-		Status:    "ok",
-		Timestamp: time.Now(),
+		Message:       "Service is healthy",
+		Status:        "ok",
+		Timestamp:     time.Now(),
+		Version:       h.buildVersion,
+		BuildTime:     h.buildTime,
+		UptimeSeconds: h.uptimeSeconds(),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// VersionResponse represents the version response
+type VersionResponse struct {
+	Version       string `json:"version"`
+	BuildTime     string `json:"build_time"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+}
+
+// Version handles GET /api/v1/version
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, VersionResponse{
+		Version:       h.buildVersion,
+		BuildTime:     h.buildTime,
+		UptimeSeconds: h.uptimeSeconds(),
+	})
+}
+
+// uptimeSeconds returns the number of whole seconds since the handler was created
+func (h *HealthHandler) uptimeSeconds() int64 {
+	return int64(time.Since(h.startTime).Seconds())
+}
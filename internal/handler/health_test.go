@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVersion(t *testing.T) {
+	h := NewHealthHandler("v1.2.3", "2026-01-02T03:04:05Z")
+	h.startTime = time.Now().Add(-90 * time.Second)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/version", nil, uuid.Nil)
+	h.Version(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var response VersionResponse
+	decodeResponse(t, recorder, &response)
+	if response.Version != "v1.2.3" || response.BuildTime != "2026-01-02T03:04:05Z" {
+		t.Errorf("version = %q, build time = %q, want the ones the handler was built with", response.Version, response.BuildTime)
+	}
+	if response.UptimeSeconds < 90 || response.UptimeSeconds > 95 {
+		t.Errorf("uptime_seconds = %d, want about 90", response.UptimeSeconds)
+	}
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+)
+
+// Inbox item types
+const (
+	InboxItemPartnerRequest = "partner_request"
+	InboxItemGameRequest    = "game_request"
+)
+
+// InboxHandler handles the unified feed of requests awaiting the user's response
+type InboxHandler struct {
+	userRepo         *database.UserRepository
+	partnershipRepo  *database.PartnershipRepository
+	gameRequestRepo  *database.GameRequestRepository
+	playRepo         *database.PlayRepository
+	notificationRepo *database.NotificationRepository
+	pageLimits       pageLimits
+}
+
+// NewInboxHandler creates a new inbox handler
+func NewInboxHandler(cfg *config.Config) *InboxHandler {
+	return &InboxHandler{
+		userRepo:         database.NewUserRepository(database.DB),
+		partnershipRepo:  database.NewPartnershipRepository(database.DB),
+		gameRequestRepo:  database.NewGameRequestRepository(database.DB),
+		playRepo:         database.NewPlayRepository(database.DB),
+		notificationRepo: newNotificationRepository(cfg),
+		pageLimits:       newPageLimits(cfg),
+	}
+}
+
+// InboxItem is a single actionable request; exactly one of the request fields is set, according to Type
+type InboxItem struct {
+	Type           string                   `json:"type"` // partner_request, game_request
+	CreatedAt      time.Time                `json:"created_at"`
+	PartnerRequest *database.PartnerRequest `json:"partner_request,omitempty"`
+	GameRequest    *database.GameRequest    `json:"game_request,omitempty"`
+}
+
+// GetInboxResponse represents the response for getting the inbox
+type GetInboxResponse struct {
+	Items  []InboxItem `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// GetInbox handles listing received partner and game requests, newest first
+func (h *InboxHandler) GetInbox(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user: " + err.Error()})
+		return
+	}
+
+	// Query by both ID and email to handle requests sent before user signed up
+	partnerRequests, err := h.partnershipRepo.FindPendingRequestsByRecipient(userUUID, user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get partner requests: " + err.Error()})
+		return
+	}
+
+	// Expire old requests first
+	_ = h.gameRequestRepo.ExpireOldRequests()
+
+	gameRequests, err := h.gameRequestRepo.FindPendingRequestsByPartner(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get game requests: " + err.Error()})
+		return
+	}
+
+	items := make([]InboxItem, 0, len(partnerRequests)+len(gameRequests))
+	for i := range partnerRequests {
+		items = append(items, InboxItem{
+			Type:           InboxItemPartnerRequest,
+			CreatedAt:      partnerRequests[i].CreatedAt,
+			PartnerRequest: &partnerRequests[i],
+		})
+	}
+	for i := range gameRequests {
+		items = append(items, InboxItem{
+			Type:        InboxItemGameRequest,
+			CreatedAt:   gameRequests[i].CreatedAt,
+			GameRequest: &gameRequests[i],
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	total := len(items)
+	start := page.Offset
+	if start > total {
+		start = total
+	}
+	end := start + page.Limit
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, GetInboxResponse{
+		Items:  items[start:end],
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// requestNotificationTypes are the notifications sent for a received request, which the request counts stand for
+var requestNotificationTypes = map[string]bool{
+	"partner_request": true,
+	"game_request":    true,
+}
+
+// GetAttentionResponse represents the response for getting what needs the user's attention
+type GetAttentionResponse struct {
+	UnreadNotifications int64 `json:"unread_notifications"`
+	PartnerRequests     int64 `json:"partner_requests"` // Received and pending
+	GameRequests        int64 `json:"game_requests"`    // Received, pending and unexpired
+	MyTurns             int64 `json:"my_turns"`         // Live plays waiting on the user's move
+	Total               int64 `json:"total"`            // Counts each request once, not also its notification
+}
+
+// GetAttention handles counting everything awaiting the user, for app badges
+// Called on every app foreground, so it only runs count queries
+func (h *InboxHandler) GetAttention(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user: " + err.Error()})
+		return
+	}
+
+	var response GetAttentionResponse
+
+	unread, err := h.notificationRepo.CountUnreadByType(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+	// Each received request also has an unread notification; the request itself is what the badge counts
+	var unreadOther int64
+	for notificationType, count := range unread {
+		response.UnreadNotifications += count
+		if !requestNotificationTypes[notificationType] {
+			unreadOther += count
+		}
+	}
+
+	// Count by both ID and email to include requests sent before user signed up
+	response.PartnerRequests, err = h.partnershipRepo.CountPendingRequestsByRecipient(userUUID, user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count partner requests: " + err.Error()})
+		return
+	}
+
+	response.GameRequests, err = h.gameRequestRepo.CountPendingRequestsByPartner(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count game requests: " + err.Error()})
+		return
+	}
+
+	response.MyTurns, err = h.playRepo.CountPlaysAwaitingTurn(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count turns: " + err.Error()})
+		return
+	}
+
+	response.Total = unreadOther + response.PartnerRequests + response.GameRequests + response.MyTurns
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestGetAttention(t *testing.T) {
+	db := openTestDB(t)
+	h := NewInboxHandler(newTestConfig())
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user, sender, partner := createTestUser(t, db), createTestUser(t, db), createTestUser(t, db)
+
+	// A received partner request and a received game request, each with the notification sent for it
+	partnerRequest := &database.PartnerRequest{SenderID: sender.ID, RecipientEmail: user.Email, Status: "pending"}
+	gameRequest := &database.GameRequest{
+		GameID: game.ID, RequesterID: partner.ID, PartnerID: user.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour),
+	}
+	for _, row := range []interface{}{
+		partnerRequest,
+		gameRequest,
+		&database.Notification{UserID: user.ID, Type: "partner_request", Message: "partner request"},
+		&database.Notification{UserID: user.ID, Type: "game_request", Message: "game request"},
+		&database.Notification{UserID: user.ID, Type: "game_started", Message: "game started"},
+		&database.Notification{UserID: user.ID, Type: "play_message", Message: "read message", Read: true},
+	} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("failed to create %T: %v", row, err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("user_id = ?", user.ID).Delete(&database.Notification{})
+		db.Delete(partnerRequest)
+		db.Delete(gameRequest)
+	})
+
+	// A live play waiting on the user's move
+	createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing", "current_turn": user.ID.String()}, true)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/attention", nil, user.ID)
+	h.GetAttention(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response GetAttentionResponse
+	decodeResponse(t, recorder, &response)
+	want := GetAttentionResponse{
+		UnreadNotifications: 3,
+		PartnerRequests:     1,
+		GameRequests:        1,
+		MyTurns:             1,
+		Total:               4, // The game_started notification, both requests and the turn; request notifications aren't counted again
+	}
+	if response != want {
+		t.Errorf("GetAttention() = %+v, want %+v", response, want)
+	}
+}
+
+func TestGetAttentionNothingPending(t *testing.T) {
+	db := openTestDB(t)
+	h := NewInboxHandler(newTestConfig())
+	user := createTestUser(t, db)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/attention", nil, user.ID)
+	h.GetAttention(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response GetAttentionResponse
+	decodeResponse(t, recorder, &response)
+	if response != (GetAttentionResponse{}) {
+		t.Errorf("GetAttention() = %+v, want all zero", response)
+	}
+}
+
+func TestGetInbox(t *testing.T) {
+	db := openTestDB(t)
+	h := NewInboxHandler(newTestConfig())
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user, sender, partner := createTestUser(t, db), createTestUser(t, db), createTestUser(t, db)
+
+	// The partner request was sent to the user's email before the game request arrived
+	partnerRequest := &database.PartnerRequest{SenderID: sender.ID, RecipientEmail: user.Email, Status: "pending", CreatedAt: time.Now().Add(-time.Hour)}
+	gameRequest := &database.GameRequest{
+		GameID: game.ID, RequesterID: partner.ID, PartnerID: user.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour),
+	}
+	sentRequest := &database.GameRequest{
+		GameID: game.ID, RequesterID: user.ID, PartnerID: partner.ID, Status: "pending", ExpiresAt: time.Now().Add(time.Hour),
+	}
+	for _, row := range []interface{}{partnerRequest, gameRequest, sentRequest} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("failed to create %T: %v", row, err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Delete(partnerRequest)
+		db.Delete(gameRequest)
+		db.Delete(sentRequest)
+	})
+
+	getInbox := func(query string) GetInboxResponse {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/inbox"+query, nil, user.ID)
+		h.GetInbox(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response GetInboxResponse
+		decodeResponse(t, recorder, &response)
+		return response
+	}
+
+	response := getInbox("")
+	if response.Total != 2 || len(response.Items) != 2 {
+		t.Fatalf("inbox = %+v, want the 2 received requests", response)
+	}
+	if response.Items[0].Type != InboxItemGameRequest || response.Items[0].GameRequest == nil || response.Items[0].GameRequest.ID != gameRequest.ID {
+		t.Errorf("first item = %+v, want the newer game request", response.Items[0])
+	}
+	if response.Items[1].Type != InboxItemPartnerRequest || response.Items[1].PartnerRequest == nil || response.Items[1].PartnerRequest.ID != partnerRequest.ID {
+		t.Errorf("second item = %+v, want the older partner request", response.Items[1])
+	}
+
+	page := getInbox("?limit=1&offset=1")
+	if page.Total != 2 || len(page.Items) != 1 || page.Items[0].Type != InboxItemPartnerRequest {
+		t.Errorf("second page = %+v, want only the partner request", page)
+	}
+}
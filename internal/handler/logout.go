@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/logging"
+)
+
+// errTokenRevoked is returned by parseJWT for tokens revoked by logout or a session revocation
+var errTokenRevoked = errors.New("token has been revoked")
+
+// parseJWT verifies a JWT's signature and expiry and rejects tokens revoked by logout or a session revocation
+// Tokens issued before jti was added can't be logged out individually and are accepted until they expire
+func (h *AuthHandler) parseJWT(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return h.jwtSecret, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	if err := h.checkRevoked(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// checkRevoked rejects tokens denylisted by logout and tokens issued before the user's sessions were
+// last revoked, e.g. by an email change; both are looked up in a single query
+// Fails closed: a token that can't be checked isn't trusted
+func (h *AuthHandler) checkRevoked(claims jwt.MapClaims) error {
+	userIDStr, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return jwt.ErrSignatureInvalid
+	}
+
+	// Tokens without a jti match no denylist entry
+	jti, _ := claims["jti"].(string)
+	revocation, err := h.revokedRepo.FindTokenRevocation(jti, userID)
+	if err != nil {
+		return err
+	}
+	if revocation.Revoked {
+		return errTokenRevoked
+	}
+	if revocation.SessionsRevokedAt == nil {
+		return nil
+	}
+
+	// Timestamps have second precision, so a token from the same second is treated as older
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil || issuedAt.Unix() <= revocation.SessionsRevokedAt.Unix() {
+		return errTokenRevoked
+	}
+	return nil
+}
+
+// LogoutRequest represents the optional request body for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // Revoked along with the access token
+	Everywhere   bool   `json:"everywhere"`    // Revoke every refresh token of the user, logging out all devices
+}
+
+// LogoutResponse represents the response for logging out
+type LogoutResponse struct {
+	Message string `json:"message"`
+}
+
+// Logout handles revoking the access token the request was made with and the session's refresh token
+// The body is optional; without a refresh token only the access token is revoked
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	tokenString, _ := c.Get("token")
+	token, _ := tokenString.(string)
+
+	claims, err := h.parseJWT(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This token can't be revoked; it expires on its own"})
+		return
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token has no expiry"})
+		return
+	}
+
+	// Revoke refresh tokens first, so a failure leaves the access token usable to retry
+	if req.Everywhere {
+		userID, _ := c.Get("user_id")
+		userUUID, ok := userID.(uuid.UUID)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+			return
+		}
+		if err := h.refreshRepo.RevokeAllForUser(userUUID); err != nil {
+			logging.FromContext(c).Error("failed to revoke refresh tokens", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+			return
+		}
+	} else if req.RefreshToken != "" {
+		if err := h.refreshRepo.Revoke(req.RefreshToken); err != nil {
+			logging.FromContext(c).Error("failed to revoke refresh token", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+			return
+		}
+	}
+
+	if err := h.revokedRepo.Revoke(jti, expiresAt.Time); err != nil {
+		logging.FromContext(c).Error("failed to revoke token", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, LogoutResponse{
+		Message: "Logged out",
+	})
+}
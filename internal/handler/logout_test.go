@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestLogoutRevokesToken(t *testing.T) {
+	db := openTestDB(t)
+	h, err := NewAuthHandler(newTestConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+
+	token, err := h.generateJWT(user.ID, user.Email, "")
+	if err != nil {
+		t.Fatalf("generateJWT() error = %v", err)
+	}
+	other, err := h.generateJWT(user.ID, user.Email, "")
+	if err != nil {
+		t.Fatalf("generateJWT() error = %v", err)
+	}
+	claims, err := h.parseJWT(token)
+	if err != nil {
+		t.Fatalf("parseJWT() error = %v", err)
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		t.Fatal("token has no jti")
+	}
+	t.Cleanup(func() { db.Delete(&database.RevokedToken{JTI: jti}) })
+
+	logout := func() int {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/logout", nil, user.ID)
+		c.Set("token", token)
+		h.Logout(c)
+		return recorder.Code
+	}
+
+	if code := logout(); code != http.StatusOK {
+		t.Fatalf("Logout() status = %d, want %d", code, http.StatusOK)
+	}
+
+	// Presenting the token again after logout is refused, however many times it's tried
+	for i := 0; i < 2; i++ {
+		if _, _, err := h.VerifyJWT(token, ""); !errors.Is(err, errTokenRevoked) {
+			t.Errorf("VerifyJWT() after logout error = %v, want %v", err, errTokenRevoked)
+		}
+		if code := logout(); code != http.StatusUnauthorized {
+			t.Errorf("Logout() with a revoked token status = %d, want %d", code, http.StatusUnauthorized)
+		}
+	}
+
+	// Other sessions of the user stay logged in
+	if _, _, err := h.VerifyJWT(other, ""); err != nil {
+		t.Errorf("VerifyJWT() for another session error = %v, want nil", err)
+	}
+}
+
+func TestLogoutRejectsTokenWithoutJTI(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	user := createTestUser(t, db)
+
+	// Tokens issued before jti was added can't be revoked, but stay valid until they expire
+	token := signTestToken(t, cfg.JWTSecret, jwt.MapClaims{
+		"user_id": user.ID.String(),
+		"email":   user.Email,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/auth/logout", nil, user.ID)
+	c.Set("token", token)
+	h.Logout(c)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Logout() status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	if _, _, err := h.VerifyJWT(token, ""); err != nil {
+		t.Errorf("VerifyJWT() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLanguage is used when the client accepts none of the languages a message is available in
+const defaultLanguage = "en"
+
+// errorMessages holds each error code's message by language; every code must have an English message
+var errorMessages = map[string]map[string]string{
+	errorCodeNoPartner: {
+		"en": "You don't have a partner",
+		"es": "No tienes pareja",
+	},
+	errorCodeNotPartnered: {
+		"en": "You are not partnered with this user",
+		"es": "No eres pareja de este usuario",
+	},
+	errorCodeOTPTooMany: {
+		"en": "Too many OTP requests. Please try again later.",
+		"es": "Demasiadas solicitudes de código. Inténtalo de nuevo más tarde.",
+	},
+	errorCodeOTPTooSoon: {
+		"en": "An OTP was sent moments ago. Please wait before requesting another.",
+		"es": "Se acaba de enviar un código. Espera antes de solicitar otro.",
+	},
+}
+
+// acceptedLanguages returns the primary language subtags from an Accept-Language header, most preferred first
+// Languages with q=0 are dropped, as is the * wildcard (the default covers it)
+func acceptedLanguages(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if primary == "" || primary == "*" || quality <= 0 {
+			continue
+		}
+		langs = append(langs, weighted{lang: primary, quality: quality})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].quality > langs[j].quality
+	})
+
+	result := make([]string, len(langs))
+	for i, l := range langs {
+		result[i] = l.lang
+	}
+	return result
+}
+
+// localizedMessage returns an error code's message in the request's preferred language, and that language
+// Falls back to English when none of the accepted languages has a translation
+func localizedMessage(c *gin.Context, code string) (string, string) {
+	messages := errorMessages[code]
+	for _, lang := range acceptedLanguages(c.GetHeader("Accept-Language")) {
+		if message, ok := messages[lang]; ok {
+			return message, lang
+		}
+	}
+	return messages[defaultLanguage], defaultLanguage
+}
+
+// respondError writes a coded error with its message localized for the request
+func respondError(c *gin.Context, status int, code string) {
+	message, lang := localizedMessage(c, code)
+	c.Header("Content-Language", lang)
+	c.JSON(status, gin.H{"error": message, "code": code})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAcceptedLanguages(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "es", want: []string{"es"}},
+		{header: "es-MX, en;q=0.5", want: []string{"es", "en"}},
+		{header: "en;q=0.3, fr;q=0.9, es;q=0.6", want: []string{"fr", "es", "en"}},
+		{header: "es;q=0, *;q=0.5, en", want: []string{"en"}},
+		{header: "es;q=high, en", want: []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		if got := acceptedLanguages(tt.header); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("acceptedLanguages(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestErrorMessagesHaveEnglish(t *testing.T) {
+	for code, messages := range errorMessages {
+		if messages[defaultLanguage] == "" {
+			t.Errorf("error code %s has no English message", code)
+		}
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		code     string
+		wantLang string
+	}{
+		{name: "no header", header: "", code: errorCodeNoPartner, wantLang: "en"},
+		{name: "spanish", header: "es-ES,es;q=0.9", code: errorCodeNoPartner, wantLang: "es"},
+		{name: "spanish preferred over english", header: "en;q=0.4, es", code: errorCodeNotPartnered, wantLang: "es"},
+		{name: "untranslated language", header: "fr", code: errorCodeNotPartnered, wantLang: "en"},
+		{name: "falls through to the next language", header: "fr, es;q=0.8", code: errorCodeOTPTooMany, wantLang: "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodGet, "/", nil, uuid.Nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Accept-Language", tt.header)
+			}
+			respondError(c, http.StatusForbidden, tt.code)
+
+			if recorder.Code != http.StatusForbidden {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusForbidden)
+			}
+			var response struct {
+				Error string `json:"error"`
+				Code  string `json:"code"`
+			}
+			decodeResponse(t, recorder, &response)
+			if response.Code != tt.code || response.Error != errorMessages[tt.code][tt.wantLang] {
+				t.Errorf("response = %+v, want the %s message for %s", response, tt.wantLang, tt.code)
+			}
+			if lang := recorder.Header().Get("Content-Language"); lang != tt.wantLang {
+				t.Errorf("Content-Language = %q, want %q", lang, tt.wantLang)
+			}
+		})
+	}
+}
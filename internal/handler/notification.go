@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+)
+
+// NotificationHandler handles notification-related requests
+type NotificationHandler struct {
+	notificationRepo *database.NotificationRepository
+	pageLimits       pageLimits
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(cfg *config.Config) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: newNotificationRepository(cfg),
+		pageLimits:       newPageLimits(cfg),
+	}
+}
+
+// newNotificationRepository creates a notification repository using the configured dedup window
+func newNotificationRepository(cfg *config.Config) *database.NotificationRepository {
+	return database.NewNotificationRepository(database.DB, time.Duration(cfg.NotificationDedupWindowMinutes)*time.Minute)
+}
+
+// GetNotificationsResponse represents the response for getting notifications
+// UnreadCount covers all of the user's unread notifications, not just this page or filter
+type GetNotificationsResponse struct {
+	Notifications []database.Notification `json:"notifications"`
+	Total         int64                   `json:"total"`
+	UnreadCount   int64                   `json:"unread_count"`
+	Limit         int                     `json:"limit"`
+	Offset        int                     `json:"offset"`
+}
+
+// GetNotifications handles getting a page of the current user's notifications, newest first
+// Filter with type=<notification type> and read=true|false
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := database.NotificationFilter{
+		Type:       c.Query("type"),
+		Pagination: page,
+	}
+	if readStr := c.Query("read"); readStr != "" {
+		read, err := strconv.ParseBool(readStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "read must be true or false"})
+			return
+		}
+		filter.Read = &read
+	}
+
+	notifications, total, err := h.notificationRepo.FindByUser(userUUID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications: " + err.Error()})
+		return
+	}
+
+	unreadCount, err := h.notificationRepo.CountUnread(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, GetNotificationsResponse{
+		Notifications: notifications,
+		Total:         total,
+		UnreadCount:   unreadCount,
+		Limit:         page.Limit,
+		Offset:        page.Offset,
+	})
+}
+
+// MarkNotificationReadResponse represents the response for marking a notification as read
+type MarkNotificationReadResponse struct {
+	Message string `json:"message"`
+}
+
+// MarkNotificationRead handles marking a single notification as read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	notificationIDStr := c.Param("id")
+	notificationID, err := uuid.Parse(notificationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	notification, err := h.notificationRepo.FindByID(notificationID)
+	if err != nil || notification.UserID != userUUID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+
+	if err := h.notificationRepo.MarkAsRead(notification.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notification as read"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MarkNotificationReadResponse{
+		Message: "Notification marked as read",
+	})
+}
+
+// MarkAllNotificationsReadResponse represents the response for marking all notifications as read
+type MarkAllNotificationsReadResponse struct {
+	Count int64 `json:"count"`
+}
+
+// MarkAllNotificationsRead handles marking all of the current user's notifications as read
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	count, err := h.notificationRepo.MarkAllRead(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notifications as read"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MarkAllNotificationsReadResponse{
+		Count: count,
+	})
+}
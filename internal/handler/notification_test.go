@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestGetNotificationsFilters(t *testing.T) {
+	db := openTestDB(t)
+	h := NewNotificationHandler(newTestConfig())
+	user := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("user_id = ?", user.ID).Delete(&database.Notification{}) })
+
+	// Created oldest first, each a minute after the last
+	seed := []struct {
+		kind string
+		read bool
+	}{
+		{"game_request", false},
+		{"partner_request", true},
+		{"game_request", true},
+		{"game_request", false},
+		{"partner_request", false},
+		{"game_request", false},
+	}
+	ids := make([]string, len(seed))
+	start := time.Now().Add(-time.Hour)
+	for i, s := range seed {
+		notification := &database.Notification{UserID: user.ID, Type: s.kind, Message: s.kind}
+		if err := db.Create(notification).Error; err != nil {
+			t.Fatalf("failed to create notification: %v", err)
+		}
+		if err := db.Model(notification).Updates(map[string]interface{}{
+			"read":       s.read,
+			"updated_at": start.Add(time.Duration(i) * time.Minute),
+		}).Error; err != nil {
+			t.Fatalf("failed to update notification: %v", err)
+		}
+		ids[i] = notification.ID.String()
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantIDs   []string
+		wantTotal int64
+	}{
+		{name: "newest first", query: "?limit=2", wantIDs: []string{ids[5], ids[4]}, wantTotal: 6},
+		{name: "second page", query: "?limit=2&offset=2", wantIDs: []string{ids[3], ids[2]}, wantTotal: 6},
+		{name: "by type", query: "?type=partner_request", wantIDs: []string{ids[4], ids[1]}, wantTotal: 2},
+		{name: "unread", query: "?read=false&limit=3", wantIDs: []string{ids[5], ids[4], ids[3]}, wantTotal: 4},
+		{name: "read of a type", query: "?type=game_request&read=true", wantIDs: []string{ids[2]}, wantTotal: 1},
+		{name: "unread of a type, second page", query: "?type=game_request&read=false&limit=2&offset=2", wantIDs: []string{ids[0]}, wantTotal: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodGet, "/api/v1/notifications"+tt.query, nil, user.ID)
+			h.GetNotifications(c)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+			}
+
+			var response GetNotificationsResponse
+			decodeResponse(t, recorder, &response)
+			if response.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", response.Total, tt.wantTotal)
+			}
+			// The unread count covers every unread notification, whatever the page or filter
+			if response.UnreadCount != 4 {
+				t.Errorf("unread count = %d, want 4", response.UnreadCount)
+			}
+			if len(response.Notifications) != len(tt.wantIDs) {
+				t.Fatalf("got %d notifications, want %d", len(response.Notifications), len(tt.wantIDs))
+			}
+			for i, notification := range response.Notifications {
+				if notification.ID.String() != tt.wantIDs[i] {
+					t.Errorf("notification %d = %s (%s), want %s", i, notification.ID, notification.Message, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/notifications?read=maybe", nil, user.ID)
+	h.GetNotifications(c)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid read filter status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
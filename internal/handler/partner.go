@@ -1,26 +1,57 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/games-app/backend/internal/config"
 	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
+	"github.com/games-app/backend/internal/logging"
 )
 
 // PartnerHandler handles partner-related requests
 type PartnerHandler struct {
-	userRepo        *database.UserRepository
-	partnershipRepo *database.PartnershipRepository
+	config           *config.Config
+	authHandler      *AuthHandler
+	emailClient      email.EmailClient
+	userRepo         *database.UserRepository
+	partnershipRepo  *database.PartnershipRepository
+	playRepo         *database.PlayRepository
+	gameRepo         *database.GameRepository
+	gameRequestRepo  *database.GameRequestRepository
+	prefsRepo        *database.UserPreferencesRepository
+	notificationRepo *database.NotificationRepository
+	coinFlipRepo     *database.CoinFlipRepository
+	pageLimits       pageLimits
+	textPolicy       textPolicy
 }
 
 // NewPartnerHandler creates a new partner handler
-func NewPartnerHandler() *PartnerHandler {
+// The auth handler signs the accept links sent in partner request emails
+func NewPartnerHandler(cfg *config.Config, emailClient email.EmailClient, authHandler *AuthHandler) *PartnerHandler {
 	return &PartnerHandler{
-		userRepo:        database.NewUserRepository(database.DB),
-		partnershipRepo: database.NewPartnershipRepository(database.DB),
+		config:           cfg,
+		authHandler:      authHandler,
+		emailClient:      emailClient,
+		userRepo:         database.NewUserRepository(database.DB),
+		partnershipRepo:  database.NewPartnershipRepository(database.DB),
+		playRepo:         database.NewPlayRepository(database.DB),
+		gameRepo:         database.NewGameRepository(database.DB),
+		gameRequestRepo:  database.NewGameRequestRepository(database.DB),
+		prefsRepo:        database.NewUserPreferencesRepository(database.DB),
+		notificationRepo: newNotificationRepository(cfg),
+		coinFlipRepo:     database.NewCoinFlipRepository(database.DB),
+		pageLimits:       newPageLimits(cfg),
+		textPolicy:       newTextPolicy(cfg),
 	}
 }
 
@@ -55,37 +86,54 @@ func (h *PartnerHandler) SendPartnerRequest(c *gin.Context) {
 		return
 	}
 
+	request, err := h.createPartnerRequest(c, senderUUID, req.Email, nil)
+	if err != nil {
+		respondPartnerRequestRefusal(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SendPartnerRequestResponse{
+		Request: request,
+		Message: "Partner request sent successfully",
+	})
+}
+
+// createPartnerRequest validates and creates a partner request from the sender to the email,
+// then notifies and emails the recipient
+// gameID, if set, is the game requested between the two once the request is accepted
+// Returns a *partnerRequestRefusal if the request can't be sent, or the error if it failed
+func (h *PartnerHandler) createPartnerRequest(c *gin.Context, senderUUID uuid.UUID, recipientEmail string, gameID *uuid.UUID) (*database.PartnerRequest, error) {
 	// Check if user already has a partner
 	hasPartnership, err := h.partnershipRepo.UserHasPartnership(senderUUID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check partnership status"})
-		return
+		return nil, fmt.Errorf("checking partnership status: %w", err)
 	}
 	if hasPartnership {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You already have a partner"})
-		return
+		return nil, &partnerRequestRefusal{status: http.StatusBadRequest, message: "You already have a partner"}
 	}
 
 	// Check if user is trying to send request to themselves
 	sender, err := h.userRepo.FindByID(senderUUID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find user"})
-		return
+		return nil, fmt.Errorf("finding sender: %w", err)
 	}
-	if sender.Email == req.Email {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot send a request to yourself"})
-		return
+	if sender.Email == recipientEmail {
+		return nil, &partnerRequestRefusal{status: http.StatusBadRequest, message: "You cannot send a request to yourself"}
+	}
+
+	// The bot is played directly and never partners up
+	if strings.EqualFold(recipientEmail, database.BotUserEmail) {
+		return nil, &partnerRequestRefusal{status: http.StatusBadRequest, message: "You cannot send a partner request to the bot"}
 	}
 
 	// Check if request already exists
-	existingRequest, err := h.partnershipRepo.FindRequestBySenderAndEmail(senderUUID, req.Email)
+	existingRequest, err := h.partnershipRepo.FindRequestBySenderAndEmail(senderUUID, recipientEmail)
 	if err == nil && existingRequest.Status == "pending" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Request already sent to this email"})
-		return
+		return nil, &partnerRequestRefusal{status: http.StatusBadRequest, message: "Request already sent to this email"}
 	}
 
 	// Find recipient by email (if they exist)
-	recipient, err := h.userRepo.FindByEmail(req.Email)
+	recipient, err := h.userRepo.FindByEmail(recipientEmail)
 	var recipientID *uuid.UUID
 	if err == nil {
 		recipientID = &recipient.ID
@@ -94,27 +142,44 @@ func (h *PartnerHandler) SendPartnerRequest(c *gin.Context) {
 	// Create partner request
 	request := &database.PartnerRequest{
 		SenderID:       senderUUID,
-		RecipientEmail: req.Email,
+		RecipientEmail: recipientEmail,
 		RecipientID:    recipientID,
 		Status:         "pending",
+		GameID:         gameID,
 	}
 
 	if err := h.partnershipRepo.CreateRequest(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create request: " + err.Error()})
-		return
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// Notify the recipient if they already have an account
+	if recipientID != nil {
+		notification := &database.Notification{
+			UserID:  *recipientID,
+			Type:    "partner_request",
+			ActorID: &senderUUID,
+			Message: sender.Email + " wants to be your partner",
+		}
+		if err := h.notificationRepo.Create(notification); err != nil {
+			logging.FromContext(c).Error("failed to create notification", "error", err)
+		}
+	}
+
+	// Email the recipient a one-tap accept link unless they've opted out (don't fail the request if it can't be sent)
+	// Invitees without an account have no preferences yet, so they always get it
+	if recipientID != nil && emailOptedOut(c, h.prefsRepo, *recipientID, database.EmailCategoryPartnerRequests) {
+		logging.FromContext(c).Info("partner request email suppressed by recipient preference", "partner_request_id", request.ID)
+	} else if err := h.sendPartnerRequestEmail(sender, request); err != nil {
+		logging.FromContext(c).Error("failed to send partner request email", "error", err)
 	}
 
 	// Load relations
 	request, err = h.partnershipRepo.FindRequestByID(request.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load request"})
-		return
+		return nil, fmt.Errorf("loading request: %w", err)
 	}
 
-	c.JSON(http.StatusOK, SendPartnerRequestResponse{
-		Request: request,
-		Message: "Partner request sent successfully",
-	})
+	return request, nil
 }
 
 // GetSentRequestsResponse represents the response for getting sent requests
@@ -226,92 +291,292 @@ func (h *PartnerHandler) AcceptPartnerRequest(c *gin.Context) {
 		return
 	}
 
-	// Verify the request is for this user (by ID or email)
-	isRecipient := (request.RecipientID != nil && *request.RecipientID == userUUID) ||
-		request.RecipientEmail == user.Email
-	if !isRecipient {
-		c.JSON(http.StatusForbidden, gin.H{"error": "This request is not for you"})
+	partnership, ok := h.acceptRequest(c, user, request, nil)
+	if !ok {
 		return
 	}
 
-	// Check if request is still pending
-	if request.Status != "pending" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Request is no longer pending"})
+	c.JSON(http.StatusOK, AcceptPartnerRequestResponse{
+		Partnership: partnership,
+		Message:     "Partner request accepted successfully",
+	})
+}
+
+// sendPartnerRequestEmail emails the recipient of a partner request a single-use accept link
+func (h *PartnerHandler) sendPartnerRequestEmail(sender *database.User, request *database.PartnerRequest) error {
+	tokenID := uuid.New()
+	if err := h.partnershipRepo.SetAcceptToken(request.ID, tokenID); err != nil {
+		return err
+	}
+
+	token, err := h.authHandler.generatePartnerAcceptToken(request.ID, request.RecipientEmail, tokenID)
+	if err != nil {
+		return err
+	}
+
+	acceptURL := h.config.PublicURL + h.config.APIBaseURL + "/partners/accept-link?token=" + url.QueryEscape(token)
+	return h.emailClient.SendTemplated(request.RecipientEmail, email.TemplatePartner, map[string]interface{}{
+		"SenderName":  userDisplayName(*sender),
+		"SenderEmail": sender.Email,
+		"AcceptURL":   acceptURL,
+		"ExpiryHours": int(partnerAcceptTokenExpiry.Hours()),
+	})
+}
+
+// AcceptPartnerLinkResponse represents the response for accepting a partner request via an email link
+type AcceptPartnerLinkResponse struct {
+	Partnership *database.Partnership `json:"partnership"`
+	Message     string                `json:"message"`
+	Token       string                `json:"token"`
+	User        *database.User        `json:"user"`
+}
+
+// AcceptPartnerLink handles accepting a partner request from the link in the request email
+// The link token identifies and authenticates the recipient, so no login is required
+func (h *PartnerHandler) AcceptPartnerLink(c *gin.Context) {
+	tokenString := c.Query("token")
+	if tokenString == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token is required"})
 		return
 	}
 
-	// Check if user already has a partner
-	hasPartnership, err := h.partnershipRepo.UserHasPartnership(userUUID)
+	claims, err := h.authHandler.verifyPartnerAcceptToken(tokenString)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check partnership status"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired link"})
 		return
 	}
-	if hasPartnership {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "You already have a partner"})
+
+	// The token is only valid for the request and recipient it was issued for
+	request, err := h.partnershipRepo.FindRequestByID(claims.RequestID)
+	if err != nil || !strings.EqualFold(request.RecipientEmail, claims.RecipientEmail) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired link"})
+		return
+	}
+	// Turn away used links before touching the user; the accept transaction makes the final check
+	if request.AcceptTokenID == nil || *request.AcceptTokenID != claims.TokenID {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "This link has already been used"})
 		return
 	}
 
-	// Check if sender already has a partner
-	hasPartnership, err = h.partnershipRepo.UserHasPartnership(request.SenderID)
+	// Following the emailed link proves ownership of the address, as an OTP would
+	user, err := h.userRepo.FindByEmail(request.RecipientEmail)
+	if err != nil {
+		user = &database.User{
+			Email: request.RecipientEmail,
+			Name:  extractNameFromEmail(request.RecipientEmail),
+		}
+	}
+	user.EmailVerified = true
+	user, err = h.userRepo.CreateOrUpdate(user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check sender partnership status"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load user: " + err.Error()})
 		return
 	}
-	if hasPartnership {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Sender already has a partner"})
+
+	// The token is consumed in the accept transaction, so the same link can't be used twice
+	partnership, ok := h.acceptRequest(c, user, request, &claims.TokenID)
+	if !ok {
 		return
 	}
 
-	// Create partnership (ensure consistent ordering: smaller UUID first)
-	user1ID := request.SenderID
-	user2ID := userUUID
-	if userUUID.String() < request.SenderID.String() {
-		user1ID = userUUID
-		user2ID = request.SenderID
+	token, err := h.authHandler.generateJWT(user.ID, user.Email, DeviceFingerprint(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
+		return
 	}
 
-	partnership := &database.Partnership{
-		User1ID: user1ID,
-		User2ID: user2ID,
+	c.JSON(http.StatusOK, AcceptPartnerLinkResponse{
+		Partnership: partnership,
+		Message:     "Partner request accepted successfully",
+		Token:       token,
+		User:        user,
+	})
+}
+
+// acceptRequest accepts a pending partner request on behalf of user and returns the new partnership
+// When accepting from an emailed link, linkTokenID is the link's token, consumed along with the accept
+// Writes the error response and returns false if the request can't be accepted
+func (h *PartnerHandler) acceptRequest(c *gin.Context, user *database.User, request *database.PartnerRequest, linkTokenID *uuid.UUID) (*database.Partnership, bool) {
+	// Create the partnership, mark the request accepted and cancel every other pending request of both
+	// users together, so a failed cleanup can't leave stale requests behind a committed partnership
+	err := h.partnershipRepo.Transaction(func(repo *database.PartnershipRepository) error {
+		if linkTokenID != nil {
+			consumed, err := repo.ConsumeAcceptToken(request.ID, *linkTokenID)
+			if err != nil {
+				return err
+			}
+			if !consumed {
+				return &partnerRequestRefusal{status: http.StatusUnauthorized, message: "This link has already been used"}
+			}
+		}
+		if err := acceptPartnerRequest(repo, user, request); err != nil {
+			return err
+		}
+		if err := repo.CancelPendingRequestsByUser(user.ID); err != nil {
+			return err
+		}
+		return repo.CancelPendingRequestsByUser(request.SenderID)
+	})
+	if err != nil {
+		var refusal *partnerRequestRefusal
+		if errors.As(err, &refusal) {
+			c.JSON(refusal.status, gin.H{"error": refusal.message})
+			return nil, false
+		}
+		// A concurrent accept created a partnership for one of the users first
+		if database.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Partnership already exists"})
+			return nil, false
+		}
+		logging.FromContext(c).Error("failed to accept partner request",
+			"partner_request_id", request.ID, "sender_id", request.SenderID, "recipient_id", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept request: " + err.Error()})
+		return nil, false
+	}
+
+	// An invite sent from PlayGame goes on to request its game (failures shouldn't undo the accept)
+	if request.GameID != nil {
+		h.requestInvitedGame(c, request, user.ID)
+	}
+
+	// Load partnership with relations
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load partnership"})
+		return nil, false
 	}
 
-	if err := h.partnershipRepo.CreatePartnership(partnership); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create partnership: " + err.Error()})
+	return partnership, true
+}
+
+// requestInvitedGame creates the game request an accepted partner invite was sent for,
+// from the inviter to the new partner, and notifies the partner
+func (h *PartnerHandler) requestInvitedGame(c *gin.Context, request *database.PartnerRequest, partnerID uuid.UUID) {
+	game, err := h.gameRepo.FindByID(*request.GameID)
+	if err != nil {
+		logging.FromContext(c).Error("failed to load invited game", "partner_request_id", request.ID, "game_id", *request.GameID, "error", err)
 		return
 	}
 
-	// Update request status and set recipient_id if it wasn't set before
-	request.Status = "accepted"
-	if request.RecipientID == nil {
-		request.RecipientID = &userUUID
+	// Valid for 24 hours, as when requested from PlayGame
+	gameRequest := &database.GameRequest{
+		GameID:      game.ID,
+		RequesterID: request.SenderID,
+		PartnerID:   partnerID,
+		Status:      "pending",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
 	}
-	request.UpdatedAt = time.Now()
-	if err := h.partnershipRepo.UpdateRequest(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update request status"})
+	if err := h.gameRequestRepo.CreateRequest(gameRequest); err != nil {
+		logging.FromContext(c).Error("failed to request invited game", "partner_request_id", request.ID, "game_id", game.ID, "error", err)
 		return
 	}
 
-	// Cancel all other pending requests for both users
-	if err := h.partnershipRepo.CancelPendingRequestsByUser(userUUID); err != nil {
-		// Log error but don't fail the request
-		_ = err
+	requesterID := request.SenderID
+	notification := &database.Notification{
+		UserID:  partnerID,
+		Type:    "game_request",
+		ActorID: &requesterID,
+		GameID:  &game.ID,
+		Message: "Your partner wants to play " + game.Name,
 	}
-	if err := h.partnershipRepo.CancelPendingRequestsByUser(request.SenderID); err != nil {
-		// Log error but don't fail the request
-		_ = err
+	if err := h.notificationRepo.Create(notification); err != nil {
+		logging.FromContext(c).Error("failed to create notification", "error", err)
 	}
+}
 
-	// Load partnership with relations
-	partnership, err = h.partnershipRepo.FindPartnershipByUser(userUUID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load partnership"})
+// partnerRequestRefusal is why a user can't respond to a partner request, reported to the client
+type partnerRequestRefusal struct {
+	status  int
+	message string
+}
+
+func (r *partnerRequestRefusal) Error() string {
+	return r.message
+}
+
+// respondPartnerRequestRefusal writes the response for an error returned by createPartnerRequest
+func respondPartnerRequestRefusal(c *gin.Context, err error) {
+	var refusal *partnerRequestRefusal
+	if errors.As(err, &refusal) {
+		c.JSON(refusal.status, gin.H{"error": refusal.message})
 		return
 	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send request: " + err.Error()})
+}
 
-	c.JSON(http.StatusOK, AcceptPartnerRequestResponse{
-		Partnership: partnership,
-		Message:     "Partner request accepted successfully",
-	})
+// checkPartnerRequestRecipient checks that the request is pending and addressed to the user (by ID or email)
+func checkPartnerRequestRecipient(user *database.User, request *database.PartnerRequest) error {
+	isRecipient := (request.RecipientID != nil && *request.RecipientID == user.ID) ||
+		request.RecipientEmail == user.Email
+	if !isRecipient {
+		return &partnerRequestRefusal{status: http.StatusForbidden, message: "This request is not for you"}
+	}
+	if request.Status != "pending" {
+		return &partnerRequestRefusal{status: http.StatusBadRequest, message: "Request is no longer pending"}
+	}
+	return nil
+}
+
+// acceptPartnerRequest creates the partnership of a pending request addressed to the user and marks it accepted
+// Runs within the caller's transaction, which is responsible for cancelling the partners' other pending requests
+// Returns a *partnerRequestRefusal if the request can't be accepted
+func acceptPartnerRequest(repo *database.PartnershipRepository, user *database.User, request *database.PartnerRequest) error {
+	if err := checkPartnerRequestRecipient(user, request); err != nil {
+		return err
+	}
+
+	// Partnerships created earlier in the transaction are visible here
+	hasPartnership, err := repo.UserHasPartnership(user.ID)
+	if err != nil {
+		return err
+	}
+	if hasPartnership {
+		return &partnerRequestRefusal{status: http.StatusBadRequest, message: "You already have a partner"}
+	}
+
+	hasPartnership, err = repo.UserHasPartnership(request.SenderID)
+	if err != nil {
+		return err
+	}
+	if hasPartnership {
+		return &partnerRequestRefusal{status: http.StatusBadRequest, message: "Sender already has a partner"}
+	}
+
+	// Create partnership (ensure consistent ordering: smaller UUID first)
+	user1ID := request.SenderID
+	user2ID := user.ID
+	if user.ID.String() < request.SenderID.String() {
+		user1ID = user.ID
+		user2ID = request.SenderID
+	}
+
+	if err := repo.CreatePartnership(&database.Partnership{
+		User1ID: user1ID,
+		User2ID: user2ID,
+	}); err != nil {
+		return err
+	}
+
+	// Update request status and set recipient_id if it wasn't set before
+	request.Status = "accepted"
+	if request.RecipientID == nil {
+		request.RecipientID = &user.ID
+	}
+	request.AcceptTokenID = nil
+	request.UpdatedAt = time.Now()
+	return repo.UpdateRequest(request)
+}
+
+// rejectPartnerRequest marks a pending request addressed to the user rejected
+// Returns a *partnerRequestRefusal if the request can't be rejected
+func rejectPartnerRequest(repo *database.PartnershipRepository, user *database.User, request *database.PartnerRequest) error {
+	if err := checkPartnerRequestRecipient(user, request); err != nil {
+		return err
+	}
+
+	request.Status = "rejected"
+	request.UpdatedAt = time.Now()
+	return repo.UpdateRequest(request)
 }
 
 // RejectPartnerRequestResponse represents the response for rejecting a partner request
@@ -354,18 +619,12 @@ func (h *PartnerHandler) RejectPartnerRequest(c *gin.Context) {
 		return
 	}
 
-	// Verify the request is for this user (by ID or email)
-	isRecipient := (request.RecipientID != nil && *request.RecipientID == userUUID) ||
-		request.RecipientEmail == user.Email
-	if !isRecipient {
-		c.JSON(http.StatusForbidden, gin.H{"error": "This request is not for you"})
-		return
-	}
-
-	// Update request status
-	request.Status = "rejected"
-	request.UpdatedAt = time.Now()
-	if err := h.partnershipRepo.UpdateRequest(request); err != nil {
+	if err := rejectPartnerRequest(h.partnershipRepo, user, request); err != nil {
+		var refusal *partnerRequestRefusal
+		if errors.As(err, &refusal) {
+			c.JSON(refusal.status, gin.H{"error": refusal.message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update request status"})
 		return
 	}
@@ -375,6 +634,159 @@ func (h *PartnerHandler) RejectPartnerRequest(c *gin.Context) {
 	})
 }
 
+// Batch request actions
+const (
+	batchActionAccept = "accept"
+	batchActionReject = "reject"
+)
+
+// maxBatchRequests is the largest number of requests handled in one batch
+const maxBatchRequests = 50
+
+// BatchRequestItem is a single action in a batch of partner request responses
+type BatchRequestItem struct {
+	ID     string `json:"id" binding:"required"`
+	Action string `json:"action" binding:"required,oneof=accept reject"`
+}
+
+// BatchRespondRequest represents the request body for responding to several partner requests
+type BatchRespondRequest struct {
+	Requests []BatchRequestItem `json:"requests" binding:"required,min=1,dive"`
+}
+
+// BatchRequestResult is the outcome of a single batch item
+type BatchRequestResult struct {
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchRespondResponse represents the response for responding to several partner requests
+type BatchRespondResponse struct {
+	Results     []BatchRequestResult  `json:"results"`
+	Partnership *database.Partnership `json:"partnership,omitempty"` // Set if an accept succeeded
+}
+
+// BatchRespondToRequests handles accepting or rejecting several received partner requests at once
+// Items are processed in order in one transaction; since a user can only have one partner,
+// at most one accept succeeds and later accepts fail
+func (h *PartnerHandler) BatchRespondToRequests(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req BatchRespondRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	if len(req.Requests) > maxBatchRequests {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d requests can be processed at once", maxBatchRequests)})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+
+	var results []BatchRequestResult
+	var acceptedSenderID *uuid.UUID
+	err = h.partnershipRepo.Transaction(func(repo *database.PartnershipRepository) error {
+		results = make([]BatchRequestResult, 0, len(req.Requests))
+		acceptedSenderID = nil
+
+		for _, item := range req.Requests {
+			result := BatchRequestResult{ID: item.ID, Action: item.Action}
+
+			message, err := h.applyBatchItem(repo, user, item, &acceptedSenderID)
+			if err != nil {
+				return err
+			}
+			result.Success = message == ""
+			result.Error = message
+			results = append(results, result)
+		}
+
+		// Same cleanup as a single accept: drop every other pending request of both partners
+		if acceptedSenderID != nil {
+			if err := repo.CancelPendingRequestsByUser(user.ID); err != nil {
+				return err
+			}
+			if err := repo.CancelPendingRequestsByUser(*acceptedSenderID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// A concurrent accept created a partnership for one of the users first
+		if database.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Partnership already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process requests: " + err.Error()})
+		return
+	}
+
+	response := BatchRespondResponse{
+		Results: results,
+	}
+	if acceptedSenderID != nil {
+		partnership, err := h.partnershipRepo.FindPartnershipByUser(user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load partnership"})
+			return
+		}
+		response.Partnership = partnership
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// applyBatchItem applies one batch action within the batch transaction
+// Returns a non-empty message if the item was refused, or an error if the transaction must be aborted
+func (h *PartnerHandler) applyBatchItem(repo *database.PartnershipRepository, user *database.User, item BatchRequestItem, acceptedSenderID **uuid.UUID) (string, error) {
+	requestID, err := uuid.Parse(item.ID)
+	if err != nil {
+		return "Invalid request ID", nil
+	}
+
+	request, err := repo.FindRequestByID(requestID)
+	if err != nil {
+		return "Request not found", nil
+	}
+
+	if item.Action == batchActionReject {
+		err = rejectPartnerRequest(repo, user, request)
+	} else {
+		err = acceptPartnerRequest(repo, user, request)
+	}
+	var refusal *partnerRequestRefusal
+	if errors.As(err, &refusal) {
+		return refusal.message, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if item.Action == batchActionAccept {
+		senderID := request.SenderID
+		*acceptedSenderID = &senderID
+	}
+	return "", nil
+}
+
 // CancelPartnerRequestResponse represents the response for cancelling a partner request
 type CancelPartnerRequestResponse struct {
 	Message string `json:"message"`
@@ -427,13 +839,17 @@ func (h *PartnerHandler) CancelPartnerRequest(c *gin.Context) {
 	})
 }
 
-// GetCurrentPartnerResponse represents the response for getting current partner
-type GetCurrentPartnerResponse struct {
-	Partnership *database.Partnership `json:"partnership"`
+// partnerRequestResendCooldown is the minimum time between emails for the same partner request
+const partnerRequestResendCooldown = 10 * time.Minute
+
+// ResendPartnerRequestResponse represents the response for resending a partner request email
+type ResendPartnerRequestResponse struct {
+	Message string `json:"message"`
 }
 
-// GetCurrentPartner handles getting the current partner
-func (h *PartnerHandler) GetCurrentPartner(c *gin.Context) {
+// ResendPartnerRequest handles re-sending the email for a pending partner request the user sent
+// A new accept link is issued, so links from earlier emails stop working
+func (h *PartnerHandler) ResendPartnerRequest(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -446,50 +862,700 @@ func (h *PartnerHandler) GetCurrentPartner(c *gin.Context) {
 		return
 	}
 
-	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	requestIDStr := c.Param("id")
+	requestID, err := uuid.Parse(requestIDStr)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No partner found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, GetCurrentPartnerResponse{
-		Partnership: partnership,
-	})
-}
-
-// DisconnectPartnerResponse represents the response for disconnecting from partner
-type DisconnectPartnerResponse struct {
-	Message string `json:"message"`
-}
+	// Find the request
+	request, err := h.partnershipRepo.FindRequestByID(requestID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Request not found"})
+		return
+	}
 
-// DisconnectPartner handles disconnecting from a partner
-func (h *PartnerHandler) DisconnectPartner(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	// Verify the request was sent by this user
+	if request.SenderID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only resend your own requests"})
 		return
 	}
 
-	userUUID, ok := userID.(uuid.UUID)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+	if request.Status != "pending" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only pending requests can be resent"})
 		return
 	}
 
-	// Find partnership
-	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No partnership found"})
+	if request.RecipientID != nil && emailOptedOut(c, h.prefsRepo, *request.RecipientID, database.EmailCategoryPartnerRequests) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The recipient has turned off partner request emails"})
 		return
 	}
 
-	// Delete partnership
-	if err := h.partnershipRepo.DeletePartnership(partnership.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disconnect: " + err.Error()})
+	lastSent := request.CreatedAt
+	if request.EmailedAt != nil {
+		lastSent = *request.EmailedAt
+	}
+	if wait := partnerRequestResendCooldown - time.Since(lastSent); wait > 0 {
+		respondTooManyRequests(c, "This request was emailed recently. Please try again later.", wait)
 		return
 	}
 
+	// Claim the resend atomically so concurrent resends can't both send
+	claimed, err := h.partnershipRepo.ClaimRequestResend(request.ID, partnerRequestResendCooldown)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update request"})
+		return
+	}
+	if !claimed {
+		respondTooManyRequests(c, "This request was emailed recently. Please try again later.", partnerRequestResendCooldown)
+		return
+	}
+
+	if err := h.sendPartnerRequestEmail(&request.Sender, request); err != nil {
+		logging.FromContext(c).Error("failed to resend partner request email", "partner_request_id", request.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ResendPartnerRequestResponse{
+		Message: "Partner request email resent",
+	})
+}
+
+// GetCurrentPartnerResponse represents the response for getting current partner
+type GetCurrentPartnerResponse struct {
+	Partnership *database.Partnership `json:"partnership"`
+}
+
+// GetCurrentPartner handles getting the current partner
+func (h *PartnerHandler) GetCurrentPartner(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partner found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, GetCurrentPartnerResponse{
+		Partnership: partnership,
+	})
+}
+
+// ActiveGame is a live play with the current partner, annotated for a dashboard
+type ActiveGame struct {
+	Play           database.Play `json:"play"`
+	CurrentTurn    *uuid.UUID    `json:"current_turn"` // Nil when no one is due to move (e.g. secrets not set, shared mode)
+	IsMyTurn       bool          `json:"is_my_turn"`
+	LastActivityAt time.Time     `json:"last_activity_at"`
+}
+
+// GetActiveGamesResponse represents the response for getting the live plays with the current partner
+type GetActiveGamesResponse struct {
+	Games []ActiveGame `json:"games"`
+}
+
+// GetActiveGames handles listing every live play with the current partner across games, most recently active first
+func (h *PartnerHandler) GetActiveGames(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partner found"})
+		return
+	}
+
+	plays, err := h.playRepo.FindLivePlaysByPartners(partnership.User1ID, partnership.User2ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+		return
+	}
+
+	games := make([]ActiveGame, 0, len(plays))
+	for i := range plays {
+		// Never expose the partner's secret for unfinished plays
+		hideOpponentSecret(&plays[i], userUUID)
+
+		currentTurn := plays[i].CurrentTurnUserID
+		games = append(games, ActiveGame{
+			Play:           plays[i],
+			CurrentTurn:    currentTurn,
+			IsMyTurn:       currentTurn != nil && *currentTurn == userUUID,
+			LastActivityAt: plays[i].UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, GetActiveGamesResponse{
+		Games: games,
+	})
+}
+
+// PartnerProfile represents the publicly visible profile of a partner
+type PartnerProfile struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"display_name"`
+}
+
+// GetPartnershipResponse represents the response for getting a partnership by ID
+type GetPartnershipResponse struct {
+	Partnership *database.Partnership `json:"partnership"`
+	Partner     PartnerProfile        `json:"partner"`
+}
+
+// GetPartnership handles getting a partnership by ID for one of its members
+func (h *PartnerHandler) GetPartnership(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnershipIDStr := c.Param("id")
+	partnershipID, err := uuid.Parse(partnershipIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	// Non-members get the same 404 as a missing partnership so IDs can't be probed
+	partnership, err := h.partnershipRepo.FindPartnershipByID(partnershipID)
+	if err != nil || (partnership.User1ID != userUUID && partnership.User2ID != userUUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		return
+	}
+
+	partner := partnership.User1
+	if partnership.User1ID == userUUID {
+		partner = partnership.User2
+	}
+
+	c.JSON(http.StatusOK, GetPartnershipResponse{
+		Partnership: partnership,
+		Partner: PartnerProfile{
+			ID:          partner.ID,
+			Name:        partner.Name,
+			DisplayName: partner.DisplayName,
+		},
+	})
+}
+
+// Partnership timeline outcomes
+const (
+	timelineOutcomeInProgress = "in_progress" // Still live
+	timelineOutcomeWon        = "won"         // Completed with a winner
+	timelineOutcomeEnded      = "ended"       // Ended without a winner, e.g. replaced by a new play
+)
+
+// TimelineEntry is a single play in a partnership's timeline
+type TimelineEntry struct {
+	PlayID          uuid.UUID       `json:"play_id"`
+	GameID          uuid.UUID       `json:"game_id"`
+	GameName        string          `json:"game_name"`
+	Outcome         string          `json:"outcome"`          // in_progress, won or ended
+	Winner          *PartnerProfile `json:"winner,omitempty"` // Set when outcome is won
+	StartedAt       time.Time       `json:"started_at"`
+	EndedAt         *time.Time      `json:"ended_at,omitempty"`
+	DurationSeconds *int64          `json:"duration_seconds,omitempty"` // Excludes time spent paused
+}
+
+// GetPartnershipTimelineResponse represents the response for getting a partnership's timeline
+type GetPartnershipTimelineResponse struct {
+	Entries []TimelineEntry `json:"entries"`
+	Total   int64           `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// GetPartnershipTimeline handles listing a partnership's plays in chronological order with their outcomes
+// Only plays started since the partnership was formed are included
+func (h *PartnerHandler) GetPartnershipTimeline(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnershipIDStr := c.Param("id")
+	partnershipID, err := uuid.Parse(partnershipIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Non-members get the same 404 as a missing partnership so IDs can't be probed
+	partnership, err := h.partnershipRepo.FindPartnershipByID(partnershipID)
+	if err != nil || (partnership.User1ID != userUUID && partnership.User2ID != userUUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		return
+	}
+
+	plays, total, err := h.playRepo.FindPlaysByPartners(partnership.User1ID, partnership.User2ID, database.PlayFilter{
+		From:       &partnership.CreatedAt,
+		Pagination: page,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+		return
+	}
+
+	profiles := map[string]PartnerProfile{
+		partnership.User1ID.String(): {
+			ID:          partnership.User1.ID,
+			Name:        partnership.User1.Name,
+			DisplayName: partnership.User1.DisplayName,
+		},
+		partnership.User2ID.String(): {
+			ID:          partnership.User2.ID,
+			Name:        partnership.User2.Name,
+			DisplayName: partnership.User2.DisplayName,
+		},
+	}
+
+	entries := make([]TimelineEntry, 0, len(plays))
+	for i := range plays {
+		entries = append(entries, buildTimelineEntry(&plays[i], profiles))
+	}
+
+	c.JSON(http.StatusOK, GetPartnershipTimelineResponse{
+		Entries: entries,
+		Total:   total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// buildTimelineEntry summarizes a play's outcome, resolving the winner against the partnership's members
+func buildTimelineEntry(play *database.Play, profiles map[string]PartnerProfile) TimelineEntry {
+	entry := TimelineEntry{
+		PlayID:    play.ID,
+		GameID:    play.GameID,
+		GameName:  play.Game.Name,
+		Outcome:   timelineOutcomeInProgress,
+		StartedAt: play.CreatedAt,
+	}
+	if play.IsLive {
+		return entry
+	}
+
+	// Plays without a winning guess end when they were last updated
+	endedAt := play.UpdatedAt
+	entry.Outcome = timelineOutcomeEnded
+	if winnerID, ok := play.PlayData["winner_id"].(string); ok {
+		if winner, found := profiles[winnerID]; found {
+			entry.Outcome = timelineOutcomeWon
+			entry.Winner = &winner
+		}
+		if winnerUUID, err := uuid.Parse(winnerID); err == nil {
+			if wonAt, found := lastGuessTime(play.PlayData, winnerUUID); found {
+				endedAt = wonAt
+			}
+		}
+	}
+
+	duration := int64(endedAt.Sub(play.CreatedAt).Seconds()) - int64(pausedSeconds(play.PlayData))
+	if duration < 0 {
+		duration = 0
+	}
+	entry.EndedAt = &endedAt
+	entry.DurationSeconds = &duration
+	return entry
+}
+
+// DisconnectPartnerResponse represents the response for disconnecting from partner
+type DisconnectPartnerResponse struct {
+	Message string `json:"message"`
+}
+
+// DisconnectPartner handles disconnecting from a partner
+func (h *PartnerHandler) DisconnectPartner(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	// Find partnership
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partnership found"})
+		return
+	}
+
+	// Delete partnership
+	if err := h.partnershipRepo.DeletePartnership(partnership.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disconnect: " + err.Error()})
+		return
+	}
+
+	// Neither partner should keep pointing at the removed partnership
+	if err := h.userRepo.ClearDefaultPartnership(partnership.ID); err != nil {
+		logging.FromContext(c).Error("failed to clear default partnership", "error", err)
+	}
+
 	c.JSON(http.StatusOK, DisconnectPartnerResponse{
 		Message: "Disconnected from partner successfully",
 	})
 }
+
+// SetDefaultPartnerResponse represents the response for setting the default partner
+type SetDefaultPartnerResponse struct {
+	Partnership *database.Partnership `json:"partnership"`
+	Message     string                `json:"message"`
+}
+
+// SetDefaultPartner handles making a partnership the caller's default for game actions
+// Only one default is kept per user, so this replaces any previous default
+func (h *PartnerHandler) SetDefaultPartner(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnershipIDStr := c.Param("id")
+	partnershipID, err := uuid.Parse(partnershipIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByID(partnershipID)
+	if err != nil || (partnership.User1ID != userUUID && partnership.User2ID != userUUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		return
+	}
+
+	if err := h.userRepo.SetDefaultPartnership(userUUID, &partnership.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set default partner: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SetDefaultPartnerResponse{
+		Partnership: partnership,
+		Message:     "Default partner updated",
+	})
+}
+
+// Limits applied to shared partnership metadata
+const (
+	maxMetadataKeys        = 20
+	maxMetadataKeyLength   = 50
+	maxMetadataValueLength = 500
+	maxMetadataBytes       = 4096
+)
+
+// PartnershipMetadataResponse represents the response for partnership metadata
+type PartnershipMetadataResponse struct {
+	Metadata database.JSONB `json:"metadata"`
+	Version  int            `json:"version"`
+}
+
+// GetPartnershipMetadata handles getting the current partnership's shared metadata
+func (h *PartnerHandler) GetPartnershipMetadata(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partnership found"})
+		return
+	}
+
+	metadata := partnership.Metadata
+	if metadata == nil {
+		metadata = database.JSONB{}
+	}
+
+	c.JSON(http.StatusOK, PartnershipMetadataResponse{
+		Metadata: metadata,
+		Version:  partnership.MetadataVersion,
+	})
+}
+
+// UpdatePartnershipMetadataRequest represents the request body for updating partnership metadata
+// Keys in Metadata are merged into the existing metadata; a null value removes the key
+type UpdatePartnershipMetadataRequest struct {
+	Metadata database.JSONB `json:"metadata" binding:"required"`
+	Version  *int           `json:"version" binding:"required"`
+}
+
+// UpdatePartnershipMetadata handles updating the current partnership's shared metadata
+func (h *PartnerHandler) UpdatePartnershipMetadata(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req UpdatePartnershipMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No partnership found"})
+		return
+	}
+
+	// Reject stale writes so one partner can't silently clobber the other's changes
+	if *req.Version != partnership.MetadataVersion {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Metadata was updated by your partner, please reload and try again",
+			"version": partnership.MetadataVersion,
+		})
+		return
+	}
+
+	// Opt-in keys belong to one partner each, so they can't be set on the other's behalf
+	for key := range req.Metadata {
+		if strings.HasPrefix(strings.TrimSpace(key), autoAcceptMetadataKeyPrefix) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("metadata key %q is reserved", key)})
+			return
+		}
+	}
+
+	// Merge the update into the existing metadata
+	merged := database.JSONB{}
+	for key, value := range partnership.Metadata {
+		merged[key] = value
+	}
+	for key, value := range req.Metadata {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	merged, err = sanitizeMetadata(merged, h.textPolicy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.partnershipRepo.UpdateMetadata(partnership.ID, merged, partnership.MetadataVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update metadata: " + err.Error()})
+		return
+	}
+	if !updated {
+		c.JSON(http.StatusConflict, gin.H{"error": "Metadata was updated by your partner, please reload and try again"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PartnershipMetadataResponse{
+		Metadata: merged,
+		Version:  partnership.MetadataVersion + 1,
+	})
+}
+
+// autoAcceptMetadataKeyPrefix prefixes the metadata keys holding each partner's game request auto-accept opt-in
+const autoAcceptMetadataKeyPrefix = "auto_accept_game_requests"
+
+// autoAcceptMetadataKey returns the metadata key holding the given member's auto-accept opt-in
+func autoAcceptMetadataKey(partnership *database.Partnership, userID uuid.UUID) string {
+	if partnership.User1ID == userID {
+		return autoAcceptMetadataKeyPrefix + "_user1"
+	}
+	return autoAcceptMetadataKeyPrefix + "_user2"
+}
+
+// autoAcceptEnabled reports whether both partners opted in to auto-accepting each other's game requests
+func autoAcceptEnabled(partnership *database.Partnership) bool {
+	user1OptIn, _ := partnership.Metadata[autoAcceptMetadataKey(partnership, partnership.User1ID)].(bool)
+	user2OptIn, _ := partnership.Metadata[autoAcceptMetadataKey(partnership, partnership.User2ID)].(bool)
+	return user1OptIn && user2OptIn
+}
+
+// SetAutoAcceptRequest represents the request body for setting the caller's auto-accept opt-in
+type SetAutoAcceptRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// SetAutoAcceptResponse represents the response for setting the caller's auto-accept opt-in
+type SetAutoAcceptResponse struct {
+	Enabled        bool `json:"enabled"`         // The caller's opt-in
+	PartnerEnabled bool `json:"partner_enabled"` // The partner's opt-in
+	Active         bool `json:"active"`          // Game requests start plays directly only when both opted in
+}
+
+// SetAutoAccept handles opting the caller in or out of auto-accepting game requests within a partnership
+func (h *PartnerHandler) SetAutoAccept(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	partnershipIDStr := c.Param("id")
+	partnershipID, err := uuid.Parse(partnershipIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	var req SetAutoAcceptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	partnership, err := h.partnershipRepo.FindPartnershipByID(partnershipID)
+	if err != nil || (partnership.User1ID != userUUID && partnership.User2ID != userUUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		return
+	}
+
+	partnerID := partnership.User1ID
+	if partnership.User1ID == userUUID {
+		partnerID = partnership.User2ID
+	}
+
+	metadata := database.JSONB{}
+	for key, value := range partnership.Metadata {
+		metadata[key] = value
+	}
+	metadata[autoAcceptMetadataKey(partnership, userUUID)] = *req.Enabled
+
+	metadata, err = sanitizeMetadata(metadata, h.textPolicy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.partnershipRepo.UpdateMetadata(partnership.ID, metadata, partnership.MetadataVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update metadata: " + err.Error()})
+		return
+	}
+	if !updated {
+		c.JSON(http.StatusConflict, gin.H{"error": "Metadata was updated by your partner, please try again"})
+		return
+	}
+
+	partnership.Metadata = metadata
+	partnerEnabled, _ := metadata[autoAcceptMetadataKey(partnership, partnerID)].(bool)
+
+	c.JSON(http.StatusOK, SetAutoAcceptResponse{
+		Enabled:        *req.Enabled,
+		PartnerEnabled: partnerEnabled,
+		Active:         autoAcceptEnabled(partnership),
+	})
+}
+
+// sanitizeMetadata validates metadata limits and normalizes values
+// Only flat string, number and boolean values are allowed; strings are cleaned by the text policy
+func sanitizeMetadata(metadata database.JSONB, policy textPolicy) (database.JSONB, error) {
+	if len(metadata) > maxMetadataKeys {
+		return nil, fmt.Errorf("metadata cannot have more than %d keys", maxMetadataKeys)
+	}
+
+	sanitized := database.JSONB{}
+	for key, value := range metadata {
+		key = strings.TrimSpace(key)
+		if key == "" || len(key) > maxMetadataKeyLength {
+			return nil, fmt.Errorf("metadata keys must be 1-%d characters", maxMetadataKeyLength)
+		}
+
+		switch v := value.(type) {
+		case string:
+			cleaned, err := policy.sanitize(v, textRules{
+				field:     fmt.Sprintf("metadata value for %q", key),
+				maxLength: maxMetadataValueLength,
+			})
+			if err != nil {
+				return nil, err
+			}
+			sanitized[key] = cleaned
+		case float64, bool:
+			sanitized[key] = v
+		default:
+			return nil, fmt.Errorf("metadata value for %q must be a string, number or boolean", key)
+		}
+	}
+
+	encoded, err := json.Marshal(sanitized)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata")
+	}
+	if len(encoded) > maxMetadataBytes {
+		return nil, fmt.Errorf("metadata cannot exceed %d bytes", maxMetadataBytes)
+	}
+
+	return sanitized, nil
+}
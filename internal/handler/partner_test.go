@@ -0,0 +1,685 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestVerifyPartnerAcceptToken(t *testing.T) {
+	cfg := newTestConfig()
+	h, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+
+	requestID, tokenID := uuid.New(), uuid.New()
+	acceptClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"purpose":    partnerAcceptTokenPurpose,
+			"request_id": requestID.String(),
+			"email":      "friend@example.com",
+			"jti":        tokenID.String(),
+			"exp":        time.Now().Add(time.Hour).Unix(),
+			"iat":        time.Now().Unix(),
+		}
+	}
+
+	valid, err := h.generatePartnerAcceptToken(requestID, "friend@example.com", tokenID)
+	if err != nil {
+		t.Fatalf("generatePartnerAcceptToken() error = %v", err)
+	}
+	claims, err := h.verifyPartnerAcceptToken(valid)
+	if err != nil {
+		t.Fatalf("verifyPartnerAcceptToken() error = %v", err)
+	}
+	if claims.RequestID != requestID || claims.TokenID != tokenID || claims.RecipientEmail != "friend@example.com" {
+		t.Errorf("claims = %+v, want request %s, token %s for friend@example.com", claims, requestID, tokenID)
+	}
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+	}{
+		{
+			name: "login token",
+			token: func(t *testing.T) string {
+				return signTestToken(t, cfg.JWTSecret, jwt.MapClaims{
+					"user_id": uuid.NewString(),
+					"email":   "friend@example.com",
+					"exp":     time.Now().Add(time.Hour).Unix(),
+				})
+			},
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				claims := acceptClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return signTestToken(t, cfg.JWTSecret, claims)
+			},
+		},
+		{
+			name: "other secret",
+			token: func(t *testing.T) string {
+				return signTestToken(t, "other-secret", acceptClaims())
+			},
+		},
+		{
+			name: "missing token ID",
+			token: func(t *testing.T) string {
+				claims := acceptClaims()
+				delete(claims, "jti")
+				return signTestToken(t, cfg.JWTSecret, claims)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := h.verifyPartnerAcceptToken(tt.token(t)); err == nil {
+				t.Error("verifyPartnerAcceptToken() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestAcceptPartnerLink(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	authHandler, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	h := NewPartnerHandler(cfg, nil, authHandler)
+
+	sender := createTestUser(t, db)
+	recipient := createTestUser(t, db)
+	tokenID := uuid.New()
+	request := &database.PartnerRequest{SenderID: sender.ID, RecipientEmail: recipient.Email, Status: "pending", AcceptTokenID: &tokenID}
+	if err := db.Create(request).Error; err != nil {
+		t.Fatalf("failed to create partner request: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("user1_id IN ? OR user2_id IN ?", []uuid.UUID{sender.ID, recipient.ID}, []uuid.UUID{sender.ID, recipient.ID}).Delete(&database.Partnership{})
+		db.Delete(request)
+	})
+
+	token, err := authHandler.generatePartnerAcceptToken(request.ID, recipient.Email, tokenID)
+	if err != nil {
+		t.Fatalf("generatePartnerAcceptToken() error = %v", err)
+	}
+	accept := func(token string) int {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/partners/accept-link?token="+url.QueryEscape(token), nil, uuid.Nil)
+		h.AcceptPartnerLink(c)
+		return recorder.Code
+	}
+
+	if status := accept(token[:len(token)-10] + "AAAAAAAAAA"); status != http.StatusUnauthorized {
+		t.Errorf("tampered link status = %d, want %d", status, http.StatusUnauthorized)
+	}
+	if status := accept(token); status != http.StatusOK {
+		t.Fatalf("first use status = %d, want %d", status, http.StatusOK)
+	}
+	if status := accept(token); status != http.StatusUnauthorized {
+		t.Errorf("second use status = %d, want %d", status, http.StatusUnauthorized)
+	}
+
+	var stored database.PartnerRequest
+	if err := db.First(&stored, "id = ?", request.ID).Error; err != nil {
+		t.Fatalf("failed to reload partner request: %v", err)
+	}
+	if stored.Status != "accepted" || stored.AcceptTokenID != nil {
+		t.Errorf("request status = %q with token %v, want accepted with the token consumed", stored.Status, stored.AcceptTokenID)
+	}
+}
+
+func TestSanitizeMetadata(t *testing.T) {
+	tooManyKeys := database.JSONB{}
+	for i := 0; i <= maxMetadataKeys; i++ {
+		tooManyKeys[fmt.Sprintf("key%d", i)] = true
+	}
+
+	tests := []struct {
+		name     string
+		metadata database.JSONB
+		want     database.JSONB
+		wantErr  bool
+	}{
+		{
+			name:     "strings are cleaned and keys trimmed",
+			metadata: database.JSONB{" nickname ": " Team\u200b Rocket ", "theme": "dark", "score": float64(3), "pinned": true},
+			want:     database.JSONB{"nickname": "Team Rocket", "theme": "dark", "score": float64(3), "pinned": true},
+		},
+		{name: "nested values are rejected", metadata: database.JSONB{"colors": []interface{}{"red"}}, wantErr: true},
+		{name: "empty key is rejected", metadata: database.JSONB{" ": "x"}, wantErr: true},
+		{name: "long key is rejected", metadata: database.JSONB{strings.Repeat("k", maxMetadataKeyLength+1): "x"}, wantErr: true},
+		{name: "long value is rejected", metadata: database.JSONB{"note": strings.Repeat("v", maxMetadataValueLength+1)}, wantErr: true},
+		{name: "too many keys are rejected", metadata: tooManyKeys, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeMetadata(tt.metadata, textPolicy{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sanitizeMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sanitizeMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePartnershipMetadataVersion(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	createTestPartnership(t, db, user, partner)
+
+	update := func(userID uuid.UUID, metadata database.JSONB, version int) *httptest.ResponseRecorder {
+		req := UpdatePartnershipMetadataRequest{Metadata: metadata, Version: &version}
+		c, recorder := newTestContext(http.MethodPut, "/api/v1/partners/current/metadata", req, userID)
+		h.UpdatePartnershipMetadata(c)
+		return recorder
+	}
+
+	recorder := update(user.ID, database.JSONB{"nickname": "Rockets"}, 0)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response PartnershipMetadataResponse
+	decodeResponse(t, recorder, &response)
+	if response.Version != 1 || response.Metadata["nickname"] != "Rockets" {
+		t.Errorf("response = %+v, want version 1 with the nickname", response)
+	}
+
+	// The partner's write based on the old version is refused rather than clobbering the nickname
+	if recorder := update(partner.ID, database.JSONB{"nickname": "Comets"}, 0); recorder.Code != http.StatusConflict {
+		t.Errorf("stale update status = %d, want %d", recorder.Code, http.StatusConflict)
+	}
+	if recorder := update(partner.ID, database.JSONB{"nickname": nil, "theme": "dark"}, 1); recorder.Code != http.StatusOK {
+		t.Fatalf("current update status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var stored database.Partnership
+	if err := db.First(&stored, "user1_id = ?", user.ID).Error; err != nil {
+		t.Fatalf("failed to reload partnership: %v", err)
+	}
+	if !reflect.DeepEqual(stored.Metadata, database.JSONB{"theme": "dark"}) || stored.MetadataVersion != 2 {
+		t.Errorf("stored metadata = %v at version %d, want only the theme at version 2", stored.Metadata, stored.MetadataVersion)
+	}
+}
+
+func TestGetPartnership(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	partnership := createTestPartnership(t, db, user, partner)
+
+	get := func(userID uuid.UUID, id string) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/partners/"+id, nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		h.GetPartnership(c)
+		return recorder
+	}
+
+	for _, member := range []*database.User{user, partner} {
+		recorder := get(member.ID, partnership.ID.String())
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status for a member = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response GetPartnershipResponse
+		decodeResponse(t, recorder, &response)
+		wantPartner := partner.ID
+		if member == partner {
+			wantPartner = user.ID
+		}
+		if response.Partner.ID != wantPartner {
+			t.Errorf("partner for %s = %s, want %s", member.ID, response.Partner.ID, wantPartner)
+		}
+	}
+
+	// Non-members can't tell the partnership from a missing one
+	if recorder := get(stranger.ID, partnership.ID.String()); recorder.Code != http.StatusNotFound {
+		t.Errorf("status for a non-member = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+	if recorder := get(user.ID, uuid.NewString()); recorder.Code != http.StatusNotFound {
+		t.Errorf("status for a missing partnership = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+	if recorder := get(user.ID, "not-a-uuid"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("status for a malformed ID = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBatchRespondToRequests(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	user := createTestUser(t, db)
+	rejected, accepted, lateAccept := createTestUser(t, db), createTestUser(t, db), createTestUser(t, db)
+
+	var requests []*database.PartnerRequest
+	for _, sender := range []*database.User{rejected, accepted, lateAccept} {
+		request := &database.PartnerRequest{SenderID: sender.ID, RecipientID: &user.ID, RecipientEmail: user.Email, Status: "pending"}
+		if err := db.Create(request).Error; err != nil {
+			t.Fatalf("failed to create partner request: %v", err)
+		}
+		requests = append(requests, request)
+	}
+	t.Cleanup(func() {
+		db.Where("user1_id = ? OR user2_id = ?", user.ID, user.ID).Delete(&database.Partnership{})
+		for _, request := range requests {
+			db.Delete(request)
+		}
+	})
+
+	req := BatchRespondRequest{Requests: []BatchRequestItem{
+		{ID: requests[0].ID.String(), Action: batchActionReject},
+		{ID: requests[1].ID.String(), Action: batchActionAccept},
+		{ID: requests[2].ID.String(), Action: batchActionAccept},
+		{ID: uuid.NewString(), Action: batchActionReject},
+	}}
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/requests/batch", req, user.ID)
+	h.BatchRespondToRequests(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response BatchRespondResponse
+	decodeResponse(t, recorder, &response)
+	// Only one partner is allowed, so the second accept is refused
+	wantSuccess := []bool{true, true, false, false}
+	if len(response.Results) != len(wantSuccess) {
+		t.Fatalf("results = %+v, want %d", response.Results, len(wantSuccess))
+	}
+	for i, result := range response.Results {
+		if result.Success != wantSuccess[i] || (result.Error == "") != wantSuccess[i] {
+			t.Errorf("result %d = %+v, want success %v", i, result, wantSuccess[i])
+		}
+	}
+	if response.Partnership == nil || (response.Partnership.User1ID != accepted.ID && response.Partnership.User2ID != accepted.ID) {
+		t.Errorf("partnership = %+v, want one with %s", response.Partnership, accepted.ID)
+	}
+
+	wantStatus := []string{"rejected", "accepted", "cancelled"}
+	for i, request := range requests {
+		var stored database.PartnerRequest
+		if err := db.First(&stored, "id = ?", request.ID).Error; err != nil {
+			t.Fatalf("failed to reload partner request: %v", err)
+		}
+		if stored.Status != wantStatus[i] {
+			t.Errorf("request %d status = %q, want %q", i, stored.Status, wantStatus[i])
+		}
+	}
+}
+
+func TestBuildTimelineEntry(t *testing.T) {
+	partner1, partner2 := uuid.New(), uuid.New()
+	profiles := map[string]PartnerProfile{
+		partner1.String(): {ID: partner1, Name: "Alice"},
+		partner2.String(): {ID: partner2, Name: "Bob"},
+	}
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := startedAt.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		isLive       bool
+		playData     database.JSONB
+		wantOutcome  string
+		wantWinner   *uuid.UUID
+		wantDuration int64
+	}{
+		{
+			name:        "live",
+			isLive:      true,
+			playData:    database.JSONB{"status": "playing"},
+			wantOutcome: timelineOutcomeInProgress,
+		},
+		{
+			name: "won",
+			playData: database.JSONB{
+				"status":         "completed",
+				"winner_id":      partner2.String(),
+				"paused_seconds": float64(60),
+				"guesses": []interface{}{
+					map[string]interface{}{"player_id": partner2.String(), "timestamp": startedAt.Add(10 * time.Minute).Format(time.RFC3339Nano)},
+				},
+			},
+			wantOutcome:  timelineOutcomeWon,
+			wantWinner:   &partner2,
+			wantDuration: 9 * 60,
+		},
+		{
+			name:         "ended without a winner",
+			playData:     database.JSONB{"status": "playing"},
+			wantOutcome:  timelineOutcomeEnded,
+			wantDuration: 60 * 60,
+		},
+		{
+			name:         "winner outside the partnership",
+			playData:     database.JSONB{"status": "completed", "winner_id": uuid.NewString()},
+			wantOutcome:  timelineOutcomeEnded,
+			wantDuration: 60 * 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			play := &database.Play{
+				ID:         uuid.New(),
+				Partner1ID: partner1,
+				Partner2ID: partner2,
+				IsLive:     tt.isLive,
+				PlayData:   tt.playData,
+				CreatedAt:  startedAt,
+				UpdatedAt:  updatedAt,
+			}
+			entry := buildTimelineEntry(play, profiles)
+
+			if entry.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q", entry.Outcome, tt.wantOutcome)
+			}
+			if (entry.Winner == nil) != (tt.wantWinner == nil) || (entry.Winner != nil && entry.Winner.ID != *tt.wantWinner) {
+				t.Errorf("winner = %+v, want %v", entry.Winner, tt.wantWinner)
+			}
+			if tt.isLive {
+				if entry.EndedAt != nil || entry.DurationSeconds != nil {
+					t.Errorf("live play has end %v and duration %v", entry.EndedAt, entry.DurationSeconds)
+				}
+				return
+			}
+			if entry.DurationSeconds == nil || *entry.DurationSeconds != tt.wantDuration {
+				t.Errorf("duration = %v, want %d", entry.DurationSeconds, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestGetPartnershipTimeline(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	partnership := createTestPartnership(t, db, user, partner)
+
+	// Plays are created out of order and dated after the partnership formed
+	won := createTestPlay(t, db, game, partner, user, database.JSONB{"status": "completed", "winner_id": user.ID.String()}, false)
+	live := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing"}, true)
+	ended := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing"}, false)
+	for i, play := range []*database.Play{ended, won, live} {
+		if err := db.Model(play).Update("created_at", partnership.CreatedAt.Add(time.Duration(i+1)*time.Minute)).Error; err != nil {
+			t.Fatalf("failed to date play: %v", err)
+		}
+	}
+
+	getTimeline := func(userID uuid.UUID) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/partners/"+partnership.ID.String()+"/timeline", nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: partnership.ID.String()}}
+		h.GetPartnershipTimeline(c)
+		return recorder
+	}
+
+	recorder := getTimeline(partner.ID)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response GetPartnershipTimelineResponse
+	decodeResponse(t, recorder, &response)
+
+	want := []struct {
+		playID  uuid.UUID
+		outcome string
+	}{
+		{ended.ID, timelineOutcomeEnded},
+		{won.ID, timelineOutcomeWon},
+		{live.ID, timelineOutcomeInProgress},
+	}
+	if response.Total != int64(len(want)) || len(response.Entries) != len(want) {
+		t.Fatalf("entries = %+v, total %d, want %d", response.Entries, response.Total, len(want))
+	}
+	for i, entry := range response.Entries {
+		if entry.PlayID != want[i].playID || entry.Outcome != want[i].outcome {
+			t.Errorf("entry %d = %s %q, want %s %q", i, entry.PlayID, entry.Outcome, want[i].playID, want[i].outcome)
+		}
+	}
+	if winner := response.Entries[1].Winner; winner == nil || winner.ID != user.ID {
+		t.Errorf("winner = %+v, want %s", winner, user.ID)
+	}
+
+	if recorder := getTimeline(stranger.ID); recorder.Code != http.StatusNotFound {
+		t.Errorf("non-member status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestAutoAcceptEnabled(t *testing.T) {
+	partnership := &database.Partnership{User1ID: uuid.New(), User2ID: uuid.New()}
+	user1Key := autoAcceptMetadataKey(partnership, partnership.User1ID)
+	user2Key := autoAcceptMetadataKey(partnership, partnership.User2ID)
+	if user1Key == user2Key {
+		t.Fatalf("both partners use metadata key %q", user1Key)
+	}
+
+	tests := []struct {
+		name     string
+		metadata database.JSONB
+		want     bool
+	}{
+		{name: "no opt-ins", metadata: nil, want: false},
+		{name: "one opt-in", metadata: database.JSONB{user1Key: true}, want: false},
+		{name: "one opt-out", metadata: database.JSONB{user1Key: true, user2Key: false}, want: false},
+		{name: "non-boolean opt-in", metadata: database.JSONB{user1Key: true, user2Key: "true"}, want: false},
+		{name: "both opt-ins", metadata: database.JSONB{user1Key: true, user2Key: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partnership.Metadata = tt.metadata
+			if got := autoAcceptEnabled(partnership); got != tt.want {
+				t.Errorf("autoAcceptEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetActiveGames(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	bullsAndCows := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	otherGame := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	loner := createTestUser(t, db)
+	createTestPartnership(t, db, user, partner)
+
+	turnData := func(turn *database.User) database.JSONB {
+		return database.JSONB{"status": "playing", "current_turn": turn.ID.String(), "partner1_secret": "1234", "partner2_secret": "5678"}
+	}
+	partnerTurn := createTestPlay(t, db, bullsAndCows, user, partner, turnData(partner), true)
+	myTurn := createTestPlay(t, db, otherGame, partner, user, turnData(user), true)
+	createTestPlay(t, db, bullsAndCows, user, partner, turnData(user), false)
+	createTestPlay(t, db, bullsAndCows, user, stranger, turnData(user), true)
+	// The partner's turn play was active most recently
+	if err := db.Model(partnerTurn).Update("updated_at", time.Now().Add(time.Minute)).Error; err != nil {
+		t.Fatalf("failed to touch play: %v", err)
+	}
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/partners/current/active-games", nil, user.ID)
+	h.GetActiveGames(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	var response GetActiveGamesResponse
+	decodeResponse(t, recorder, &response)
+	want := []struct {
+		playID   uuid.UUID
+		turn     uuid.UUID
+		isMyTurn bool
+	}{
+		{partnerTurn.ID, partner.ID, false},
+		{myTurn.ID, user.ID, true},
+	}
+	if len(response.Games) != len(want) {
+		t.Fatalf("games = %+v, want %d", response.Games, len(want))
+	}
+	for i, game := range response.Games {
+		if game.Play.ID != want[i].playID || game.CurrentTurn == nil || *game.CurrentTurn != want[i].turn || game.IsMyTurn != want[i].isMyTurn {
+			t.Errorf("game %d = play %s, turn %v, mine %v, want play %s, turn %s, mine %v",
+				i, game.Play.ID, game.CurrentTurn, game.IsMyTurn, want[i].playID, want[i].turn, want[i].isMyTurn)
+		}
+		opponentKey := "partner2_secret"
+		if game.Play.Partner2ID == user.ID {
+			opponentKey = "partner1_secret"
+		}
+		if secret := game.Play.PlayData[opponentKey]; secret != nil {
+			t.Errorf("play %s reveals the partner's secret %v", game.Play.ID, secret)
+		}
+	}
+
+	c, recorder = newTestContext(http.MethodGet, "/api/v1/partners/current/active-games", nil, loner.ID)
+	h.GetActiveGames(c)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("status without a partner = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestAcceptPartnerRequestRollsBackFailedCleanup(t *testing.T) {
+	db := openTestDB(t)
+	h := NewPartnerHandler(newTestConfig(), nil, nil)
+	user := createTestUser(t, db)
+	sender := createTestUser(t, db)
+
+	request := &database.PartnerRequest{SenderID: sender.ID, RecipientID: &user.ID, RecipientEmail: user.Email, Status: "pending"}
+	other := &database.PartnerRequest{SenderID: sender.ID, RecipientEmail: "other-" + uuid.NewString() + "@example.com", Status: "pending"}
+	for _, r := range []*database.PartnerRequest{request, other} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("failed to create partner request: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("user1_id = ? OR user2_id = ?", user.ID, user.ID).Delete(&database.Partnership{})
+		db.Delete(request)
+		db.Delete(other)
+	})
+
+	// Fail the cancellation of the users' other pending requests
+	const callbackName = "test:fail_partner_request_cleanup"
+	if err := db.Callback().Update().Before("gorm:update").Register(callbackName, func(tx *gorm.DB) {
+		if updates, ok := tx.Statement.Dest.(map[string]interface{}); ok && tx.Statement.Table == "partner_requests" && updates["status"] == "cancelled" {
+			tx.AddError(errors.New("cleanup failed"))
+		}
+	}); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	t.Cleanup(func() { db.Callback().Update().Remove(callbackName) })
+
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/accept/"+request.ID.String(), nil, user.ID)
+	c.Params = gin.Params{{Key: "id", Value: request.ID.String()}}
+	h.AcceptPartnerRequest(c)
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusInternalServerError, recorder.Body.String())
+	}
+
+	// The whole accept was rolled back, so no partnership exists and the request can be accepted again
+	var partnerships int64
+	if err := db.Model(&database.Partnership{}).Where("user1_id = ? OR user2_id = ?", user.ID, user.ID).Count(&partnerships).Error; err != nil {
+		t.Fatalf("failed to count partnerships: %v", err)
+	}
+	if partnerships != 0 {
+		t.Errorf("%d partnerships created, want none", partnerships)
+	}
+	for _, r := range []*database.PartnerRequest{request, other} {
+		var stored database.PartnerRequest
+		if err := db.First(&stored, "id = ?", r.ID).Error; err != nil {
+			t.Fatalf("failed to reload partner request: %v", err)
+		}
+		if stored.Status != "pending" {
+			t.Errorf("request %s status = %q, want pending", r.ID, stored.Status)
+		}
+	}
+}
+
+func TestResendPartnerRequest(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	authHandler, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	emailClient := &recordingEmailClient{}
+	h := NewPartnerHandler(cfg, emailClient, authHandler)
+	sender := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	t.Cleanup(func() { db.Where("sender_id = ?", sender.ID).Delete(&database.PartnerRequest{}) })
+
+	newRequest := func(status string, createdAt time.Time) *database.PartnerRequest {
+		request := &database.PartnerRequest{SenderID: sender.ID, RecipientEmail: uuid.NewString() + "@example.com", Status: status, CreatedAt: createdAt}
+		if err := db.Create(request).Error; err != nil {
+			t.Fatalf("failed to create partner request: %v", err)
+		}
+		return request
+	}
+	resend := func(request *database.PartnerRequest, userID uuid.UUID) *httptest.ResponseRecorder {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/request/"+request.ID.String()+"/resend", nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: request.ID.String()}}
+		h.ResendPartnerRequest(c)
+		return recorder
+	}
+
+	request := newRequest("pending", time.Now().Add(-time.Hour))
+
+	if recorder := resend(request, stranger.ID); recorder.Code != http.StatusForbidden {
+		t.Errorf("resend by another user status = %d, want %d", recorder.Code, http.StatusForbidden)
+	}
+	if sent := emailClient.templatedSent(0); len(sent) != 0 {
+		t.Errorf("emails sent = %v, want none for another user's resend", sent)
+	}
+
+	if recorder := resend(request, sender.ID); recorder.Code != http.StatusOK {
+		t.Fatalf("resend status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if sent := emailClient.templatedSent(1); len(sent) != 1 {
+		t.Errorf("emails sent = %v, want one", sent)
+	}
+	var count int64
+	db.Model(&database.PartnerRequest{}).Where("sender_id = ?", sender.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("sender has %d partner requests, want the original only", count)
+	}
+
+	// The resend starts a new cooldown
+	recorder := resend(request, sender.ID)
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") == "" {
+		t.Errorf("second resend status = %d, Retry-After %q, want %d with a Retry-After", recorder.Code, recorder.Header().Get("Retry-After"), http.StatusTooManyRequests)
+	}
+
+	// So does sending the request in the first place
+	if recorder := resend(newRequest("pending", time.Now()), sender.ID); recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("resend of a new request status = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+	}
+
+	if recorder := resend(newRequest("cancelled", time.Now().Add(-time.Hour)), sender.ID); recorder.Code != http.StatusBadRequest {
+		t.Errorf("resend of a cancelled request status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	if sent := emailClient.templatedSent(1); len(sent) != 1 {
+		t.Errorf("emails sent = %v, want only the one resend", sent)
+	}
+}
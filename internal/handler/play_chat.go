@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// maxPlayMessageLength is the most characters a play chat message may have
+const maxPlayMessageLength = 500
+
+// playMessageRules are the text rules of play chat messages, which may span several lines
+var playMessageRules = textRules{field: "message", maxLength: maxPlayMessageLength, required: true, allowNewlines: true}
+
+// loadChatPlay loads a play for its chat, checking the user is one of its two partners
+// Writes the error response and returns nil if the user can't chat in the play
+func (h *GamesHandler) loadChatPlay(c *gin.Context, userUUID uuid.UUID) *database.Play {
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return nil
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return nil
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return nil
+	}
+
+	// Practice and bot plays have nobody to talk to
+	if play.IsPractice || isBotPlay(play) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Chat is only available in plays between partners"})
+		return nil
+	}
+
+	return play
+}
+
+// SendPlayMessageRequest represents the request body for sending a play chat message
+type SendPlayMessageRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// SendPlayMessageResponse represents the response for sending a play chat message
+type SendPlayMessageResponse struct {
+	Message *database.PlayMessage `json:"message"`
+}
+
+// SendPlayMessage handles posting a chat message to the other partner of a play
+func (h *GamesHandler) SendPlayMessage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req SendPlayMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	body, err := h.textPolicy.sanitize(req.Body, playMessageRules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	play := h.loadChatPlay(c, userUUID)
+	if play == nil {
+		return
+	}
+
+	message := &database.PlayMessage{
+		PlayID:   play.ID,
+		SenderID: userUUID,
+		Body:     body,
+	}
+	if err := h.playMessageRepo.Create(message); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message: " + err.Error()})
+		return
+	}
+	h.playHub.publishMessage(*message)
+
+	// Let the other partner know unless they muted this chat (unread chat notifications collapse into one)
+	recipientID := play.Partner1ID
+	if recipientID == userUUID {
+		recipientID = play.Partner2ID
+	}
+	muted, err := h.playMessageRepo.IsChatMuted(play.ID, recipientID)
+	if err != nil {
+		logging.FromContext(c).Error("failed to check chat mute", "error", err)
+	}
+	if !muted {
+		gameID := play.GameID
+		notification := &database.Notification{
+			UserID:  recipientID,
+			Type:    "play_message",
+			ActorID: &userUUID,
+			GameID:  &gameID,
+			Message: "Your partner sent you a message in " + play.Game.Name,
+		}
+		if err := h.notificationRepo.Create(notification); err != nil {
+			logging.FromContext(c).Error("failed to create notification", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, SendPlayMessageResponse{
+		Message: message,
+	})
+}
+
+// GetPlayMessagesResponse represents the response for listing a play's chat messages
+type GetPlayMessagesResponse struct {
+	Messages []database.PlayMessage `json:"messages"`
+	Total    int64                  `json:"total"`
+	Limit    int                    `json:"limit"`
+	Offset   int                    `json:"offset"`
+	Muted    bool                   `json:"muted"` // Whether the caller muted notifications for this chat
+}
+
+// GetPlayMessages handles listing a play's chat messages, newest first
+func (h *GamesHandler) GetPlayMessages(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	page, err := parsePagination(c, h.pageLimits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	play := h.loadChatPlay(c, userUUID)
+	if play == nil {
+		return
+	}
+
+	messages, total, err := h.playMessageRepo.FindByPlay(play.ID, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages: " + err.Error()})
+		return
+	}
+
+	muted, err := h.playMessageRepo.IsChatMuted(play.ID, userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check chat mute: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, GetPlayMessagesResponse{
+		Messages: messages,
+		Total:    total,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+		Muted:    muted,
+	})
+}
+
+// MutePlayChatRequest represents the request body for muting a play's chat notifications
+type MutePlayChatRequest struct {
+	Muted *bool `json:"muted" binding:"required"`
+}
+
+// MutePlayChatResponse represents the response for muting a play's chat notifications
+type MutePlayChatResponse struct {
+	PlayID uuid.UUID `json:"play_id"`
+	Muted  bool      `json:"muted"`
+}
+
+// MutePlayChat handles turning the caller's notifications for a play's chat messages off or on
+// Game notifications for the play are unaffected
+func (h *GamesHandler) MutePlayChat(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req MutePlayChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	play := h.loadChatPlay(c, userUUID)
+	if play == nil {
+		return
+	}
+
+	if err := h.playMessageRepo.SetChatMuted(play.ID, userUUID, *req.Muted); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update chat mute: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, MutePlayChatResponse{
+		PlayID: play.ID,
+		Muted:  *req.Muted,
+	})
+}
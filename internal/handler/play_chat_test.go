@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// sendTestPlayMessage posts a chat message to a play as the user, returning the response status
+func sendTestPlayMessage(t *testing.T, h *GamesHandler, play *database.Play, userID uuid.UUID, body string) int {
+	t.Helper()
+
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/games/plays/"+play.ID.String()+"/messages", SendPlayMessageRequest{Body: body}, userID)
+	c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+	h.SendPlayMessage(c)
+	return recorder.Code
+}
+
+func TestPlayChat(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing"}, true)
+	t.Cleanup(func() {
+		db.Where("play_id = ?", play.ID).Delete(&database.PlayMessage{})
+		db.Where("user_id IN ?", []uuid.UUID{user.ID, partner.ID}).Delete(&database.Notification{})
+	})
+
+	for _, message := range []struct {
+		sender *database.User
+		body   string
+	}{
+		{sender: user, body: "good luck"},
+		{sender: partner, body: "  you'll need it\u200b  "},
+		{sender: user, body: "we'll see\nabout that"},
+	} {
+		if code := sendTestPlayMessage(t, h, play, message.sender.ID, message.body); code != http.StatusOK {
+			t.Fatalf("SendPlayMessage(%q) status = %d, want %d", message.body, code, http.StatusOK)
+		}
+	}
+
+	if code := sendTestPlayMessage(t, h, play, stranger.ID, "hello"); code != http.StatusForbidden {
+		t.Errorf("SendPlayMessage() by a non-participant status = %d, want %d", code, http.StatusForbidden)
+	}
+	for name, body := range map[string]string{
+		"blank":    " \u200b ",
+		"too long": strings.Repeat("a", maxPlayMessageLength+1),
+	} {
+		if code := sendTestPlayMessage(t, h, play, user.ID, body); code != http.StatusBadRequest {
+			t.Errorf("SendPlayMessage() %s status = %d, want %d", name, code, http.StatusBadRequest)
+		}
+	}
+
+	list := func(userID uuid.UUID, query string) (int, GetPlayMessagesResponse) {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/plays/"+play.ID.String()+"/messages?"+query, nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.GetPlayMessages(c)
+		var response GetPlayMessagesResponse
+		decodeResponse(t, recorder, &response)
+		return recorder.Code, response
+	}
+
+	code, response := list(partner.ID, "limit=2")
+	if code != http.StatusOK {
+		t.Fatalf("GetPlayMessages() status = %d, want %d", code, http.StatusOK)
+	}
+	if response.Total != 3 || len(response.Messages) != 2 {
+		t.Fatalf("got %d of %d messages, want 2 of 3", len(response.Messages), response.Total)
+	}
+	// Newest first, sanitized as they were sent
+	if response.Messages[0].Body != "we'll see\nabout that" || response.Messages[1].Body != "you'll need it" || response.Messages[1].SenderID != partner.ID {
+		t.Errorf("messages = %+v, want the newest two, sanitized", response.Messages)
+	}
+
+	_, response = list(user.ID, "limit=2&offset=2")
+	if len(response.Messages) != 1 || response.Messages[0].Body != "good luck" {
+		t.Errorf("second page = %+v, want the first message", response.Messages)
+	}
+
+	if code, _ := list(stranger.ID, ""); code != http.StatusForbidden {
+		t.Errorf("GetPlayMessages() by a non-participant status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	// Each message notified the other partner
+	var notifications int64
+	db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", partner.ID, "play_message").Count(&notifications)
+	if notifications == 0 {
+		t.Error("partner wasn't notified of the messages")
+	}
+}
+
+func TestPlayChatUnavailableInPractice(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, user, database.JSONB{"status": "playing"}, true)
+	if err := db.Model(play).Update("is_practice", true).Error; err != nil {
+		t.Fatalf("failed to mark practice play: %v", err)
+	}
+
+	if code := sendTestPlayMessage(t, h, play, user.ID, "hello?"); code != http.StatusBadRequest {
+		t.Errorf("SendPlayMessage() in a practice play status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestMutePlayChat(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{
+		"status":          "playing",
+		"current_turn":    user.ID.String(),
+		"partner1_secret": "1234",
+		"partner2_secret": "5678",
+	}, true)
+	t.Cleanup(func() {
+		db.Where("play_id = ?", play.ID).Delete(&database.PlayMessage{})
+		db.Where("play_id = ?", play.ID).Delete(&database.PlayChatMute{})
+		db.Where("user_id IN ?", []uuid.UUID{user.ID, partner.ID}).Delete(&database.Notification{})
+	})
+
+	mute := func(userID uuid.UUID, muted bool) int {
+		c, recorder := newTestContext(http.MethodPut, "/api/v1/games/plays/"+play.ID.String()+"/messages/mute", MutePlayChatRequest{Muted: &muted}, userID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.MutePlayChat(c)
+		return recorder.Code
+	}
+	chatNotifications := func() int64 {
+		var count int64
+		db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", partner.ID, "play_message").Count(&count)
+		return count
+	}
+
+	if code := mute(stranger.ID, true); code != http.StatusForbidden {
+		t.Errorf("mute by a non-participant status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	// Chats start unmuted
+	if code := sendTestPlayMessage(t, h, play, user.ID, "first"); code != http.StatusOK {
+		t.Fatalf("SendPlayMessage() status = %d, want %d", code, http.StatusOK)
+	}
+	if count := chatNotifications(); count != 1 {
+		t.Fatalf("partner has %d chat notifications, want 1", count)
+	}
+
+	if code := mute(partner.ID, true); code != http.StatusOK {
+		t.Fatalf("mute status = %d, want %d", code, http.StatusOK)
+	}
+	// Muting twice is harmless
+	if code := mute(partner.ID, true); code != http.StatusOK {
+		t.Errorf("repeated mute status = %d, want %d", code, http.StatusOK)
+	}
+
+	watcher := h.playHub.subscribe(play.ID)
+	defer h.playHub.unsubscribe(play.ID, watcher)
+	db.Where("user_id = ? AND type = ?", partner.ID, "play_message").Delete(&database.Notification{})
+
+	if code := sendTestPlayMessage(t, h, play, user.ID, "second"); code != http.StatusOK {
+		t.Fatalf("SendPlayMessage() status = %d, want %d", code, http.StatusOK)
+	}
+	if count := chatNotifications(); count != 0 {
+		t.Errorf("muted partner has %d chat notifications, want none", count)
+	}
+
+	// Turn updates still reach the muted partner
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/guess", MakeGuessRequest{Guess: "1678"}, user.ID)
+	c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+	h.MakeGuess(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("MakeGuess status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	select {
+	case <-watcher.updates:
+	default:
+		t.Error("muted partner wasn't sent the play update for their turn")
+	}
+
+	// The mute is per user: the partner's messages still notify the user
+	if code := sendTestPlayMessage(t, h, play, partner.ID, "third"); code != http.StatusOK {
+		t.Fatalf("SendPlayMessage() status = %d, want %d", code, http.StatusOK)
+	}
+	var userNotifications int64
+	db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", user.ID, "play_message").Count(&userNotifications)
+	if userNotifications == 0 {
+		t.Error("user wasn't notified of the partner's message")
+	}
+
+	if code := mute(partner.ID, false); code != http.StatusOK {
+		t.Fatalf("unmute status = %d, want %d", code, http.StatusOK)
+	}
+	if code := sendTestPlayMessage(t, h, play, user.ID, "fourth"); code != http.StatusOK {
+		t.Fatalf("SendPlayMessage() status = %d, want %d", code, http.StatusOK)
+	}
+	if count := chatNotifications(); count != 1 {
+		t.Errorf("unmuted partner has %d chat notifications, want 1", count)
+	}
+}
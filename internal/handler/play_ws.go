@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// WebSocketTokenProtocol is the WebSocket subprotocol that precedes the access token in Sec-WebSocket-Protocol
+const WebSocketTokenProtocol = "access_token"
+
+// WebSocket keepalive timing for play watchers
+const (
+	playWSWriteTimeout = 10 * time.Second
+	playWSPongTimeout  = 60 * time.Second
+	playWSPingInterval = playWSPongTimeout * 9 / 10 // Ping before the pong deadline passes
+)
+
+// playWatcherMessageBuffer is how many chat messages a watcher may fall behind by before missing some
+const playWatcherMessageBuffer = 16
+
+// playHub fans play updates and chat messages out to the WebSocket connections watching each play in this process
+type playHub struct {
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[*playWatcher]struct{}
+}
+
+// playWatcher is one connection's subscription to a play
+type playWatcher struct {
+	updates  chan struct{}             // Signalled whenever the play is updated
+	messages chan database.PlayMessage // Chat messages posted to the play
+}
+
+// newPlayHub creates an empty play hub
+func newPlayHub() *playHub {
+	return &playHub{watchers: make(map[uuid.UUID]map[*playWatcher]struct{})}
+}
+
+// subscribe registers a watcher of a play
+// Update signals are coalesced, so a slow watcher skips straight to the latest state
+func (hub *playHub) subscribe(playID uuid.UUID) *playWatcher {
+	watcher := &playWatcher{
+		updates:  make(chan struct{}, 1),
+		messages: make(chan database.PlayMessage, playWatcherMessageBuffer),
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.watchers[playID] == nil {
+		hub.watchers[playID] = make(map[*playWatcher]struct{})
+	}
+	hub.watchers[playID][watcher] = struct{}{}
+	return watcher
+}
+
+// unsubscribe removes a watcher registered by subscribe
+func (hub *playHub) unsubscribe(playID uuid.UUID, watcher *playWatcher) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	delete(hub.watchers[playID], watcher)
+	if len(hub.watchers[playID]) == 0 {
+		delete(hub.watchers, playID)
+	}
+}
+
+// publish signals every watcher of a play that it was updated
+func (hub *playHub) publish(playID uuid.UUID) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for watcher := range hub.watchers[playID] {
+		select {
+		case watcher.updates <- struct{}{}:
+		default: // A signal is already pending
+		}
+	}
+}
+
+// publishMessage sends a new chat message to every watcher of its play
+// A watcher too far behind misses the message; clients can catch up by listing the play's messages
+func (hub *playHub) publishMessage(message database.PlayMessage) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for watcher := range hub.watchers[message.PlayID] {
+		select {
+		case watcher.messages <- message:
+		default:
+		}
+	}
+}
+
+// newPlayUpgrader creates the WebSocket upgrader for play watchers
+// Origins are checked like CORS: any origin is allowed if allowedOrigins is empty
+func newPlayUpgrader(allowedOrigins []string) websocket.Upgrader {
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins[origin] = true
+	}
+
+	return websocket.Upgrader{
+		Subprotocols: []string{WebSocketTokenProtocol},
+		CheckOrigin: func(r *http.Request) bool {
+			return len(origins) == 0 || origins[r.Header.Get("Origin")]
+		},
+	}
+}
+
+// WebSocket message types sent to play watchers
+const (
+	playWSTypePlay    = "play"
+	playWSTypeMessage = "message"
+)
+
+// PlayUpdateMessage is the WebSocket message carrying a play's latest state
+type PlayUpdateMessage struct {
+	Type string         `json:"type"` // Always "play"
+	Play *database.Play `json:"play"`
+}
+
+// PlayChatMessage is the WebSocket message carrying a chat message posted to the play
+type PlayChatMessage struct {
+	Type    string               `json:"type"` // Always "message"
+	Message database.PlayMessage `json:"message"`
+}
+
+// WatchPlay handles a WebSocket connection that receives a play's state on connect and after every update,
+// and each chat message as it is posted
+func (h *GamesHandler) WatchPlay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	// Subscribe before upgrading so no update between the load and the first message is missed
+	watcher := h.playHub.subscribe(playID)
+	defer h.playHub.unsubscribe(playID, watcher)
+
+	// The upgrader writes the error response itself
+	conn, err := h.playUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Reading detects the client going away and handles pongs; clients aren't expected to send messages
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(playWSPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(playWSPongTimeout))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(playWSPingInterval)
+	defer ping.Stop()
+
+	for {
+		hideOpponentSecret(play, userUUID)
+		conn.SetWriteDeadline(time.Now().Add(playWSWriteTimeout))
+		if err := conn.WriteJSON(PlayUpdateMessage{Type: playWSTypePlay, Play: play}); err != nil {
+			return
+		}
+
+		// Wait for the next update, forwarding chat messages and keeping the connection alive meanwhile
+		for waiting := true; waiting; {
+			select {
+			case <-closed:
+				return
+			case <-watcher.updates:
+				waiting = false
+			case message := <-watcher.messages:
+				conn.SetWriteDeadline(time.Now().Add(playWSWriteTimeout))
+				if err := conn.WriteJSON(PlayChatMessage{Type: playWSTypeMessage, Message: message}); err != nil {
+					return
+				}
+			case <-ping.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(playWSWriteTimeout)); err != nil {
+					return
+				}
+			}
+		}
+
+		play, err = h.playRepo.FindPlayByID(playID)
+		if err != nil {
+			logging.FromContext(c).Error("failed to reload watched play", "play_id", playID, "error", err)
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "Failed to reload play"),
+				time.Now().Add(playWSWriteTimeout))
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// playLock is a play's mutex and the number of callers holding or waiting for it
+type playLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// playLocks holds a mutex per play ID, serializing load-modify-save of a play's PlayData
+// This only protects against interleaving within this process; with several instances,
+// concurrent updates to the same play are caught by the play's version check.
+// Entries are removed once no caller holds or waits for them.
+var (
+	playLocksMu sync.Mutex
+	playLocks   = make(map[uuid.UUID]*playLock)
+)
+
+// lockPlay locks the play for a mutating operation and returns the function that unlocks it
+func lockPlay(playID uuid.UUID) func() {
+	playLocksMu.Lock()
+	lock, exists := playLocks[playID]
+	if !exists {
+		lock = &playLock{}
+		playLocks[playID] = lock
+	}
+	lock.refs++
+	playLocksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		playLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(playLocks, playID)
+		}
+		playLocksMu.Unlock()
+	}
+}
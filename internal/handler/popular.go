@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// popularGamesTTL is how long computed popular game rankings are served from cache
+const popularGamesTTL = 5 * time.Minute
+
+// maxPopularGamesWindow is the longest since window popular games can be ranked over
+const maxPopularGamesWindow = 365 * 24 * time.Hour
+
+// cachedPopularGames holds a computed popular games ranking and when it was computed
+type cachedPopularGames struct {
+	games      []database.PopularGame
+	computedAt time.Time
+}
+
+// parseSinceWindow reads the optional since query parameter, a whole number of hours or days such as 24h or 7d
+// Returns 0 when since is absent, meaning all time
+func parseSinceWindow(c *gin.Context) (time.Duration, error) {
+	sinceStr := c.Query("since")
+	if sinceStr == "" {
+		return 0, nil
+	}
+
+	unit := time.Hour
+	amountStr := strings.TrimSuffix(sinceStr, "h")
+	if strings.HasSuffix(sinceStr, "d") {
+		unit = 24 * time.Hour
+		amountStr = strings.TrimSuffix(sinceStr, "d")
+	}
+	amount, err := strconv.Atoi(amountStr)
+	if err != nil || amount < 1 || amountStr == sinceStr {
+		return 0, fmt.Errorf("since must be a positive number of hours or days, such as 24h or 7d")
+	}
+
+	window := time.Duration(amount) * unit
+	if window > maxPopularGamesWindow {
+		return 0, fmt.Errorf("since cannot exceed %d days", int(maxPopularGamesWindow.Hours()/24))
+	}
+	return window, nil
+}
+
+// GetPopularGamesResponse represents the response for getting the most played games
+type GetPopularGamesResponse struct {
+	Games []database.PopularGame `json:"games"`
+	Since *time.Time             `json:"since"` // Start of the ranked window; null when ranking all time
+}
+
+// GetPopularGames handles ranking games by how many plays were started, optionally within a recent window
+func (h *GamesHandler) GetPopularGames(c *gin.Context) {
+	window, err := parseSinceWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var since *time.Time
+	if window > 0 {
+		start := time.Now().Add(-window)
+		since = &start
+	}
+
+	h.popularGamesMu.Lock()
+	cached, exists := h.popularGames[window]
+	h.popularGamesMu.Unlock()
+	if exists && time.Since(cached.computedAt) < popularGamesTTL {
+		c.JSON(http.StatusOK, GetPopularGamesResponse{
+			Games: cached.games,
+			Since: since,
+		})
+		return
+	}
+
+	games, err := h.playRepo.FindPopularGames(since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch popular games: " + err.Error()})
+		return
+	}
+
+	h.popularGamesMu.Lock()
+	h.popularGames[window] = cachedPopularGames{
+		games:      games,
+		computedAt: time.Now(),
+	}
+	h.popularGamesMu.Unlock()
+
+	c.JSON(http.StatusOK, GetPopularGamesResponse{
+		Games: games,
+		Since: since,
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestParseSinceWindow(t *testing.T) {
+	tests := []struct {
+		since   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{since: "", want: 0},
+		{since: "24h", want: 24 * time.Hour},
+		{since: "7d", want: 7 * 24 * time.Hour},
+		{since: "365d", want: 365 * 24 * time.Hour},
+		{since: "366d", wantErr: true},
+		{since: "0d", wantErr: true},
+		{since: "-1h", wantErr: true},
+		{since: "7", wantErr: true},
+		{since: "1w", wantErr: true},
+		{since: "1.5d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		c, _ := newTestContext(http.MethodGet, "/api/v1/games/popular?since="+tt.since, nil, uuid.Nil)
+		got, err := parseSinceWindow(c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSinceWindow(%q) error = %v, wantErr %v", tt.since, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSinceWindow(%q) = %v, want %v", tt.since, got, tt.want)
+		}
+	}
+}
+
+func TestGetPopularGames(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	bot := seedBotUser(t, db)
+	busy := createTestGame(t, db, database.JSONB{})
+	quiet := createTestGame(t, db, database.JSONB{})
+	stale := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+
+	for i := 0; i < 3; i++ {
+		createTestPlay(t, db, busy, user, partner, database.JSONB{}, false)
+	}
+	createTestPlay(t, db, quiet, user, partner, database.JSONB{}, false)
+	// Practice and bot plays would put quiet ahead if they were counted
+	for i := 0; i < 3; i++ {
+		practice := createTestPlay(t, db, quiet, user, partner, database.JSONB{}, false)
+		if err := db.Model(practice).Update("is_practice", true).Error; err != nil {
+			t.Fatalf("failed to mark practice play: %v", err)
+		}
+		createTestPlay(t, db, quiet, user, bot, database.JSONB{}, false)
+	}
+	old := createTestPlay(t, db, stale, user, partner, database.JSONB{}, false)
+	if err := db.Model(old).Update("created_at", time.Now().Add(-10*24*time.Hour)).Error; err != nil {
+		t.Fatalf("failed to age play: %v", err)
+	}
+
+	getPopular := func(since string) map[uuid.UUID]int {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/popular?since="+since, nil, uuid.Nil)
+		h.GetPopularGames(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response GetPopularGamesResponse
+		decodeResponse(t, recorder, &response)
+
+		// Ranks of this test's games, with their counts checked along the way
+		ranks := make(map[uuid.UUID]int)
+		wantCounts := map[uuid.UUID]int64{busy.ID: 3, quiet.ID: 1, stale.ID: 1}
+		for rank, popularGame := range response.Games {
+			if want, ok := wantCounts[popularGame.GameID]; ok {
+				ranks[popularGame.GameID] = rank
+				if popularGame.PlayCount != want {
+					t.Errorf("game %s play count = %d, want %d", popularGame.GameID, popularGame.PlayCount, want)
+				}
+			}
+		}
+		return ranks
+	}
+
+	ranks := getPopular("")
+	busyRank, busyRanked := ranks[busy.ID]
+	quietRank, quietRanked := ranks[quiet.ID]
+	if !busyRanked || !quietRanked || busyRank > quietRank {
+		t.Errorf("ranks = %v, want %s ahead of %s", ranks, busy.ID, quiet.ID)
+	}
+	if _, ok := ranks[stale.ID]; !ok {
+		t.Errorf("all time ranking is missing %s", stale.ID)
+	}
+
+	if _, ok := getPopular("7d")[stale.ID]; ok {
+		t.Errorf("7 day ranking includes %s, last played 10 days ago", stale.ID)
+	}
+
+	// Rankings are cached, so a new play doesn't show until the cache expires
+	createTestPlay(t, db, stale, user, partner, database.JSONB{}, false)
+	if _, ok := getPopular("7d")[stale.ID]; ok {
+		t.Error("cached 7 day ranking changed before it expired")
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// emailOptedOut reports whether a user has turned off emails of the given category
+// A failed lookup is logged and treated as opted in, matching the all-on default
+func emailOptedOut(c *gin.Context, prefsRepo *database.UserPreferencesRepository, userID uuid.UUID, category string) bool {
+	prefs, err := prefsRepo.FindByUserID(userID)
+	if err != nil {
+		logging.FromContext(c).Error("failed to load email preferences", "user_id", userID, "error", err)
+		return false
+	}
+	return !prefs.AllowsEmail(category)
+}
+
+// PreferencesResponse represents the response with the current user's preferences
+type PreferencesResponse struct {
+	Preferences *database.UserPreferences `json:"preferences"`
+}
+
+// GetPreferences handles getting the current user's notification preferences
+func (h *AuthHandler) GetPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	prefs, err := h.prefsRepo.FindByUserID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load preferences: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PreferencesResponse{
+		Preferences: prefs,
+	})
+}
+
+// UpdatePreferencesRequest represents the request body for updating notification preferences
+// Omitted fields are left unchanged
+type UpdatePreferencesRequest struct {
+	EmailPartnerRequests *bool `json:"email_partner_requests"`
+	EmailGameRequests    *bool `json:"email_game_requests"`
+	EmailReminders       *bool `json:"email_reminders"`
+	EmailSummaries       *bool `json:"email_summaries"`
+}
+
+// UpdatePreferences handles updating the current user's notification preferences
+func (h *AuthHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	prefs, err := h.prefsRepo.FindByUserID(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load preferences: " + err.Error()})
+		return
+	}
+
+	if req.EmailPartnerRequests != nil {
+		prefs.EmailPartnerRequests = *req.EmailPartnerRequests
+	}
+	if req.EmailGameRequests != nil {
+		prefs.EmailGameRequests = *req.EmailGameRequests
+	}
+	if req.EmailReminders != nil {
+		prefs.EmailReminders = *req.EmailReminders
+	}
+	if req.EmailSummaries != nil {
+		prefs.EmailSummaries = *req.EmailSummaries
+	}
+
+	if err := h.prefsRepo.Save(prefs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save preferences: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PreferencesResponse{
+		Preferences: prefs,
+	})
+}
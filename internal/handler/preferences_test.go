@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
+)
+
+func TestEmailPreferencesSuppressOnlyDisabledCategory(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	emailClient := &recordingEmailClient{}
+	authHandler, err := NewAuthHandler(cfg, emailClient)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	partnerHandler := NewPartnerHandler(cfg, emailClient, authHandler)
+	gamesHandler := NewGamesHandler(cfg, emailClient, partnerHandler)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	sender := createTestUser(t, db)
+	opponent := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, opponent, database.JSONB{"status": "completed", "winner_id": user.ID.String()}, false)
+	t.Cleanup(func() {
+		db.Where("user_id = ?", user.ID).Delete(&database.UserPreferences{})
+		db.Where("sender_id = ?", sender.ID).Delete(&database.PartnerRequest{})
+		db.Where("user_id = ?", user.ID).Delete(&database.Notification{})
+	})
+
+	updatePreferences := func(req UpdatePreferencesRequest) PreferencesResponse {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodPut, "/api/v1/users/me/preferences", req, user.ID)
+		authHandler.UpdatePreferences(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("UpdatePreferences status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response PreferencesResponse
+		decodeResponse(t, recorder, &response)
+		return response
+	}
+	emailSummary := func() int {
+		c, recorder := newTestContext(http.MethodPost, "/api/v1/plays/"+play.ID.String()+"/email-summary", nil, user.ID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		gamesHandler.EmailPlaySummary(c)
+		return recorder.Code
+	}
+
+	off := false
+	response := updatePreferences(UpdatePreferencesRequest{EmailPartnerRequests: &off})
+	if prefs := response.Preferences; prefs.EmailPartnerRequests || !prefs.EmailGameRequests || !prefs.EmailReminders || !prefs.EmailSummaries {
+		t.Errorf("preferences = %+v, want only partner request emails off", prefs)
+	}
+
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/partners/request", SendPartnerRequestRequest{Email: user.Email}, sender.ID)
+	partnerHandler.SendPartnerRequest(c)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("SendPartnerRequest status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+
+	// Summaries are still on, so only the summary is emailed
+	if code := emailSummary(); code != http.StatusOK {
+		t.Fatalf("EmailPlaySummary status = %d, want %d", code, http.StatusOK)
+	}
+	if sent := emailClient.templatedSent(1); len(sent) != 1 || sent[0] != email.TemplateGameSummary {
+		t.Errorf("sent %v, want only the game summary", sent)
+	}
+
+	updatePreferences(UpdatePreferencesRequest{EmailSummaries: &off})
+	if code := emailSummary(); code != http.StatusForbidden {
+		t.Errorf("EmailPlaySummary with summaries off status = %d, want %d", code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/games-app/backend/internal/config"
+	"github.com/games-app/backend/internal/database"
+)
+
+// maxDateRange is the widest from/to window accepted by date-filtered endpoints
+const maxDateRange = 366 * 24 * time.Hour
+
+// pageLimits is the configured page size policy shared by every list endpoint
+type pageLimits struct {
+	defaultLimit int
+	maxLimit     int
+}
+
+// newPageLimits reads the page size policy from the configuration
+func newPageLimits(cfg *config.Config) pageLimits {
+	return pageLimits{
+		defaultLimit: cfg.PageDefaultLimit,
+		maxLimit:     cfg.PageMaxLimit,
+	}
+}
+
+// parsePagination reads limit and offset query parameters
+// A missing limit uses the default and larger limits are clamped to the maximum
+func parsePagination(c *gin.Context, limits pageLimits) (database.Pagination, error) {
+	limit := limits.defaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			return database.Pagination{}, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = parsed
+	}
+	if limit > limits.maxLimit {
+		limit = limits.maxLimit
+	}
+
+	offset := 0
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			return database.Pagination{}, fmt.Errorf("offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+
+	return database.Pagination{Limit: limit, Offset: offset}, nil
+}
+
+// parseDateRange reads optional RFC3339 from and to query parameters
+func parseDateRange(c *gin.Context) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("from must be an RFC3339 timestamp")
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("to must be an RFC3339 timestamp")
+		}
+		to = &parsed
+	}
+
+	if from != nil && to != nil {
+		if from.After(*to) {
+			return nil, nil, fmt.Errorf("from must not be after to")
+		}
+		if to.Sub(*from) > maxDateRange {
+			return nil, nil, fmt.Errorf("date range cannot exceed %d days", int(maxDateRange.Hours()/24))
+		}
+	}
+
+	return from, to, nil
+}
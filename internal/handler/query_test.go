@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{name: "no range", query: ""},
+		{name: "from only", query: "from=2026-01-01T00:00:00Z", wantFrom: "2026-01-01T00:00:00Z"},
+		{name: "to only", query: "to=2026-02-01T00:00:00Z", wantTo: "2026-02-01T00:00:00Z"},
+		{
+			name:     "both bounds",
+			query:    "from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00%2B01:00",
+			wantFrom: "2026-01-01T00:00:00Z",
+			wantTo:   "2026-01-31T23:00:00Z",
+		},
+		{name: "malformed from", query: "from=2026-01-01", wantErr: true},
+		{name: "malformed to", query: "to=yesterday", wantErr: true},
+		{name: "from after to", query: "from=2026-02-01T00:00:00Z&to=2026-01-01T00:00:00Z", wantErr: true},
+		{name: "range too wide", query: "from=2024-01-01T00:00:00Z&to=2026-01-01T00:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestContext(http.MethodGet, "/api/v1/games/plays?"+tt.query, nil, uuid.Nil)
+			from, to, err := parseDateRange(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assertTime(t, "from", from, tt.wantFrom)
+			assertTime(t, "to", to, tt.wantTo)
+		})
+	}
+}
+
+// assertTime checks that got is the RFC3339 time want, or nil if want is empty
+func assertTime(t *testing.T, name string, got *time.Time, want string) {
+	t.Helper()
+
+	if want == "" {
+		if got != nil {
+			t.Errorf("%s = %v, want none", name, got)
+		}
+		return
+	}
+	wantTime, _ := time.Parse(time.RFC3339, want)
+	if got == nil || !got.Equal(wantTime) {
+		t.Errorf("%s = %v, want %v", name, got, wantTime)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	limits := newPageLimits(newTestConfig())
+
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantOffset int
+		wantErr    bool
+	}{
+		{name: "defaults", query: "", wantLimit: 20},
+		{name: "within range", query: "limit=50&offset=40", wantLimit: 50, wantOffset: 40},
+		{name: "at the maximum", query: "limit=100", wantLimit: 100},
+		{name: "above the maximum is clamped", query: "limit=1000000", wantLimit: 100},
+		{name: "smallest page", query: "limit=1", wantLimit: 1},
+		{name: "zero limit", query: "limit=0", wantErr: true},
+		{name: "negative limit", query: "limit=-5", wantErr: true},
+		{name: "non-numeric limit", query: "limit=all", wantErr: true},
+		{name: "negative offset", query: "offset=-1", wantErr: true},
+		{name: "non-numeric offset", query: "offset=next", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestContext(http.MethodGet, "/api/v1/notifications?"+tt.query, nil, uuid.Nil)
+			page, err := parsePagination(c, limits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if page.Limit != tt.wantLimit || page.Offset != tt.wantOffset {
+				t.Errorf("parsePagination() = %+v, want limit %d, offset %d", page, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParsePaginationConfiguredLimits(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PageDefaultLimit = 5
+	cfg.PageMaxLimit = 10
+	limits := newPageLimits(cfg)
+
+	for query, want := range map[string]int{"": 5, "limit=8": 8, "limit=11": 10} {
+		c, _ := newTestContext(http.MethodGet, "/api/v1/notifications?"+query, nil, uuid.Nil)
+		page, err := parsePagination(c, limits)
+		if err != nil {
+			t.Fatalf("parsePagination(%q) error = %v", query, err)
+		}
+		if page.Limit != want {
+			t.Errorf("parsePagination(%q) limit = %d, want %d", query, page.Limit, want)
+		}
+	}
+}
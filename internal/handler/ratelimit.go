@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondTooManyRequests writes a 429 with a Retry-After header telling the client how long to wait
+// The body carries the same wait as retry_after_seconds, rounded up to whole seconds and at least 1 second
+func respondTooManyRequests(c *gin.Context, message string, wait time.Duration) {
+	retryAfter := setRetryAfter(c, wait)
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":               message,
+		"retry_after_seconds": retryAfter,
+	})
+}
+
+// respondTooManyRequestsCoded writes a 429 like respondTooManyRequests, with a coded error as respondError writes it
+// so clients can tell different limits apart
+func respondTooManyRequestsCoded(c *gin.Context, code string, wait time.Duration) {
+	retryAfter := setRetryAfter(c, wait)
+	message, lang := localizedMessage(c, code)
+	c.Header("Content-Language", lang)
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":               message,
+		"code":                code,
+		"retry_after_seconds": retryAfter,
+	})
+}
+
+// setRetryAfter sets the Retry-After header for wait and returns the whole seconds it was rounded up to
+func setRetryAfter(c *gin.Context, wait time.Duration) int {
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	return retryAfter
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRespondTooManyRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		wait time.Duration
+		want int
+	}{
+		{name: "whole seconds", wait: 30 * time.Second, want: 30},
+		{name: "rounded up", wait: 1500 * time.Millisecond, want: 2},
+		{name: "at least one second", wait: 10 * time.Millisecond, want: 1},
+		{name: "already elapsed", wait: -time.Second, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodGet, "/", nil, uuid.Nil)
+			respondTooManyRequests(c, "Slow down", tt.wait)
+
+			if recorder.Code != http.StatusTooManyRequests {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+			}
+			var response struct {
+				Error             string `json:"error"`
+				RetryAfterSeconds int    `json:"retry_after_seconds"`
+			}
+			decodeResponse(t, recorder, &response)
+			if response.Error != "Slow down" || response.RetryAfterSeconds != tt.want {
+				t.Errorf("response = %+v, want the message with retry_after_seconds %d", response, tt.want)
+			}
+			if header := recorder.Header().Get("Retry-After"); header != strconv.Itoa(tt.want) {
+				t.Errorf("Retry-After = %q, want %d", header, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondTooManyRequestsCoded(t *testing.T) {
+	c, recorder := newTestContext(http.MethodGet, "/", nil, uuid.Nil)
+	c.Request.Header.Set("Accept-Language", "es")
+	respondTooManyRequestsCoded(c, errorCodeOTPTooSoon, 42*time.Second)
+
+	var response struct {
+		Error             string `json:"error"`
+		Code              string `json:"code"`
+		RetryAfterSeconds int    `json:"retry_after_seconds"`
+	}
+	decodeResponse(t, recorder, &response)
+	if response.Code != errorCodeOTPTooSoon || response.RetryAfterSeconds != 42 || response.Error != errorMessages[errorCodeOTPTooSoon]["es"] {
+		t.Errorf("response = %+v, want the Spanish %s message with retry_after_seconds 42", response, errorCodeOTPTooSoon)
+	}
+	if recorder.Header().Get("Retry-After") != "42" || recorder.Header().Get("Content-Language") != "es" {
+		t.Errorf("headers = %v, want Retry-After 42 and Content-Language es", recorder.Header())
+	}
+}
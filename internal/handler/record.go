@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// OpponentRecord is the user's results against one opponent in a game
+type OpponentRecord struct {
+	OpponentID  uuid.UUID `json:"opponent_id"`
+	DisplayName string    `json:"display_name"`
+	Wins        int64     `json:"wins"`
+	Losses      int64     `json:"losses"`
+	Draws       int64     `json:"draws"`
+}
+
+// GameRecord is the user's results in a game, in total and per opponent
+type GameRecord struct {
+	GameID    uuid.UUID        `json:"game_id"`
+	GameName  string           `json:"game_name"`
+	Wins      int64            `json:"wins"`
+	Losses    int64            `json:"losses"`
+	Draws     int64            `json:"draws"`
+	Opponents []OpponentRecord `json:"opponents"` // Most games played first
+}
+
+// GetRecordResponse represents the response for getting the user's head-to-head record
+type GetRecordResponse struct {
+	Games []GameRecord `json:"games"`
+}
+
+// GetRecord handles getting the current user's wins, losses and draws per game and opponent
+// Covers every partner the user has played, including past partnerships; practice plays are excluded
+func (h *GamesHandler) GetRecord(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	records, err := h.playRepo.FindHeadToHeadRecords(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record: " + err.Error()})
+		return
+	}
+
+	purged, err := h.playStatRepo.FindByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record: " + err.Error()})
+		return
+	}
+	records = mergePurgedRecords(records, purged)
+
+	response := GetRecordResponse{Games: []GameRecord{}}
+	if len(records) == 0 {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// Resolve opponent display names in one query
+	opponentIDs := make([]uuid.UUID, 0, len(records))
+	seen := make(map[uuid.UUID]bool)
+	for _, record := range records {
+		if !seen[record.OpponentID] {
+			seen[record.OpponentID] = true
+			opponentIDs = append(opponentIDs, record.OpponentID)
+		}
+	}
+	opponents, err := h.userRepo.FindByIDs(opponentIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch opponents: " + err.Error()})
+		return
+	}
+	names := make(map[uuid.UUID]string, len(opponents))
+	for _, opponent := range opponents {
+		names[opponent.ID] = userDisplayName(opponent)
+	}
+
+	games, err := h.gameRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch games: " + err.Error()})
+		return
+	}
+	gameNames := make(map[uuid.UUID]string, len(games))
+	for _, game := range games {
+		gameNames[game.ID] = game.Name
+	}
+
+	byGame := make(map[uuid.UUID]*GameRecord)
+	for _, record := range records {
+		gameRecord, exists := byGame[record.GameID]
+		if !exists {
+			gameRecord = &GameRecord{GameID: record.GameID, GameName: gameNames[record.GameID]}
+			byGame[record.GameID] = gameRecord
+		}
+		gameRecord.Wins += record.Wins
+		gameRecord.Losses += record.Losses
+		gameRecord.Draws += record.Draws
+		gameRecord.Opponents = append(gameRecord.Opponents, OpponentRecord{
+			OpponentID:  record.OpponentID,
+			DisplayName: names[record.OpponentID],
+			Wins:        record.Wins,
+			Losses:      record.Losses,
+			Draws:       record.Draws,
+		})
+	}
+
+	for _, gameRecord := range byGame {
+		sort.Slice(gameRecord.Opponents, func(i, j int) bool {
+			a, b := gameRecord.Opponents[i], gameRecord.Opponents[j]
+			if played, otherPlayed := a.Wins+a.Losses+a.Draws, b.Wins+b.Losses+b.Draws; played != otherPlayed {
+				return played > otherPlayed
+			}
+			return a.DisplayName < b.DisplayName
+		})
+		response.Games = append(response.Games, *gameRecord)
+	}
+	sort.Slice(response.Games, func(i, j int) bool {
+		return response.Games[i].GameName < response.Games[j].GameName
+	})
+
+	c.JSON(http.StatusOK, response)
+}
+
+// mergePurgedRecords adds the purged-play counters to the records of the remaining plays
+func mergePurgedRecords(records []database.HeadToHeadRecord, purged []database.PlayStat) []database.HeadToHeadRecord {
+	type recordKey struct{ gameID, opponentID uuid.UUID }
+	index := make(map[recordKey]int, len(records))
+	for i, record := range records {
+		index[recordKey{record.GameID, record.OpponentID}] = i
+	}
+
+	for _, stat := range purged {
+		key := recordKey{stat.GameID, stat.OpponentID}
+		i, exists := index[key]
+		if !exists {
+			records = append(records, database.HeadToHeadRecord{GameID: stat.GameID, OpponentID: stat.OpponentID})
+			i = len(records) - 1
+			index[key] = i
+		}
+		records[i].Wins += int64(stat.Wins)
+		records[i].Losses += int64(stat.Losses)
+		records[i].Draws += int64(stat.Draws)
+	}
+	return records
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestMergePurgedRecords(t *testing.T) {
+	game, opponent, newOpponent := uuid.New(), uuid.New(), uuid.New()
+	records := []database.HeadToHeadRecord{{GameID: game, OpponentID: opponent, Wins: 1, Losses: 2, Draws: 0}}
+	purged := []database.PlayStat{
+		{GameID: game, OpponentID: opponent, Wins: 3, Losses: 1, Draws: 1},
+		{GameID: game, OpponentID: newOpponent, Wins: 0, Losses: 2, Draws: 0},
+	}
+
+	got := mergePurgedRecords(records, purged)
+	want := []database.HeadToHeadRecord{
+		{GameID: game, OpponentID: opponent, Wins: 4, Losses: 3, Draws: 1},
+		{GameID: game, OpponentID: newOpponent, Wins: 0, Losses: 2, Draws: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePurgedRecords() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetRecord(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	gameA := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	gameB := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	for game, name := range map[*database.Game]string{gameA: "A " + uuid.NewString(), gameB: "B " + uuid.NewString()} {
+		if err := db.Model(game).Update("name", name).Error; err != nil {
+			t.Fatalf("failed to name game: %v", err)
+		}
+	}
+	user := createTestUser(t, db)
+	rival := createTestUser(t, db)
+	friend := createTestUser(t, db)
+	if err := db.Model(friend).Update("display_name", "Friend").Error; err != nil {
+		t.Fatalf("failed to set display name: %v", err)
+	}
+
+	completed := func(winner *database.User) database.JSONB {
+		data := database.JSONB{"status": "completed"}
+		if winner != nil {
+			data["winner_id"] = winner.ID.String()
+		}
+		return data
+	}
+	createTestPlay(t, db, gameA, user, rival, completed(user), false)
+	createTestPlay(t, db, gameA, rival, user, completed(rival), false)
+	createTestPlay(t, db, gameA, user, rival, completed(nil), false)
+	createTestPlay(t, db, gameA, friend, user, completed(user), false)
+	createTestPlay(t, db, gameB, user, rival, completed(rival), false)
+	// Unfinished and practice plays don't count
+	createTestPlay(t, db, gameB, user, friend, database.JSONB{"status": "playing"}, true)
+	practice := createTestPlay(t, db, gameB, user, friend, completed(friend), false)
+	if err := db.Model(practice).Update("is_practice", true).Error; err != nil {
+		t.Fatalf("failed to mark practice play: %v", err)
+	}
+
+	getRecord := func(userID uuid.UUID) GetRecordResponse {
+		t.Helper()
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/users/me/record", nil, userID)
+		h.GetRecord(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		var response GetRecordResponse
+		decodeResponse(t, recorder, &response)
+		return response
+	}
+
+	response := getRecord(user.ID)
+	if len(response.Games) != 2 {
+		t.Fatalf("games = %+v, want 2", response.Games)
+	}
+
+	// Games are sorted by name, opponents by games played
+	a, b := response.Games[0], response.Games[1]
+	if a.GameID != gameA.ID || a.Wins != 2 || a.Losses != 1 || a.Draws != 1 {
+		t.Errorf("first game = %+v, want %s with 2 wins, 1 loss and 1 draw", a, gameA.ID)
+	}
+	wantOpponents := []OpponentRecord{
+		{OpponentID: rival.ID, DisplayName: rival.Name, Wins: 1, Losses: 1, Draws: 1},
+		{OpponentID: friend.ID, DisplayName: "Friend", Wins: 1},
+	}
+	if !reflect.DeepEqual(a.Opponents, wantOpponents) {
+		t.Errorf("first game opponents = %+v, want %+v", a.Opponents, wantOpponents)
+	}
+	if b.GameID != gameB.ID || b.Wins != 0 || b.Losses != 1 || b.Draws != 0 || len(b.Opponents) != 1 || b.Opponents[0].OpponentID != rival.ID {
+		t.Errorf("second game = %+v, want %s with 1 loss to %s", b, gameB.ID, rival.ID)
+	}
+
+	newcomer := createTestUser(t, db)
+	if response := getRecord(newcomer.ID); response.Games == nil || len(response.Games) != 0 {
+		t.Errorf("games without completed plays = %+v, want an empty list", response.Games)
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// defaultRefreshExpiry is used when JWT_REFRESH_EXPIRY is unset or invalid
+const defaultRefreshExpiry = 30 * 24 * time.Hour
+
+// refreshExpiry returns how long newly issued refresh tokens stay valid
+func (h *AuthHandler) refreshExpiry() time.Duration {
+	expiry, err := time.ParseDuration(h.config.JWTRefreshExpiry)
+	if err != nil || expiry <= 0 {
+		return defaultRefreshExpiry
+	}
+	return expiry
+}
+
+// generateRefreshToken returns a random opaque refresh token
+// Refresh tokens aren't JWTs, so they can never be mistaken for access tokens
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// issueRefreshToken creates and stores a new refresh token for a user
+func (h *AuthHandler) issueRefreshToken(userID uuid.UUID) (string, error) {
+	token, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	if err := h.refreshRepo.Create(userID, token, time.Now().Add(h.refreshExpiry())); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RefreshTokenRequest represents the request body for refreshing an access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshTokenResponse represents the response for refreshing an access token
+// The refresh token is rotated: the one sent in the request no longer works
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken handles exchanging a refresh token for a new access token and refresh token
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	newRefreshToken, err := generateRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+		return
+	}
+
+	userID, err := h.refreshRepo.Rotate(req.RefreshToken, newRefreshToken, time.Now().Add(h.refreshExpiry()))
+	if err != nil {
+		if errors.Is(err, database.ErrRefreshTokenInvalid) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+			return
+		}
+		logging.FromContext(c).Error("failed to rotate refresh token", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	// Access tokens carry the user's current email
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+
+	token, err := h.generateJWT(user.ID, user.Email, DeviceFingerprint(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, RefreshTokenResponse{
+		Token:        token,
+		RefreshToken: newRefreshToken,
+	})
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestGenerateRefreshToken(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		token, err := generateRefreshToken()
+		if err != nil {
+			t.Fatalf("generateRefreshToken() error = %v", err)
+		}
+
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			t.Fatalf("token %q is not URL-safe base64: %v", token, err)
+		}
+		if len(raw) != 32 {
+			t.Errorf("token has %d random bytes, want 32", len(raw))
+		}
+		if seen[token] {
+			t.Fatalf("token %q generated twice", token)
+		}
+		seen[token] = true
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// RematchResponse represents the response for starting a rematch
+type RematchResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// Rematch handles starting a fresh play of a finished play's game between the same partners
+// The loser of the finished play moves first
+func (h *GamesHandler) Rematch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	if play.IsLive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Play is still in progress"})
+		return
+	}
+
+	// Bot and practice plays are started from their own endpoints
+	if play.IsPractice || play.Partner1ID == database.BotUserID || play.Partner2ID == database.BotUserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rematches are only available for plays between partners"})
+		return
+	}
+
+	opponentID := play.Partner1ID
+	if opponentID == userUUID {
+		opponentID = play.Partner2ID
+	}
+	// The players may have unpartnered since the play ended
+	if _, err := h.resolvePartnership(userUUID, opponentID.String()); err != nil {
+		respondPartnershipError(c, err)
+		return
+	}
+	if !h.allowNewLivePlay(c, play.GameID, true, userUUID, opponentID) {
+		return
+	}
+
+	// Partner1 moves first, so the loser takes that slot; draws and abandoned plays keep the original order
+	firstID, secondID := play.Partner1ID, play.Partner2ID
+	if winnerID, _ := play.PlayData["winner_id"].(string); winnerID == firstID.String() {
+		firstID, secondID = secondID, firstID
+	}
+
+	playData, err := initialPlayData(play.Game, firstID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize play: " + err.Error()})
+		return
+	}
+
+	rematch := &database.Play{
+		GameID:     play.GameID,
+		Partner1ID: firstID,
+		Partner2ID: secondID,
+		PlayData:   playData,
+		IsLive:     true,
+	}
+	// Any live play of this game between the partners ends, as when a game request is accepted
+	if err := h.playRepo.ReplaceLivePlay(rematch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+		return
+	}
+
+	// Let the opponent know the rematch started (failures shouldn't block the play)
+	gameID := play.GameID
+	notification := &database.Notification{
+		UserID:  opponentID,
+		Type:    "game_started",
+		ActorID: &userUUID,
+		GameID:  &gameID,
+		Message: "Your partner started a rematch of " + play.Game.Name,
+	}
+	if err := h.notificationRepo.Create(notification); err != nil {
+		logging.FromContext(c).Error("failed to create notification", "error", err)
+	}
+
+	rematch, err = h.playRepo.FindPlayByID(rematch.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load play"})
+		return
+	}
+	hideOpponentSecret(rematch, userUUID)
+
+	c.JSON(http.StatusOK, RematchResponse{
+		Play: rematch,
+	})
+}
@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// replayFormatVersion is bumped whenever the replay JSON layout changes incompatibly
+const replayFormatVersion = 1
+
+// Replay is a self-contained record of a completed play that can be shared and imported
+type Replay struct {
+	Version      int               `json:"version"`
+	Game         ReplayGame        `json:"game"`
+	Players      []ReplayPlayer    `json:"players"`
+	Secrets      map[string]string `json:"secrets"` // Player label (or "shared") to secret
+	Guesses      []ReplayGuess     `json:"guesses"`
+	Winner       string            `json:"winner,omitempty"` // Label of the winning player
+	TotalGuesses int               `json:"total_guesses"`
+	StartedAt    time.Time         `json:"started_at"`
+	Anonymized   bool              `json:"anonymized"`
+}
+
+// ReplayGame identifies the game a replay belongs to
+type ReplayGame struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Mode string    `json:"mode"`
+}
+
+// ReplayPlayer is a participant in a replay; ID is omitted when anonymized
+type ReplayPlayer struct {
+	Label string     `json:"label"`
+	ID    *uuid.UUID `json:"id,omitempty"`
+}
+
+// ReplayGuess is a single guess in a replay, in the order it was made
+type ReplayGuess struct {
+	Player    string `json:"player"`
+	Guess     string `json:"guess"`
+	Bulls     int    `json:"bulls"`
+	Cows      int    `json:"cows"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// GetReplayResponse represents the response for getting a play's replay
+type GetReplayResponse struct {
+	Replay     Replay `json:"replay"`
+	ShareToken string `json:"share_token,omitempty"` // Only returned to participants
+}
+
+// GetReplay handles exporting a completed play as a shareable replay
+// Participants can always fetch it; other users need the play's share_token
+// Pass anonymize=true to replace player names with "Player 1" / "Player 2"
+func (h *GamesHandler) GetReplay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Non-participants without a valid share token get the same 404 as a missing play
+	isParticipant := play.Partner1ID == userUUID || play.Partner2ID == userUUID
+	if !isParticipant {
+		shareToken := c.Query("share_token")
+		if play.ShareToken == nil || shareToken == "" ||
+			subtle.ConstantTimeCompare([]byte(shareToken), []byte(*play.ShareToken)) != 1 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Play not found"})
+			return
+		}
+	}
+
+	// Secrets are only revealed once the play is over
+	if status, _ := play.PlayData["status"].(string); status != "completed" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only completed plays can be replayed"})
+		return
+	}
+
+	fullPlay, err := h.withFullGuessHistory(play)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load guess history: " + err.Error()})
+		return
+	}
+
+	response := GetReplayResponse{
+		Replay: buildReplay(fullPlay, c.Query("anonymize") == "true"),
+	}
+
+	// Hand participants a share token, creating it on first use
+	if isParticipant {
+		if play.ShareToken == nil {
+			token, err := generateShareToken()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create share token"})
+				return
+			}
+			if err := h.playRepo.SetShareToken(play.ID, token); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save share token: " + err.Error()})
+				return
+			}
+			play.ShareToken = &token
+		}
+		response.ShareToken = *play.ShareToken
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ImportReplayRequest represents the request body for importing a replay
+type ImportReplayRequest struct {
+	Replay Replay `json:"replay" binding:"required"`
+}
+
+// ImportReplayResponse represents the response for importing a replay
+type ImportReplayResponse struct {
+	Play          *database.Play `json:"play"`
+	TargetGuesses int            `json:"target_guesses"` // Guesses the replay's winner needed
+}
+
+// ImportReplay handles creating a practice play seeded with the secret from a shared replay
+// The caller plays alone against the secret the replay's winner cracked; practice plays are unranked
+func (h *GamesHandler) ImportReplay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	var req ImportReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	replay := req.Replay
+
+	if replay.Version != replayFormatVersion {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unsupported replay version %d", replay.Version)})
+		return
+	}
+
+	game, err := h.gameRepo.FindByID(replay.Game.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	play := &database.Play{
+		GameID:     game.ID,
+		Game:       *game,
+		Partner1ID: userUUID,
+		Partner2ID: userUUID,
+		IsLive:     true,
+		IsPractice: true,
+	}
+	if !isBullsAndCows(play) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only Bulls and Cows replays can be imported"})
+		return
+	}
+
+	secret, targetGuesses, err := replayPracticeSecret(replay, secretLength(*game))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid replay: " + err.Error()})
+		return
+	}
+
+	if !h.allowNewLivePlay(c, game.ID, false, userUUID, userUUID) {
+		return
+	}
+
+	play.PlayData = database.JSONB{
+		"status":         "playing",
+		"shared_secret":  secret,
+		"guesses":        []interface{}{},
+		"target_guesses": targetGuesses,
+	}
+	if err := h.playRepo.CreatePlay(play); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create play: " + err.Error()})
+		return
+	}
+
+	play, err = h.playRepo.FindPlayByID(play.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load play"})
+		return
+	}
+
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusCreated, ImportReplayResponse{
+		Play:          play,
+		TargetGuesses: targetGuesses,
+	})
+}
+
+// replayPracticeSecret picks the secret the replay's winner guessed and checks the winner's guesses against it
+// Returns the secret and the number of guesses the winner needed
+func replayPracticeSecret(replay Replay, length int) (string, int, error) {
+	if replay.Winner == "" {
+		return "", 0, fmt.Errorf("replay has no winner")
+	}
+
+	// In dual mode the winner cracked the other player's secret
+	secret, ok := replay.Secrets["shared"]
+	if !ok {
+		for _, player := range replay.Players {
+			if player.Label != replay.Winner {
+				secret, ok = replay.Secrets[player.Label]
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", 0, fmt.Errorf("replay is missing the winning secret")
+	}
+	if err := validateSecret(secret, length); err != nil {
+		return "", 0, err
+	}
+
+	targetGuesses := 0
+	for i, guess := range replay.Guesses {
+		if guess.Player != replay.Winner {
+			continue
+		}
+		bulls, cows := calculateBullsAndCows(secret, guess.Guess)
+		if bulls != guess.Bulls || cows != guess.Cows {
+			return "", 0, fmt.Errorf("guess %d does not match the secret", i+1)
+		}
+		targetGuesses++
+	}
+	if targetGuesses == 0 {
+		return "", 0, fmt.Errorf("replay has no guesses by the winner")
+	}
+	if last := replay.Guesses[len(replay.Guesses)-1]; last.Player != replay.Winner || last.Bulls != length {
+		return "", 0, fmt.Errorf("replay does not end with the winning guess")
+	}
+
+	return secret, targetGuesses, nil
+}
+
+// buildReplay converts a completed play into its replay representation
+func buildReplay(play *database.Play, anonymize bool) Replay {
+	player1Label, player2Label := "Player 1", "Player 2"
+	if !anonymize {
+		player1Label = userDisplayName(play.Partner1)
+		player2Label = userDisplayName(play.Partner2)
+		// Keep labels distinct so guesses stay attributable
+		if player1Label == player2Label {
+			player1Label += " (1)"
+			player2Label += " (2)"
+		}
+	}
+	labels := map[string]string{
+		play.Partner1ID.String(): player1Label,
+		play.Partner2ID.String(): player2Label,
+	}
+
+	mode := bullsAndCowsModeDual
+	if usesSharedSecret(play) {
+		mode = bullsAndCowsModeShared
+	}
+
+	replay := Replay{
+		Version: replayFormatVersion,
+		Game: ReplayGame{
+			ID:   play.GameID,
+			Name: play.Game.Name,
+			Mode: mode,
+		},
+		Players: []ReplayPlayer{
+			{Label: player1Label},
+			{Label: player2Label},
+		},
+		Secrets:    make(map[string]string),
+		Guesses:    []ReplayGuess{},
+		StartedAt:  play.CreatedAt,
+		Anonymized: anonymize,
+	}
+	if !anonymize {
+		partner1ID, partner2ID := play.Partner1ID, play.Partner2ID
+		replay.Players[0].ID = &partner1ID
+		replay.Players[1].ID = &partner2ID
+	}
+
+	if secret, ok := play.PlayData["partner1_secret"].(string); ok {
+		replay.Secrets[player1Label] = secret
+	}
+	if secret, ok := play.PlayData["partner2_secret"].(string); ok {
+		replay.Secrets[player2Label] = secret
+	}
+	if secret, ok := play.PlayData["shared_secret"].(string); ok {
+		replay.Secrets["shared"] = secret
+	}
+
+	guesses, _ := play.PlayData["guesses"].([]interface{})
+	for _, g := range guesses {
+		guess, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		playerID, _ := guess["player_id"].(string)
+		value, _ := guess["guess"].(string)
+		bulls, _ := guess["bulls"].(float64)
+		cows, _ := guess["cows"].(float64)
+		timestamp, _ := guess["timestamp"].(string)
+		replay.Guesses = append(replay.Guesses, ReplayGuess{
+			Player:    labels[playerID],
+			Guess:     value,
+			Bulls:     int(bulls),
+			Cows:      int(cows),
+			Timestamp: timestamp,
+		})
+	}
+	replay.TotalGuesses = len(replay.Guesses)
+
+	if winnerID, ok := play.PlayData["winner_id"].(string); ok {
+		replay.Winner = labels[winnerID]
+	}
+
+	return replay
+}
+
+// generateShareToken generates a random token for sharing a play's replay
+func generateShareToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
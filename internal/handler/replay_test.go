@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// completedTestPlayData is a finished dual-mode play won by partner1 on the second guess
+func completedTestPlayData(partner1ID, partner2ID uuid.UUID) database.JSONB {
+	return database.JSONB{
+		"status":          "completed",
+		"partner1_secret": "1234",
+		"partner2_secret": "5678",
+		"winner_id":       partner1ID.String(),
+		"guesses": []interface{}{
+			map[string]interface{}{"player_id": partner1ID.String(), "guess": "5687", "bulls": float64(2), "cows": float64(2)},
+			map[string]interface{}{"player_id": partner2ID.String(), "guess": "1243", "bulls": float64(2), "cows": float64(2)},
+			map[string]interface{}{"player_id": partner1ID.String(), "guess": "5678", "bulls": float64(4), "cows": float64(0)},
+		},
+	}
+}
+
+func TestBuildReplay(t *testing.T) {
+	partner1 := database.User{ID: uuid.New(), Name: "Alice"}
+	partner2 := database.User{ID: uuid.New(), Name: "Bob", DisplayName: "Bobby"}
+	play := &database.Play{
+		GameID:     uuid.New(),
+		Game:       database.Game{Name: "Bulls and Cows", Details: database.JSONB{"type": "bulls_and_cows"}},
+		Partner1ID: partner1.ID,
+		Partner2ID: partner2.ID,
+		Partner1:   partner1,
+		Partner2:   partner2,
+		PlayData:   completedTestPlayData(partner1.ID, partner2.ID),
+	}
+
+	tests := []struct {
+		name      string
+		anonymize bool
+		labels    [2]string
+	}{
+		{name: "named", anonymize: false, labels: [2]string{"Alice", "Bobby"}},
+		{name: "anonymized", anonymize: true, labels: [2]string{"Player 1", "Player 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay := buildReplay(play, tt.anonymize)
+
+			if replay.Anonymized != tt.anonymize {
+				t.Errorf("Anonymized = %v, want %v", replay.Anonymized, tt.anonymize)
+			}
+			for i, player := range replay.Players {
+				if player.Label != tt.labels[i] {
+					t.Errorf("player %d label = %q, want %q", i, player.Label, tt.labels[i])
+				}
+				if (player.ID == nil) != tt.anonymize {
+					t.Errorf("player %d ID = %v, want it only when not anonymized", i, player.ID)
+				}
+			}
+			if replay.Secrets[tt.labels[0]] != "1234" || replay.Secrets[tt.labels[1]] != "5678" {
+				t.Errorf("secrets = %v, want both revealed", replay.Secrets)
+			}
+			if replay.Winner != tt.labels[0] {
+				t.Errorf("winner = %q, want %q", replay.Winner, tt.labels[0])
+			}
+			wantPlayers := []string{tt.labels[0], tt.labels[1], tt.labels[0]}
+			if replay.TotalGuesses != len(wantPlayers) || len(replay.Guesses) != len(wantPlayers) {
+				t.Fatalf("guesses = %+v, want %d", replay.Guesses, len(wantPlayers))
+			}
+			for i, guess := range replay.Guesses {
+				if guess.Player != wantPlayers[i] {
+					t.Errorf("guess %d player = %q, want %q", i, guess.Player, wantPlayers[i])
+				}
+			}
+			if last := replay.Guesses[2]; last.Guess != "5678" || last.Bulls != 4 || last.Cows != 0 {
+				t.Errorf("last guess = %+v, want 5678 with 4 bulls", last)
+			}
+		})
+	}
+}
+
+func TestBuildReplayDistinctLabels(t *testing.T) {
+	partner1 := database.User{ID: uuid.New(), Name: "Sam"}
+	partner2 := database.User{ID: uuid.New(), Name: "Sam"}
+	play := &database.Play{
+		Partner1ID: partner1.ID,
+		Partner2ID: partner2.ID,
+		Partner1:   partner1,
+		Partner2:   partner2,
+		PlayData:   completedTestPlayData(partner1.ID, partner2.ID),
+	}
+
+	replay := buildReplay(play, false)
+	if replay.Players[0].Label == replay.Players[1].Label {
+		t.Errorf("labels = %q and %q, want them distinct", replay.Players[0].Label, replay.Players[1].Label)
+	}
+}
+
+func TestGetReplayAccess(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	stranger := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, completedTestPlayData(user.ID, partner.ID), false)
+
+	getReplay := func(userID uuid.UUID, query string) (int, GetReplayResponse) {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/plays/"+play.ID.String()+"/replay"+query, nil, userID)
+		c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+		h.GetReplay(c)
+		var response GetReplayResponse
+		if recorder.Code == http.StatusOK {
+			decodeResponse(t, recorder, &response)
+		}
+		return recorder.Code, response
+	}
+
+	code, response := getReplay(user.ID, "")
+	if code != http.StatusOK {
+		t.Fatalf("participant status = %d, want %d", code, http.StatusOK)
+	}
+	if response.ShareToken == "" {
+		t.Fatal("participant did not get a share token")
+	}
+	if response.Replay.TotalGuesses != 3 {
+		t.Errorf("total guesses = %d, want 3", response.Replay.TotalGuesses)
+	}
+
+	if code, _ := getReplay(stranger.ID, ""); code != http.StatusNotFound {
+		t.Errorf("stranger without token status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code, _ := getReplay(stranger.ID, "?share_token=wrong"); code != http.StatusNotFound {
+		t.Errorf("stranger with wrong token status = %d, want %d", code, http.StatusNotFound)
+	}
+	code, shared := getReplay(stranger.ID, "?anonymize=true&share_token="+response.ShareToken)
+	if code != http.StatusOK {
+		t.Fatalf("stranger with token status = %d, want %d", code, http.StatusOK)
+	}
+	if shared.ShareToken != "" {
+		t.Error("share token returned to a non-participant")
+	}
+	if !shared.Replay.Anonymized || shared.Replay.Players[0].ID != nil {
+		t.Errorf("replay = %+v, want it anonymized", shared.Replay)
+	}
+}
+
+func TestGetReplayRequiresCompletedPlay(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing", "partner1_secret": "1234", "partner2_secret": "5678"}, true)
+
+	c, recorder := newTestContext(http.MethodGet, "/api/v1/games/plays/"+play.ID.String()+"/replay", nil, user.ID)
+	c.Params = gin.Params{{Key: "id", Value: play.ID.String()}}
+	h.GetReplay(c)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestReplayPracticeSecret(t *testing.T) {
+	partner1 := database.User{ID: uuid.New(), Name: "Alice"}
+	partner2 := database.User{ID: uuid.New(), Name: "Bob"}
+	valid := func() Replay {
+		return buildReplay(&database.Play{
+			Partner1ID: partner1.ID,
+			Partner2ID: partner2.ID,
+			Partner1:   partner1,
+			Partner2:   partner2,
+			PlayData:   completedTestPlayData(partner1.ID, partner2.ID),
+		}, true)
+	}
+
+	secret, targetGuesses, err := replayPracticeSecret(valid(), 4)
+	if err != nil {
+		t.Fatalf("replayPracticeSecret() error = %v", err)
+	}
+	// The winner cracked the other player's secret in two guesses
+	if secret != "5678" || targetGuesses != 2 {
+		t.Errorf("replayPracticeSecret() = %q, %d, want 5678, 2", secret, targetGuesses)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Replay)
+	}{
+		{name: "no winner", modify: func(r *Replay) { r.Winner = "" }},
+		{name: "missing secret", modify: func(r *Replay) { delete(r.Secrets, "Player 2") }},
+		{name: "invalid secret", modify: func(r *Replay) { r.Secrets["Player 2"] = "5578" }},
+		{name: "tampered score", modify: func(r *Replay) { r.Guesses[0].Bulls = 3 }},
+		{name: "not won", modify: func(r *Replay) { r.Guesses = r.Guesses[:2] }},
+		{name: "wrong length", modify: func(r *Replay) { r.Secrets["Player 2"] = "56789" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay := valid()
+			tt.modify(&replay)
+			if _, _, err := replayPracticeSecret(replay, 4); err == nil {
+				t.Error("replayPracticeSecret() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestImportReplay(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	game := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	importer := createTestUser(t, db)
+	original := createTestPlay(t, db, game, user, partner, completedTestPlayData(user.ID, partner.ID), false)
+
+	original.Game = *game
+	replay := buildReplay(original, true)
+	c, recorder := newTestContext(http.MethodPost, "/api/v1/games/plays/import", ImportReplayRequest{Replay: replay}, importer.ID)
+	h.ImportReplay(c)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusCreated, recorder.Body.String())
+	}
+
+	var response ImportReplayResponse
+	decodeResponse(t, recorder, &response)
+	if response.TargetGuesses != 2 {
+		t.Errorf("target guesses = %d, want 2", response.TargetGuesses)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(&database.Play{}, "id = ?", response.Play.ID) })
+
+	var stored database.Play
+	if err := db.First(&stored, "id = ?", response.Play.ID).Error; err != nil {
+		t.Fatalf("failed to load imported play: %v", err)
+	}
+	if !stored.IsPractice || !stored.IsLive {
+		t.Errorf("imported play practice = %v, live = %v, want a live practice play", stored.IsPractice, stored.IsLive)
+	}
+	if stored.Partner1ID != importer.ID || stored.Partner2ID != importer.ID {
+		t.Errorf("imported play players = %s, %s, want the importer", stored.Partner1ID, stored.Partner2ID)
+	}
+	if secret := stored.PlayData["shared_secret"]; secret != "5678" {
+		t.Errorf("shared secret = %v, want 5678", secret)
+	}
+	if secret := response.Play.PlayData["shared_secret"]; secret != nil {
+		t.Error("imported secret revealed in the response")
+	}
+
+	replay.Guesses[0].Cows = 0
+	c, recorder = newTestContext(http.MethodPost, "/api/v1/games/plays/import", ImportReplayRequest{Replay: replay}, importer.ID)
+	h.ImportReplay(c)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("tampered replay status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// ResumablePlayResponse represents the response for getting the play to resume
+// Play is null when the user has no live play
+type ResumablePlayResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// GetResumablePlay handles getting the current user's most recently updated live play, across all partnerships and games
+func (h *GamesHandler) GetResumablePlay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	play, err := h.playRepo.FindMostRecentLivePlayByUser(userUUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusOK, ResumablePlayResponse{})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch play: " + err.Error()})
+		return
+	}
+
+	// Never expose the opponent's secret for unfinished plays
+	hideOpponentSecret(play, userUUID)
+
+	c.JSON(http.StatusOK, ResumablePlayResponse{
+		Play: play,
+	})
+}
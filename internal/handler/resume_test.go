@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestGetResumablePlay(t *testing.T) {
+	db := openTestDB(t)
+	h := NewGamesHandler(newTestConfig(), nil, nil)
+	firstGame := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	secondGame := createTestGame(t, db, database.JSONB{"type": "bulls_and_cows"})
+	user := createTestUser(t, db)
+	partnerA := createTestUser(t, db)
+	partnerB := createTestUser(t, db)
+
+	resume := func() (string, ResumablePlayResponse) {
+		c, recorder := newTestContext(http.MethodGet, "/api/v1/games/resume", nil, user.ID)
+		h.GetResumablePlay(c)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+		}
+		body := recorder.Body.String()
+		var response ResumablePlayResponse
+		decodeResponse(t, recorder, &response)
+		return body, response
+	}
+
+	// Nothing to resume is not an error
+	body, response := resume()
+	if response.Play != nil || !strings.Contains(body, `"play":null`) {
+		t.Errorf("response = %s, want a null play", body)
+	}
+
+	touch := func(play *database.Play, age time.Duration) {
+		if err := db.Model(play).UpdateColumn("updated_at", time.Now().Add(-age)).Error; err != nil {
+			t.Fatalf("failed to set updated_at: %v", err)
+		}
+	}
+	older := createTestPlay(t, db, firstGame, user, partnerA, database.JSONB{"status": "playing"}, true)
+	touch(older, 2*time.Hour)
+	// The user is the second partner here, with another partner and game
+	newer := createTestPlay(t, db, secondGame, partnerB, user, database.JSONB{
+		"status":          "playing",
+		"partner1_secret": "1234",
+		"partner2_secret": "5678",
+	}, true)
+	touch(newer, time.Hour)
+	ended := createTestPlay(t, db, firstGame, user, partnerB, database.JSONB{"status": "completed"}, false)
+	touch(ended, time.Minute)
+
+	_, response = resume()
+	if response.Play == nil || response.Play.ID != newer.ID {
+		t.Fatalf("play = %+v, want the most recently updated live play %s", response.Play, newer.ID)
+	}
+	if secret := response.Play.PlayData["partner1_secret"]; secret != nil {
+		t.Errorf("opponent's secret revealed: %v", secret)
+	}
+	if secret := response.Play.PlayData["partner2_secret"]; secret != "5678" {
+		t.Errorf("own secret = %v, want 5678", secret)
+	}
+
+	// Playing the older one makes it the one to resume
+	touch(older, 0)
+	if _, response := resume(); response.Play == nil || response.Play.ID != older.ID {
+		t.Errorf("play = %+v, want %s after it was updated", response.Play, older.ID)
+	}
+}
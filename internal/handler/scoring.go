@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// Default Bulls and Cows scoring, overridable per game via details
+// "score_base", "score_guess_penalty" and "score_minute_penalty"
+const (
+	defaultScoreBase          = 1000
+	defaultScoreGuessPenalty  = 50
+	defaultScoreMinutePenalty = 10
+)
+
+// scorePlay computes the winner's score for a completed play using its game's scoring rules
+// Returns false if the game has no scoring rules or the play is an unranked practice play
+func scorePlay(play *database.Play, winnerID uuid.UUID) (int, bool) {
+	if play.IsPractice {
+		return 0, false
+	}
+	if isBullsAndCows(play) {
+		return scoreBullsAndCows(play, winnerID), true
+	}
+	return 0, false
+}
+
+// scoreBullsAndCows rewards winning in fewer guesses and less (unpaused) time
+// The score only depends on the stored play data, so recomputing it gives the same result
+func scoreBullsAndCows(play *database.Play, winnerID uuid.UUID) int {
+	base := gameDetailIntOr(play.Game, "score_base", defaultScoreBase)
+	guessPenalty := gameDetailIntOr(play.Game, "score_guess_penalty", defaultScoreGuessPenalty)
+	minutePenalty := gameDetailIntOr(play.Game, "score_minute_penalty", defaultScoreMinutePenalty)
+
+	winnerGuesses := 0
+	guesses, _ := play.PlayData["guesses"].([]interface{})
+	for _, g := range guesses {
+		if guess, ok := g.(map[string]interface{}); ok && guess["player_id"] == winnerID.String() {
+			winnerGuesses++
+		}
+	}
+
+	minutes := 0
+	if wonAt, ok := lastGuessTime(play.PlayData, winnerID); ok {
+		seconds := wonAt.Sub(play.CreatedAt).Seconds() - pausedSeconds(play.PlayData)
+		if seconds > 0 {
+			minutes = int(seconds / 60)
+		}
+	}
+
+	// The first guess is free
+	score := base
+	if winnerGuesses > 1 {
+		score -= (winnerGuesses - 1) * guessPenalty
+	}
+	score -= minutes * minutePenalty
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// gameDetailIntOr returns a non-negative integer from the game's details, or defaultValue if it isn't set
+func gameDetailIntOr(game database.Game, key string, defaultValue int) int {
+	if _, exists := game.Details[key]; !exists {
+		return defaultValue
+	}
+	return gameDetailInt(game, key)
+}
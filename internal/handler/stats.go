@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// PlayTotals counts a user's completed plays by outcome
+type PlayTotals struct {
+	Played int `json:"played"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"` // Completed without a winner
+}
+
+// add counts one completed play with the given winner ("" for a draw)
+func (t *PlayTotals) add(winnerID string, userID uuid.UUID) {
+	t.Played++
+	switch winnerID {
+	case "":
+		t.Draws++
+	case userID.String():
+		t.Wins++
+	default:
+		t.Losses++
+	}
+}
+
+// addPurged counts the plays kept in a purged-play counter
+func (t *PlayTotals) addPurged(stat database.PlayStat) {
+	t.Played += stat.Plays
+	t.Wins += stat.Wins
+	t.Losses += stat.Losses
+	t.Draws += stat.Draws
+}
+
+// GetStatsResponse represents the response for getting the current user's play statistics
+type GetStatsResponse struct {
+	PlayTotals
+	Games map[uuid.UUID]*PlayTotals `json:"games"` // Totals per game ID
+}
+
+// GetStats handles getting the current user's completed play totals, overall and per game
+func (h *GamesHandler) GetStats(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	plays, err := h.playRepo.FindCompletedPlaysByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plays: " + err.Error()})
+		return
+	}
+
+	purged, err := h.playStatRepo.FindByUser(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stats: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, computeUserStats(userUUID, plays, purged))
+}
+
+// computeUserStats tallies completed plays and purged-play counters into overall and per-game totals for the user
+func computeUserStats(userID uuid.UUID, plays []database.Play, purged []database.PlayStat) GetStatsResponse {
+	stats := GetStatsResponse{
+		Games: make(map[uuid.UUID]*PlayTotals),
+	}
+	for _, play := range plays {
+		winnerID, _ := play.PlayData["winner_id"].(string)
+		stats.add(winnerID, userID)
+
+		game, exists := stats.Games[play.GameID]
+		if !exists {
+			game = &PlayTotals{}
+			stats.Games[play.GameID] = game
+		}
+		game.add(winnerID, userID)
+	}
+	for _, stat := range purged {
+		stats.addPurged(stat)
+
+		game, exists := stats.Games[stat.GameID]
+		if !exists {
+			game = &PlayTotals{}
+			stats.Games[stat.GameID] = game
+		}
+		game.addPurged(stat)
+	}
+	return stats
+}
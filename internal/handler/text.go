@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/games-app/backend/internal/config"
+)
+
+// textPolicy is the configured cleaning and profanity policy applied to all user-supplied text
+type textPolicy struct {
+	blockedWords map[string]bool // Lowercased blocked words; nil when the profanity filter is disabled
+}
+
+// newTextPolicy reads the profanity filter settings from the configuration
+func newTextPolicy(cfg *config.Config) textPolicy {
+	if !cfg.ProfanityFilterEnabled || len(cfg.ProfanityWords) == 0 {
+		return textPolicy{}
+	}
+
+	blockedWords := make(map[string]bool, len(cfg.ProfanityWords))
+	for _, word := range cfg.ProfanityWords {
+		blockedWords[strings.ToLower(word)] = true
+	}
+	return textPolicy{blockedWords: blockedWords}
+}
+
+// textRules describes one kind of user text
+type textRules struct {
+	field         string // Name used in error messages
+	maxLength     int    // Most characters allowed after cleaning
+	required      bool   // Reject text that is empty after cleaning
+	allowNewlines bool   // Keep line breaks instead of stripping them with other control characters
+}
+
+// isZeroWidth reports whether r is an invisible character that could hide text from filters or impersonate names
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+		return true
+	}
+	return false
+}
+
+// sanitize trims text and strips control and zero-width characters, then checks it against the rules
+// and the profanity filter
+// The returned error is suitable to show to the client
+func (p textPolicy) sanitize(text string, rules textRules) (string, error) {
+	cleaned := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == '\n' && rules.allowNewlines {
+			return r
+		}
+		if unicode.IsControl(r) || isZeroWidth(r) {
+			return -1
+		}
+		return r
+	}, text))
+
+	if cleaned == "" && rules.required {
+		return "", fmt.Errorf("%s cannot be empty", rules.field)
+	}
+	if utf8.RuneCountInString(cleaned) > rules.maxLength {
+		return "", fmt.Errorf("%s cannot exceed %d characters", rules.field, rules.maxLength)
+	}
+	if p.containsBlockedWord(cleaned) {
+		return "", fmt.Errorf("%s contains language that isn't allowed", rules.field)
+	}
+	return cleaned, nil
+}
+
+// containsBlockedWord reports whether any whole word of text is on the blocked list
+func (p textPolicy) containsBlockedWord(text string) bool {
+	if p.blockedWords == nil {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		if p.blockedWords[word] {
+			return true
+		}
+	}
+	return false
+}
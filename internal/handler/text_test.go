@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestSanitize(t *testing.T) {
+	policy := newTextPolicy(newTestConfig())
+	nameRules := textRules{field: "name", maxLength: 10, required: true}
+	messageRules := textRules{field: "message", maxLength: 10, allowNewlines: true}
+
+	tests := []struct {
+		name    string
+		text    string
+		rules   textRules
+		want    string
+		wantErr bool
+	}{
+		{name: "trimmed", text: "  Alex \t", rules: nameRules, want: "Alex"},
+		{name: "control characters", text: "Al\x00e\x1bx\u0085", rules: nameRules, want: "Alex"},
+		{name: "zero-width characters", text: "A\u200bl\u200ce\u200dx\u2060\ufeff", rules: nameRules, want: "Alex"},
+		{name: "newlines stripped", text: "Al\nex", rules: nameRules, want: "Alex"},
+		{name: "newlines kept", text: "hi\nthere", rules: messageRules, want: "hi\nthere"},
+		{name: "at the limit", text: strings.Repeat("é", 10), rules: nameRules, want: strings.Repeat("é", 10)},
+		{name: "over the limit", text: strings.Repeat("a", 11), rules: nameRules, wantErr: true},
+		{name: "limit counts cleaned text", text: " " + strings.Repeat("a", 10) + "\u200b ", rules: nameRules, want: strings.Repeat("a", 10)},
+		{name: "empty when required", text: " \u200b\x00 ", rules: nameRules, wantErr: true},
+		{name: "empty when optional", text: "  ", rules: messageRules, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.sanitize(tt.text, tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sanitize(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("sanitize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeProfanity(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ProfanityWords = []string{"Darn"}
+	rules := textRules{field: "message", maxLength: 100}
+
+	// The word list does nothing until the filter is enabled
+	if _, err := newTextPolicy(cfg).sanitize("darn it", rules); err != nil {
+		t.Errorf("sanitize() with the filter disabled error = %v, want nil", err)
+	}
+
+	cfg.ProfanityFilterEnabled = true
+	policy := newTextPolicy(cfg)
+	tests := []struct {
+		text    string
+		blocked bool
+	}{
+		{text: "darn it", blocked: true},
+		{text: "DARN!", blocked: true},
+		{text: "well,darn", blocked: true},
+		{text: "d\u200barn", blocked: true}, // Zero-width characters can't hide a word
+		{text: "darned socks"},              // Only whole words are blocked
+		{text: "nothing to see"},
+	}
+
+	for _, tt := range tests {
+		_, err := policy.sanitize(tt.text, rules)
+		if tt.blocked && (err == nil || !strings.Contains(err.Error(), "message")) {
+			t.Errorf("sanitize(%q) error = %v, want an error naming the field", tt.text, err)
+		}
+		if !tt.blocked && err != nil {
+			t.Errorf("sanitize(%q) error = %v, want nil", tt.text, err)
+		}
+	}
+}
+
+func TestProfanityFilterAppliedToUserText(t *testing.T) {
+	db := openTestDB(t)
+	cfg := newTestConfig()
+	cfg.ProfanityFilterEnabled = true
+	cfg.ProfanityWords = []string{"darn"}
+	authHandler, err := NewAuthHandler(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewAuthHandler() error = %v", err)
+	}
+	gamesHandler := NewGamesHandler(cfg, nil, nil)
+	game := createTestGame(t, db, database.JSONB{})
+	user := createTestUser(t, db)
+	partner := createTestUser(t, db)
+	play := createTestPlay(t, db, game, user, partner, database.JSONB{"status": "playing"}, true)
+	t.Cleanup(func() {
+		db.Where("play_id = ?", play.ID).Delete(&database.PlayMessage{})
+		db.Where("user_id = ?", partner.ID).Delete(&database.Notification{})
+	})
+
+	displayName := "Darn Player"
+	c, recorder := newTestContext(http.MethodPatch, "/api/v1/users/me", PatchProfileRequest{DisplayName: &displayName}, user.ID)
+	authHandler.PatchProfile(c)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("PatchProfile() with a blocked word status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+
+	if code := sendTestPlayMessage(t, gamesHandler, play, user.ID, "oh darn"); code != http.StatusBadRequest {
+		t.Errorf("SendPlayMessage() with a blocked word status = %d, want %d", code, http.StatusBadRequest)
+	}
+	if code := sendTestPlayMessage(t, gamesHandler, play, user.ID, "oh dear"); code != http.StatusOK {
+		t.Errorf("SendPlayMessage() status = %d, want %d", code, http.StatusOK)
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+// ticTacToeGameID is the ID of the seeded Tic-Tac-Toe game
+const ticTacToeGameID = "550e8400-e29b-41d4-a716-446655440002"
+
+// Tic-Tac-Toe marks; partner 1 plays X and moves first
+const (
+	ticTacToeMarkX = "X"
+	ticTacToeMarkO = "O"
+)
+
+// ticTacToeLines are the rows, columns and diagonals that win when one mark holds all three cells
+var ticTacToeLines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// isTicTacToe reports whether a play is a Tic-Tac-Toe game
+func isTicTacToe(play *database.Play) bool {
+	if play.GameID.String() == ticTacToeGameID {
+		return true
+	}
+	gameType, _ := play.Game.Details["type"].(string)
+	return gameType == "tic_tac_toe"
+}
+
+// initialTicTacToeData returns the play data of a new Tic-Tac-Toe play: an empty board with the first player to move
+// Empty cells are empty strings; marked cells hold the mark
+func initialTicTacToeData(firstPlayerID uuid.UUID) database.JSONB {
+	board := make([]interface{}, 9)
+	for i := range board {
+		board[i] = ""
+	}
+	return database.JSONB{
+		"status":       "playing",
+		"board":        board,
+		"current_turn": firstPlayerID.String(),
+		"moves":        []interface{}{},
+	}
+}
+
+// ticTacToeBoard reads the board from play data
+// Returns false if the board is missing or malformed
+func ticTacToeBoard(playData database.JSONB) ([9]string, bool) {
+	var board [9]string
+	cells, ok := playData["board"].([]interface{})
+	if !ok || len(cells) != len(board) {
+		return board, false
+	}
+	for i, cell := range cells {
+		mark, ok := cell.(string)
+		if !ok || (mark != "" && mark != ticTacToeMarkX && mark != ticTacToeMarkO) {
+			return board, false
+		}
+		board[i] = mark
+	}
+	return board, true
+}
+
+// ticTacToeWinner returns the mark holding a complete line, or "" if neither does
+func ticTacToeWinner(board [9]string) string {
+	for _, line := range ticTacToeLines {
+		mark := board[line[0]]
+		if mark != "" && board[line[1]] == mark && board[line[2]] == mark {
+			return mark
+		}
+	}
+	return ""
+}
+
+// ticTacToeBoardFull reports whether every cell is marked
+func ticTacToeBoardFull(board [9]string) bool {
+	for _, mark := range board {
+		if mark == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// MakeMoveRequest represents the request body for making a Tic-Tac-Toe move
+type MakeMoveRequest struct {
+	Cell *int `json:"cell" binding:"required,min=0,max=8"` // Cells are numbered 0-8 left to right, top to bottom
+}
+
+// MakeMoveResponse represents the response for making a Tic-Tac-Toe move
+type MakeMoveResponse struct {
+	Play *database.Play `json:"play"`
+}
+
+// MakeMove handles marking a cell in Tic-Tac-Toe
+func (h *GamesHandler) MakeMove(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+		return
+	}
+
+	playIDStr := c.Param("id")
+	playID, err := uuid.Parse(playIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid play ID"})
+		return
+	}
+
+	var req MakeMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Serialize mutations of this play so concurrent requests don't lose updates
+	unlock := lockPlay(playID)
+	defer unlock()
+
+	// Get play
+	play, err := h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		respondPlayLookupError(c, err, "Play not found")
+		return
+	}
+
+	// Verify user is part of this play
+	if play.Partner1ID != userUUID && play.Partner2ID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this play"})
+		return
+	}
+
+	if !isTicTacToe(play) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This game doesn't use moves"})
+		return
+	}
+
+	// No moves can be made while the play is paused
+	if isPlayPaused(play.PlayData) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Play is paused"})
+		return
+	}
+
+	playData := play.PlayData
+	if status, _ := playData["status"].(string); status != "playing" || !play.IsLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is not in playing state"})
+		return
+	}
+
+	if currentTurn, _ := playData["current_turn"].(string); currentTurn != userUUID.String() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "It's not your turn"})
+		return
+	}
+
+	board, ok := ticTacToeBoard(playData)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game state"})
+		return
+	}
+
+	cell := *req.Cell
+	if board[cell] != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "That cell is already taken"})
+		return
+	}
+
+	mark, opponentID := ticTacToeMarkX, play.Partner2ID
+	if play.Partner2ID == userUUID {
+		mark, opponentID = ticTacToeMarkO, play.Partner1ID
+	}
+	board[cell] = mark
+
+	cells := make([]interface{}, len(board))
+	for i, m := range board {
+		cells[i] = m
+	}
+	playData["board"] = cells
+
+	moves, _ := playData["moves"].([]interface{})
+	playData["moves"] = append(moves, map[string]interface{}{
+		"player_id": userUUID.String(),
+		"cell":      cell,
+		"mark":      mark,
+		"timestamp": time.Now().Format(time.RFC3339Nano),
+	})
+
+	if ticTacToeWinner(board) == mark {
+		playData["status"] = "completed"
+		playData["winner_id"] = userUUID.String()
+		playData["result"] = database.PlayResult(database.PlayOutcomeWin, database.PlayEndReasonThreeInARow, &userUUID)
+		play.IsLive = false
+	} else if ticTacToeBoardFull(board) {
+		playData["status"] = "completed"
+		playData["result"] = database.PlayResult(database.PlayOutcomeDraw, database.PlayEndReasonBoardFull, nil)
+		play.IsLive = false
+	} else {
+		playData["current_turn"] = opponentID.String()
+	}
+
+	// The play lock only covers this process, so the save also checks it's still this user's turn
+	play.PlayData = playData
+	if err := h.playRepo.UpdatePlayOnTurn(play, userUUID); err != nil {
+		respondPlayUpdateError(c, err, "Failed to update play")
+		return
+	}
+	h.playHub.publish(play.ID)
+
+	// Reload play
+	play, err = h.playRepo.FindPlayByID(playID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload play"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MakeMoveResponse{
+		Play: play,
+	})
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// GameRequestSweepInterval is how often pending game requests are swept
+const GameRequestSweepInterval = time.Minute
+
+// gameRequestSweepJob names the sweep in logs and the job registry
+const gameRequestSweepJob = "game_request_sweep"
+
+// StartGameRequestSweep periodically expires lapsed game requests and reminds partners of
+// requests expiring within reminderMinutes
+// Reminders are disabled if reminderMinutes is 0; expiry always runs
+func StartGameRequestSweep(gameRequestRepo *database.GameRequestRepository, notificationRepo *database.NotificationRepository, reminderMinutes int) {
+	logger := logging.Component(gameRequestSweepJob)
+	register(gameRequestSweepJob, GameRequestSweepInterval)
+
+	go func() {
+		ticker := time.NewTicker(GameRequestSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			err := gameRequestRepo.ExpireOldRequests()
+			if err != nil {
+				logger.Error("failed to expire game requests", "error", err)
+			}
+			if reminderMinutes > 0 {
+				if _, reminderErr := SendGameRequestReminders(gameRequestRepo, notificationRepo, time.Duration(reminderMinutes)*time.Minute); reminderErr != nil {
+					err = reminderErr
+				}
+			}
+			recordRun(gameRequestSweepJob, err)
+			<-ticker.C
+		}
+	}()
+}
+
+// SendGameRequestReminders notifies partners of pending requests expiring within the window once and
+// returns how many reminders were sent
+// Requests are claimed before notifying, so a reminder is never sent twice (a failed one is not retried)
+func SendGameRequestReminders(gameRequestRepo *database.GameRequestRepository, notificationRepo *database.NotificationRepository, window time.Duration) (int, error) {
+	logger := logging.Component(gameRequestSweepJob)
+
+	requests, err := gameRequestRepo.ClaimRequestsForReminder(window)
+	if err != nil {
+		logger.Error("failed to claim game requests for reminders", "error", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, request := range requests {
+		requesterID := request.RequesterID
+		gameID := request.GameID
+		notification := &database.Notification{
+			UserID:  request.PartnerID,
+			Type:    "game_request_reminder",
+			ActorID: &requesterID,
+			GameID:  &gameID,
+			Message: "Your partner's request to play " + request.Game.Name + " expires soon",
+		}
+		if err := notificationRepo.Create(notification); err != nil {
+			logger.Error("failed to create reminder notification", "game_request_id", request.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		logger.Info("sent game request reminders", "count", sent)
+	}
+	return sent, nil
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// RefreshTokenPurgeInterval is how often expired refresh tokens are purged
+const RefreshTokenPurgeInterval = time.Hour
+
+// refreshTokenPurgeJob names the purge in logs and the job registry
+const refreshTokenPurgeJob = "refresh_token_purge"
+
+// StartRefreshTokenPurge periodically deletes refresh tokens that have expired
+func StartRefreshTokenPurge(refreshTokenRepo *database.RefreshTokenRepository) {
+	logger := logging.Component(refreshTokenPurgeJob)
+	register(refreshTokenPurgeJob, RefreshTokenPurgeInterval)
+
+	go func() {
+		ticker := time.NewTicker(RefreshTokenPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := refreshTokenRepo.PurgeExpired()
+			if err != nil {
+				logger.Error("failed to purge refresh tokens", "error", err)
+			} else if purged > 0 {
+				logger.Info("purged refresh tokens", "count", purged)
+			}
+			recordRun(refreshTokenPurgeJob, err)
+			<-ticker.C
+		}
+	}()
+}
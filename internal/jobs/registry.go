@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// staleAfterIntervals is how many missed intervals make a job stale
+const staleAfterIntervals = 2
+
+// JobStatus reports a background job's recent runs
+type JobStatus struct {
+	Name            string     `json:"name"`
+	IntervalSeconds int64      `json:"interval_seconds"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+	LastSuccessAt   *time.Time `json:"last_success_at"`
+	LastError       string     `json:"last_error,omitempty"` // Error of the last run, if it failed
+	Healthy         bool       `json:"healthy"`              // Succeeded within staleAfterIntervals intervals
+}
+
+// jobState is the registry's record of a running job
+type jobState struct {
+	interval      time.Duration
+	registeredAt  time.Time
+	lastRunAt     time.Time
+	lastSuccessAt time.Time
+	lastError     string
+}
+
+// registry holds the state of every started job
+var registry = struct {
+	mu   sync.Mutex
+	jobs map[string]*jobState
+}{jobs: make(map[string]*jobState)}
+
+// register adds a job to the registry when it starts
+func register(name string, interval time.Duration) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.jobs[name] = &jobState{
+		interval:     interval,
+		registeredAt: time.Now(),
+	}
+}
+
+// recordRun records the outcome of one run of a registered job
+func recordRun(name string, err error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	job, exists := registry.jobs[name]
+	if !exists {
+		return
+	}
+	job.lastRunAt = time.Now()
+	if err != nil {
+		job.lastError = err.Error()
+		return
+	}
+	job.lastSuccessAt = job.lastRunAt
+	job.lastError = ""
+}
+
+// Statuses returns the status of every started job, sorted by name
+// A job is unhealthy once it hasn't succeeded for staleAfterIntervals intervals (counted from
+// its start if it never has)
+func Statuses() []JobStatus {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]JobStatus, 0, len(registry.jobs))
+	for name, job := range registry.jobs {
+		status := JobStatus{
+			Name:            name,
+			IntervalSeconds: int64(job.interval.Seconds()),
+			LastError:       job.lastError,
+		}
+		if !job.lastRunAt.IsZero() {
+			lastRunAt := job.lastRunAt
+			status.LastRunAt = &lastRunAt
+		}
+
+		healthySince := job.registeredAt
+		if !job.lastSuccessAt.IsZero() {
+			lastSuccessAt := job.lastSuccessAt
+			status.LastSuccessAt = &lastSuccessAt
+			healthySince = lastSuccessAt
+		}
+		status.Healthy = now.Sub(healthySince) <= staleAfterIntervals*job.interval
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// registerTestJob registers a job with its timestamps moved back by the given ages, removed when the test finishes
+// A zero age leaves the timestamp unset
+func registerTestJob(t *testing.T, name string, interval, registeredAgo, lastSuccessAgo time.Duration) {
+	t.Helper()
+
+	register(name, interval)
+	registry.mu.Lock()
+	job := registry.jobs[name]
+	job.registeredAt = time.Now().Add(-registeredAgo)
+	if lastSuccessAgo > 0 {
+		job.lastRunAt = time.Now().Add(-lastSuccessAgo)
+		job.lastSuccessAt = job.lastRunAt
+	}
+	registry.mu.Unlock()
+
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		delete(registry.jobs, name)
+	})
+}
+
+// findStatus returns the named job's status, failing the test if it isn't reported
+func findStatus(t *testing.T, name string) JobStatus {
+	t.Helper()
+
+	for _, status := range Statuses() {
+		if status.Name == name {
+			return status
+		}
+	}
+	t.Fatalf("job %s not reported", name)
+	return JobStatus{}
+}
+
+func TestStatusesHealth(t *testing.T) {
+	interval := time.Hour
+	tests := []struct {
+		name           string
+		registeredAgo  time.Duration
+		lastSuccessAgo time.Duration
+		want           bool
+	}{
+		{name: "test-just-started", registeredAgo: time.Minute, want: true},
+		{name: "test-never-succeeded", registeredAgo: 3 * interval, want: false},
+		{name: "test-recent-success", registeredAgo: 10 * interval, lastSuccessAgo: interval, want: true},
+		{name: "test-stale-success", registeredAgo: 10 * interval, lastSuccessAgo: 3 * interval, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registerTestJob(t, tt.name, interval, tt.registeredAgo, tt.lastSuccessAgo)
+
+			status := findStatus(t, tt.name)
+			if status.Healthy != tt.want {
+				t.Errorf("Healthy = %v, want %v", status.Healthy, tt.want)
+			}
+			if status.IntervalSeconds != int64(interval.Seconds()) {
+				t.Errorf("IntervalSeconds = %d, want %d", status.IntervalSeconds, int64(interval.Seconds()))
+			}
+			if (status.LastSuccessAt != nil) != (tt.lastSuccessAgo > 0) {
+				t.Errorf("LastSuccessAt = %v, want it set only after a success", status.LastSuccessAt)
+			}
+		})
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	name := "test-record-run"
+	registerTestJob(t, name, time.Hour, 3*time.Hour, 0)
+
+	// A failed run doesn't make a job that never succeeded healthy
+	recordRun(name, errors.New("database unavailable"))
+	status := findStatus(t, name)
+	if status.Healthy || status.LastRunAt == nil || status.LastSuccessAt != nil || status.LastError != "database unavailable" {
+		t.Errorf("status after a failure = %+v, want an unhealthy run with the error", status)
+	}
+
+	recordRun(name, nil)
+	status = findStatus(t, name)
+	if !status.Healthy || status.LastSuccessAt == nil || status.LastError != "" {
+		t.Errorf("status after a success = %+v, want a healthy run without an error", status)
+	}
+
+	// Runs of jobs that were never started are ignored
+	recordRun("test-unregistered", nil)
+	for _, status := range Statuses() {
+		if status.Name == "test-unregistered" {
+			t.Error("unregistered job reported")
+		}
+	}
+}
+
+func TestStatusesSortedByName(t *testing.T) {
+	for _, name := range []string{"test-sort-c", "test-sort-a", "test-sort-b"} {
+		registerTestJob(t, name, time.Hour, 0, 0)
+	}
+
+	statuses := Statuses()
+	for i := 1; i < len(statuses); i++ {
+		if statuses[i-1].Name > statuses[i].Name {
+			t.Errorf("statuses out of order: %s before %s", statuses[i-1].Name, statuses[i].Name)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// PlayRetentionInterval is how often the retention job runs
+const PlayRetentionInterval = 24 * time.Hour
+
+// playRetentionJob names the retention job in logs and the job registry
+const playRetentionJob = "play_retention"
+
+// StartPlayRetention periodically purges ended plays older than retentionDays
+// Does nothing if retentionDays is 0 (keep forever)
+func StartPlayRetention(playRepo *database.PlayRepository, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+
+	register(playRetentionJob, PlayRetentionInterval)
+
+	go func() {
+		ticker := time.NewTicker(PlayRetentionInterval)
+		defer ticker.Stop()
+
+		for {
+			_, err := PurgeExpiredPlays(playRepo, retentionDays)
+			recordRun(playRetentionJob, err)
+			<-ticker.C
+		}
+	}()
+}
+
+// PurgeExpiredPlays purges ended plays older than retentionDays once and returns how many were purged
+func PurgeExpiredPlays(playRepo *database.PlayRepository, retentionDays int) (int64, error) {
+	logger := logging.Component(playRetentionJob)
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	purged, err := playRepo.PurgeEndedPlaysBefore(cutoff)
+	if err != nil {
+		logger.Error("failed to purge plays", "error", err)
+		return 0, err
+	}
+	logger.Info("purged plays", "count", purged, "ended_before", cutoff.Format(time.RFC3339))
+	return purged, nil
+}
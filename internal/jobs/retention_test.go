@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/games-app/backend/internal/database"
+)
+
+func TestPurgeExpiredPlaysLogsFailures(t *testing.T) {
+	// Nothing listens on port 1, so every query fails without a database
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1"), &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	if _, err := PurgeExpiredPlays(database.NewPlayRepository(db), 30); err == nil {
+		t.Fatal("PurgeExpiredPlays() succeeded without a database, want an error")
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record %q: %v", logs.String(), err)
+	}
+	if record["level"] != "ERROR" || record["component"] != playRetentionJob || record["error"] == nil {
+		t.Errorf("log record = %v, want an ERROR from component %s with the error", record, playRetentionJob)
+	}
+}
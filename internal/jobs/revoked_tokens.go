@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/logging"
+)
+
+// RevokedTokenPurgeInterval is how often expired denylist entries are purged
+const RevokedTokenPurgeInterval = time.Hour
+
+// revokedTokenPurgeJob names the purge in logs and the job registry
+const revokedTokenPurgeJob = "revoked_token_purge"
+
+// StartRevokedTokenPurge periodically deletes denylist entries for tokens that have expired
+func StartRevokedTokenPurge(revokedTokenRepo *database.RevokedTokenRepository) {
+	logger := logging.Component(revokedTokenPurgeJob)
+	register(revokedTokenPurgeJob, RevokedTokenPurgeInterval)
+
+	go func() {
+		ticker := time.NewTicker(RevokedTokenPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := revokedTokenRepo.PurgeExpired()
+			if err != nil {
+				logger.Error("failed to purge revoked tokens", "error", err)
+			} else if purged > 0 {
+				logger.Info("purged revoked tokens", "count", purged)
+			}
+			recordRun(revokedTokenPurgeJob, err)
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,30 @@
+package logging
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDKey is the gin context key holding the current request ID
+const RequestIDKey = "request_id"
+
+// FromContext returns a structured logger carrying the request ID, route and user ID (if authenticated)
+func FromContext(c *gin.Context) *slog.Logger {
+	logger := slog.Default().With(
+		"request_id", c.GetString(RequestIDKey),
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+	)
+
+	if userID, exists := c.Get("user_id"); exists {
+		logger = logger.With("user_id", userID)
+	}
+
+	return logger
+}
+
+// Component returns a structured logger for code that runs outside a request, such as email clients
+func Component(name string) *slog.Logger {
+	return slog.Default().With("component", name)
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware creates a middleware that only allows configured admin emails
+// Must run after AuthMiddleware, which sets the email in the context
+func AdminMiddleware(adminEmails []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, adminEmail := range adminEmails {
+		admins[strings.ToLower(adminEmail)] = true
+	}
+
+	return func(c *gin.Context) {
+		email := c.GetString("email")
+		if email == "" || !admins[strings.ToLower(email)] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -27,7 +27,7 @@ func AuthMiddleware(authHandler *handler.AuthHandler) gin.HandlerFunc {
 		}
 
 		token := parts[1]
-		userID, email, err := authHandler.VerifyJWT(token)
+		userID, email, err := authHandler.VerifyJWT(token, handler.DeviceFingerprint(c))
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
@@ -37,6 +37,45 @@ func AuthMiddleware(authHandler *handler.AuthHandler) gin.HandlerFunc {
 		// Store user information in context
 		c.Set("user_id", userID)
 		c.Set("email", email)
+		c.Set("token", token)
+
+		c.Next()
+	}
+}
+
+// WebSocketAuthMiddleware creates a middleware that verifies JWT tokens on WebSocket upgrade requests
+// Browsers can't set headers on WebSocket requests, so the token is read from the Sec-WebSocket-Protocol
+// header ("access_token, <token>") or the token query parameter, and the device ID from the device_id parameter
+func WebSocketAuthMiddleware(authHandler *handler.AuthHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		for _, protocols := range c.Request.Header.Values("Sec-WebSocket-Protocol") {
+			parts := strings.Split(protocols, ",")
+			if len(parts) == 2 && strings.TrimSpace(parts[0]) == handler.WebSocketTokenProtocol {
+				token = strings.TrimSpace(parts[1])
+			}
+		}
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Access token required"})
+			c.Abort()
+			return
+		}
+
+		if deviceID := c.Query("device_id"); deviceID != "" && c.GetHeader(handler.DeviceIDHeader) == "" {
+			c.Request.Header.Set(handler.DeviceIDHeader, deviceID)
+		}
+
+		userID, email, err := authHandler.VerifyJWT(token, handler.DeviceFingerprint(c))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		// Store user information in context
+		c.Set("user_id", userID)
+		c.Set("email", email)
+		c.Set("token", token)
 
 		c.Next()
 	}
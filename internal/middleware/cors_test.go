@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		wantOrigin     string
+	}{
+		{name: "any origin when unconfigured", origin: "https://anywhere.example", wantOrigin: "*"},
+		{name: "listed origin", allowedOrigins: []string{"https://app.example"}, origin: "https://app.example", wantOrigin: "https://app.example"},
+		{name: "unlisted origin", allowedOrigins: []string{"https://app.example"}, origin: "https://evil.example", wantOrigin: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(CORS(tt.allowedOrigins))
+			router.GET("/api", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				req := httptest.NewRequest(method, "/api", nil)
+				req.Header.Set("Origin", tt.origin)
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, req)
+
+				if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+					t.Errorf("%s Access-Control-Allow-Origin = %q, want %q", method, got, tt.wantOrigin)
+				}
+				if method == http.MethodOptions && recorder.Code != http.StatusNoContent {
+					t.Errorf("preflight status = %d, want %d", recorder.Code, http.StatusNoContent)
+				}
+			}
+		})
+	}
+}
@@ -5,14 +5,17 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/games-app/backend/internal/logging"
 )
 
 // Logger returns a middleware that logs HTTP requests
 func Logger() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		log.Printf(
-			"[%s] %s %s %s %d %s \"%s\" %s\n",
+			"[%s] %s %s %s %s %d %s \"%s\" %s\n",
 			param.TimeStamp.Format(time.RFC3339),
+			param.Keys[logging.RequestIDKey],
 			param.ClientIP,
 			param.Method,
 			param.Path,
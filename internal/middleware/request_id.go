@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/games-app/backend/internal/logging"
+)
+
+// RequestIDHeader is the header carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns a middleware that assigns each request an ID, reusing the caller's if provided
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(logging.RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceKeyHeader is the header other services send their API key in
+const ServiceKeyHeader = "X-Service-Key"
+
+// ServiceKeyMiddleware creates a middleware that only allows callers presenting the configured service API key
+// Rejects every request if no key is configured
+func ServiceKeyMiddleware(serviceAPIKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if serviceAPIKey == "" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Service access is not configured"})
+			c.Abort()
+			return
+		}
+
+		key := c.GetHeader(ServiceKeyHeader)
+		if key == "" || subtle.ConstantTimeCompare([]byte(key), []byte(serviceAPIKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid service key"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
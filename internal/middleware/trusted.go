@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/games-app/backend/internal/logging"
+)
+
+// ServicePrincipalKey is the gin context key set for requests authenticated as an internal service
+const ServicePrincipalKey = "service_principal"
+
+// TrustedServiceMiddleware creates a middleware that admits internal tooling without a user JWT
+// Requests must come from one of the trusted networks (by connecting address, not forwarded headers)
+// and present the service API key. Only read-only methods are allowed, and no user_id is set,
+// so user-scoped handlers still reject the request.
+func TrustedServiceMiddleware(trustedNetworks []string, serviceAPIKey string) gin.HandlerFunc {
+	var networks []*net.IPNet
+	for _, cidr := range trustedNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logging.Component("trusted_network").Warn("ignoring invalid trusted network", "cidr", cidr, "error", err)
+			continue
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *gin.Context) {
+		if len(networks) == 0 || serviceAPIKey == "" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Internal access is not configured"})
+			c.Abort()
+			return
+		}
+
+		ip := net.ParseIP(c.RemoteIP())
+		trusted := false
+		for _, network := range networks {
+			if ip != nil && network.Contains(ip) {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not a trusted network"})
+			c.Abort()
+			return
+		}
+
+		key := c.GetHeader(ServiceKeyHeader)
+		if key == "" || subtle.ConstantTimeCompare([]byte(key), []byte(serviceAPIKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid service key"})
+			c.Abort()
+			return
+		}
+
+		// Service principals can only read
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Service principals cannot modify data"})
+			c.Abort()
+			return
+		}
+
+		c.Set(ServicePrincipalKey, true)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTrustedServiceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		networks   []string
+		method     string
+		remoteAddr string
+		key        string
+		wantStatus int
+	}{
+		{name: "trusted read", networks: []string{"10.0.0.0/8"}, method: http.MethodGet, remoteAddr: "10.1.2.3:4000", key: "secret", wantStatus: http.StatusOK},
+		{name: "untrusted network", networks: []string{"10.0.0.0/8"}, method: http.MethodGet, remoteAddr: "192.168.1.2:4000", key: "secret", wantStatus: http.StatusForbidden},
+		{name: "wrong key", networks: []string{"10.0.0.0/8"}, method: http.MethodGet, remoteAddr: "10.1.2.3:4000", key: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "missing key", networks: []string{"10.0.0.0/8"}, method: http.MethodGet, remoteAddr: "10.1.2.3:4000", wantStatus: http.StatusUnauthorized},
+		{name: "write", networks: []string{"10.0.0.0/8"}, method: http.MethodPost, remoteAddr: "10.1.2.3:4000", key: "secret", wantStatus: http.StatusForbidden},
+		{name: "invalid networks are ignored", networks: []string{"not-a-cidr"}, method: http.MethodGet, remoteAddr: "10.1.2.3:4000", key: "secret", wantStatus: http.StatusServiceUnavailable},
+		{name: "not configured", method: http.MethodGet, remoteAddr: "10.1.2.3:4000", key: "secret", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(TrustedServiceMiddleware(tt.networks, "secret"))
+			router.Handle(tt.method, "/internal", func(c *gin.Context) {
+				if !c.GetBool(ServicePrincipalKey) {
+					t.Error("service principal not set")
+				}
+				if _, exists := c.Get("user_id"); exists {
+					t.Error("user_id set for a service principal")
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/internal", nil)
+			req.RemoteAddr = tt.remoteAddr
+			// Forwarded headers must not make an outside request look internal
+			req.Header.Set("X-Forwarded-For", "10.9.9.9")
+			if tt.key != "" {
+				req.Header.Set(ServiceKeyHeader, tt.key)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -7,16 +7,17 @@ import (
 )
 
 // New creates a new Gin router with middleware
-func New() *gin.Engine {
+func New(corsOrigins []string) *gin.Engine {
 	// Set Gin mode based on environment
 	gin.SetMode(gin.ReleaseMode)
 
 	r := gin.New()
 
 	// Apply global middleware
+	r.Use(middleware.RequestID())
 	r.Use(middleware.Logger())
 	r.Use(middleware.Recovery())
-	r.Use(middleware.CORS())
+	r.Use(middleware.CORS(corsOrigins))
 
 	return r
 }
@@ -26,11 +27,12 @@ func RegisterHealthRoutes(r *gin.Engine, healthHandler *handler.HealthHandler) {
 	v1 := r.Group("/api/v1")
 	{
 		v1.GET("/health-check", healthHandler.HealthCheck)
+		v1.GET("/version", healthHandler.Version)
 	}
 }
 
 // RegisterAuthRoutes registers authentication routes
-func RegisterAuthRoutes(r *gin.Engine, authHandler *handler.AuthHandler) {
+func RegisterAuthRoutes(r *gin.Engine, authHandler *handler.AuthHandler, serviceAPIKey string) {
 	v1 := r.Group("/api/v1")
 	{
 		auth := v1.Group("/auth")
@@ -38,12 +40,22 @@ func RegisterAuthRoutes(r *gin.Engine, authHandler *handler.AuthHandler) {
 			// Public routes
 			auth.POST("/request-otp", authHandler.RequestOtp)
 			auth.POST("/verify-otp", authHandler.VerifyOtp)
+			auth.POST("/invalidate-otps", authHandler.InvalidateOtps)
+			auth.POST("/refresh", authHandler.RefreshToken)
+
+			// Service routes
+			service := auth.Group("")
+			service.Use(middleware.ServiceKeyMiddleware(serviceAPIKey))
+			{
+				service.POST("/introspect", authHandler.Introspect)
+			}
 
 			// Protected routes
 			protected := auth.Group("")
 			protected.Use(middleware.AuthMiddleware(authHandler))
 			{
 				protected.GET("/me", authHandler.GetCurrentUser)
+				protected.POST("/logout", authHandler.Logout)
 			}
 		}
 		// User profile routes
@@ -51,6 +63,9 @@ func RegisterAuthRoutes(r *gin.Engine, authHandler *handler.AuthHandler) {
 		users.Use(middleware.AuthMiddleware(authHandler))
 		{
 			users.PUT("/me", authHandler.UpdateProfile)
+			users.PATCH("/me", authHandler.PatchProfile)
+			users.GET("/me/preferences", authHandler.GetPreferences)
+			users.PUT("/me/preferences", authHandler.UpdatePreferences)
 		}
 	}
 }
@@ -59,6 +74,9 @@ func RegisterAuthRoutes(r *gin.Engine, authHandler *handler.AuthHandler) {
 func RegisterPartnerRoutes(r *gin.Engine, partnerHandler *handler.PartnerHandler, authHandler *handler.AuthHandler) {
 	v1 := r.Group("/api/v1")
 	{
+		// Public accept link from partner request emails (the token authenticates the recipient)
+		v1.GET("/partners/accept-link", partnerHandler.AcceptPartnerLink)
+
 		partners := v1.Group("/partners")
 		partners.Use(middleware.AuthMiddleware(authHandler))
 		{
@@ -66,13 +84,29 @@ func RegisterPartnerRoutes(r *gin.Engine, partnerHandler *handler.PartnerHandler
 			partners.POST("/request", partnerHandler.SendPartnerRequest)
 			partners.GET("/requests/sent", partnerHandler.GetSentRequests)
 			partners.GET("/requests/received", partnerHandler.GetReceivedRequests)
+			partners.POST("/requests/batch", partnerHandler.BatchRespondToRequests)
 			partners.POST("/accept/:id", partnerHandler.AcceptPartnerRequest)
 			partners.POST("/reject/:id", partnerHandler.RejectPartnerRequest)
 			partners.DELETE("/request/:id", partnerHandler.CancelPartnerRequest)
+			partners.POST("/request/:id/resend", partnerHandler.ResendPartnerRequest)
 
 			// Current partner
 			partners.GET("/current", partnerHandler.GetCurrentPartner)
 			partners.DELETE("/current", partnerHandler.DisconnectPartner)
+			partners.GET("/current/metadata", partnerHandler.GetPartnershipMetadata)
+			partners.PUT("/current/metadata", partnerHandler.UpdatePartnershipMetadata)
+			partners.GET("/current/active-games", partnerHandler.GetActiveGames)
+			partners.POST("/current/coinflip", partnerHandler.FlipCoin)
+
+			// Partnership by ID
+			partners.GET("/:id", partnerHandler.GetPartnership)
+			partners.GET("/:id/timeline", partnerHandler.GetPartnershipTimeline)
+
+			// Default partner for game actions
+			partners.PUT("/:id/default", partnerHandler.SetDefaultPartner)
+
+			// Skip the game request step with partners who both opted in
+			partners.PUT("/:id/auto-accept", partnerHandler.SetAutoAccept)
 		}
 	}
 }
@@ -85,25 +119,141 @@ func RegisterGameRoutes(r *gin.Engine, gamesHandler *handler.GamesHandler, authH
 		{
 			// Public routes
 			games.GET("", gamesHandler.ListGames)
+			games.GET("/slug/:slug", gamesHandler.GetGameBySlug)
+			games.GET("/popular", gamesHandler.GetPopularGames)
+			games.GET("/:gameId/global-stats", gamesHandler.GetGameGlobalStats)
+			games.GET("/:gameId/leaderboard", gamesHandler.GetLeaderboard)
+			games.GET("/:gameId/example-secret", gamesHandler.GetExampleSecret)
 
 			// Protected routes
 			protected := games.Group("")
 			protected.Use(middleware.AuthMiddleware(authHandler))
 			{
+				// Games annotated with the user's live plays and pending requests
+				protected.GET("/with-status", gamesHandler.ListGamesWithStatus)
+
 				// Play game (checks for live play first, then creates request)
 				protected.POST("/play", gamesHandler.PlayGame)
+				protected.POST("/bot/play", gamesHandler.PlayBot)
+				protected.GET("/:gameId/play-preview", gamesHandler.PlayGamePreview)
 				// Game requests
 				protected.POST("/requests", gamesHandler.CreateGameRequest)
 				protected.GET("/requests/pending", gamesHandler.GetPendingGameRequests)
+				protected.GET("/requests/:id", gamesHandler.GetGameRequest)
 				protected.POST("/requests/:id/respond", gamesHandler.RespondToGameRequest)
 
 				// Plays
 				protected.GET("/:gameId/play", gamesHandler.GetLivePlay)
+				protected.GET("/:gameId/play/with/:opponentId", gamesHandler.GetLivePlayWithOpponent)
+				protected.GET("/plays", gamesHandler.GetPlayHistory)
+				protected.GET("/played", gamesHandler.GetPlayedGames)
+				protected.GET("/stats", gamesHandler.GetStats)
+				protected.GET("/my-turns", gamesHandler.GetMyTurns)
+				protected.GET("/resume", gamesHandler.GetResumablePlay)
 				protected.GET("/plays/:id", gamesHandler.GetPlayById)
 				protected.PUT("/plays/:id", gamesHandler.UpdatePlay)
+				protected.GET("/plays/:id/role", gamesHandler.GetPlayRole)
+				protected.GET("/plays/:id/history", gamesHandler.GetPlayMoveHistory)
+				protected.GET("/plays/:id/replay", gamesHandler.GetReplay)
+				protected.GET("/plays/:id/final", gamesHandler.GetPlayFinal)
+				protected.POST("/plays/import", gamesHandler.ImportReplay)
 				protected.POST("/plays/:id/set-secret", gamesHandler.SetSecret)
 				protected.POST("/plays/:id/guess", gamesHandler.MakeGuess)
+				protected.POST("/plays/:id/move", gamesHandler.MakeMove)
+				protected.POST("/plays/:id/rematch", gamesHandler.Rematch)
+				protected.POST("/plays/:id/forfeit", gamesHandler.ForfeitPlay)
+				protected.GET("/plays/:id/messages", gamesHandler.GetPlayMessages)
+				protected.POST("/plays/:id/messages", gamesHandler.SendPlayMessage)
+				protected.PUT("/plays/:id/messages/mute", gamesHandler.MutePlayChat)
+				protected.POST("/plays/:id/pause", gamesHandler.PausePlay)
+				protected.POST("/plays/:id/resume", gamesHandler.ResumePlay)
+				protected.POST("/plays/:id/email-summary", gamesHandler.EmailPlaySummary)
+			}
+
+			// Live play updates; browsers can't send the Authorization header on WebSocket requests
+			watch := games.Group("")
+			watch.Use(middleware.WebSocketAuthMiddleware(authHandler))
+			{
+				watch.GET("/plays/:id/ws", gamesHandler.WatchPlay)
 			}
 		}
+
+		// Game results on the user's profile
+		users := v1.Group("/users")
+		users.Use(middleware.AuthMiddleware(authHandler))
+		{
+			users.GET("/me/record", gamesHandler.GetRecord)
+		}
+	}
+}
+
+// RegisterNotificationRoutes registers notification routes
+func RegisterNotificationRoutes(r *gin.Engine, notificationHandler *handler.NotificationHandler, authHandler *handler.AuthHandler) {
+	v1 := r.Group("/api/v1")
+	{
+		notifications := v1.Group("/notifications")
+		notifications.Use(middleware.AuthMiddleware(authHandler))
+		{
+			notifications.GET("", notificationHandler.GetNotifications)
+			notifications.POST("/read-all", notificationHandler.MarkAllNotificationsRead)
+			notifications.POST("/:id/read", notificationHandler.MarkNotificationRead)
+		}
+	}
+}
+
+// RegisterInboxRoutes registers the unified inbox and attention badge routes
+func RegisterInboxRoutes(r *gin.Engine, inboxHandler *handler.InboxHandler, authHandler *handler.AuthHandler) {
+	v1 := r.Group("/api/v1")
+	{
+		inbox := v1.Group("/inbox")
+		inbox.Use(middleware.AuthMiddleware(authHandler))
+		{
+			inbox.GET("", inboxHandler.GetInbox)
+		}
+
+		attention := v1.Group("/attention")
+		attention.Use(middleware.AuthMiddleware(authHandler))
+		{
+			attention.GET("", inboxHandler.GetAttention)
+		}
+	}
+}
+
+// RegisterInternalRoutes registers read-only routes for internal tooling on trusted networks
+// These authenticate with the service API key instead of a user JWT
+func RegisterInternalRoutes(r *gin.Engine, healthHandler *handler.HealthHandler, adminHandler *handler.AdminHandler, gamesHandler *handler.GamesHandler, trustedNetworks []string, serviceAPIKey string) {
+	v1 := r.Group("/api/v1")
+	{
+		internal := v1.Group("/internal")
+		internal.Use(middleware.TrustedServiceMiddleware(trustedNetworks, serviceAPIKey))
+		{
+			internal.GET("/health", healthHandler.HealthCheck)
+			internal.GET("/email-provider", adminHandler.GetEmailProvider)
+			internal.GET("/jobs", adminHandler.GetJobs)
+
+			// Stats
+			internal.GET("/games/popular", gamesHandler.GetPopularGames)
+			internal.GET("/games/:gameId/global-stats", gamesHandler.GetGameGlobalStats)
+		}
+	}
+}
+
+// RegisterAdminRoutes registers admin-only routes
+func RegisterAdminRoutes(r *gin.Engine, adminHandler *handler.AdminHandler, authHandler *handler.AuthHandler, adminEmails []string) {
+	v1 := r.Group("/api/v1")
+	{
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(authHandler))
+		admin.Use(middleware.AdminMiddleware(adminEmails))
+		{
+			admin.GET("/email-provider", adminHandler.GetEmailProvider)
+			admin.POST("/email-provider", adminHandler.SetEmailProvider)
+			admin.GET("/config", adminHandler.GetConfig)
+			admin.GET("/jobs", adminHandler.GetJobs)
+			admin.POST("/plays/purge", adminHandler.PurgePlays)
+			admin.GET("/plays/suspicious", adminHandler.GetSuspiciousPlays)
+			admin.POST("/plays/:id/reset", adminHandler.ResetPlay)
+			admin.PUT("/users/:id/email", adminHandler.ChangeUserEmail)
+		}
 	}
 }
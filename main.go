@@ -3,16 +3,29 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/games-app/backend/internal/config"
 	"github.com/games-app/backend/internal/database"
+	"github.com/games-app/backend/internal/email"
 	"github.com/games-app/backend/internal/handler"
+	"github.com/games-app/backend/internal/jobs"
 	"github.com/games-app/backend/internal/router"
 )
 
+// Build information, injected at build time via:
+// go build -ldflags "-X main.BuildVersion=<version> -X main.BuildTime=<time>"
+var (
+	BuildVersion = "dev"
+	BuildTime    = "unknown"
+)
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Initialize database
 	if cfg.DatabaseURL != "" {
@@ -30,28 +43,58 @@ func main() {
 	}
 
 	// Initialize router
-	r := router.New()
+	r := router.New(cfg.CORSAllowedOrigins)
 
 	// Register handlers
-	healthHandler := handler.NewHealthHandler()
+	healthHandler := handler.NewHealthHandler(BuildVersion, BuildTime)
 	router.RegisterHealthRoutes(r, healthHandler)
 
 	// Register auth handlers if database is available
 	if cfg.DatabaseURL != "" {
-		authHandler, err := handler.NewAuthHandler(cfg)
+		// Initialize email client for the configured provider
+		emailClient, err := email.NewSwitchableClient(cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize email client: %v", err)
+			os.Exit(1)
+		}
+
+		authHandler, err := handler.NewAuthHandler(cfg, emailClient)
 		if err != nil {
 			log.Fatalf("Failed to initialize auth handler: %v", err)
 			os.Exit(1)
 		}
-		router.RegisterAuthRoutes(r, authHandler)
+		router.RegisterAuthRoutes(r, authHandler, cfg.ServiceAPIKey)
 
 		// Register partner handlers
-		partnerHandler := handler.NewPartnerHandler()
+		partnerHandler := handler.NewPartnerHandler(cfg, emailClient, authHandler)
 		router.RegisterPartnerRoutes(r, partnerHandler, authHandler)
 
 		// Register game handlers
-		gamesHandler := handler.NewGamesHandler()
+		gamesHandler := handler.NewGamesHandler(cfg, emailClient, partnerHandler)
 		router.RegisterGameRoutes(r, gamesHandler, authHandler)
+
+		// Register notification handlers
+		notificationHandler := handler.NewNotificationHandler(cfg)
+		router.RegisterNotificationRoutes(r, notificationHandler, authHandler)
+
+		// Register inbox handlers
+		inboxHandler := handler.NewInboxHandler(cfg)
+		router.RegisterInboxRoutes(r, inboxHandler, authHandler)
+
+		// Register admin handlers
+		adminHandler := handler.NewAdminHandler(cfg, emailClient, gamesHandler)
+		router.RegisterAdminRoutes(r, adminHandler, authHandler, cfg.AdminEmails)
+		router.RegisterInternalRoutes(r, healthHandler, adminHandler, gamesHandler, cfg.TrustedNetworks, cfg.ServiceAPIKey)
+
+		// Start background jobs
+		jobs.StartPlayRetention(database.NewPlayRepository(database.DB), cfg.PlayRetentionDays)
+		jobs.StartGameRequestSweep(
+			database.NewGameRequestRepository(database.DB),
+			database.NewNotificationRepository(database.DB, time.Duration(cfg.NotificationDedupWindowMinutes)*time.Minute),
+			cfg.GameRequestReminderMinutes,
+		)
+		jobs.StartRevokedTokenPurge(database.NewRevokedTokenRepository(database.DB))
+		jobs.StartRefreshTokenPurge(database.NewRefreshTokenRepository(database.DB))
 	}
 
 	// Start server
@@ -0,0 +1,20 @@
+-- Notifications table - in-app notifications for users
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL, -- game_request, partner_request
+    actor_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    game_id UUID REFERENCES games(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    read BOOLEAN NOT NULL DEFAULT false,
+    count INTEGER NOT NULL DEFAULT 1, -- number of events collapsed into this notification
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_type ON notifications(type);
+CREATE INDEX IF NOT EXISTS idx_notifications_read ON notifications(read);
+-- Supports the dedup lookup for recent unread notifications of the same subject
+CREATE INDEX IF NOT EXISTS idx_notifications_dedup ON notifications(user_id, type, actor_id, game_id, read, updated_at);
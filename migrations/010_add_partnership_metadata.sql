@@ -0,0 +1,4 @@
+-- Add shared metadata to partnerships
+ALTER TABLE partnerships ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+-- Version used for optimistic concurrency when both partners update metadata
+ALTER TABLE partnerships ADD COLUMN IF NOT EXISTS metadata_version INTEGER NOT NULL DEFAULT 0;
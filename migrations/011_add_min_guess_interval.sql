@@ -0,0 +1,6 @@
+-- Bulls and Cows has no cooldown between guesses by default
+-- Set details.min_guess_interval_seconds to a positive value to enforce one
+UPDATE games
+SET details = details || '{"min_guess_interval_seconds": 0}'::jsonb
+WHERE id = '550e8400-e29b-41d4-a716-446655440001'
+  AND NOT details ? 'min_guess_interval_seconds';
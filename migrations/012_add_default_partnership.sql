@@ -0,0 +1,3 @@
+-- Add default partnership preference to users
+-- Game actions that don't name a partner use this partnership
+ALTER TABLE users ADD COLUMN IF NOT EXISTS default_partnership_id UUID REFERENCES partnerships(id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+-- Soft delete for plays purged by the retention policy
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_plays_deleted_at ON plays(deleted_at);
+
+-- Counters preserving per-user, per-game, per-opponent totals for purged completed plays
+CREATE TABLE IF NOT EXISTS play_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    opponent_id UUID NOT NULL,
+    plays INTEGER NOT NULL DEFAULT 0,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, game_id, opponent_id)
+);
@@ -0,0 +1,3 @@
+-- Add single-use accept link token to partner requests
+-- Cleared once the link is used so each emailed link works only once
+ALTER TABLE partner_requests ADD COLUMN IF NOT EXISTS accept_token_id UUID;
@@ -0,0 +1,3 @@
+-- Numeric score computed by the game's scoring rules when a play completes
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS score INTEGER;
+CREATE INDEX IF NOT EXISTS idx_plays_game_score ON plays(game_id, score DESC) WHERE score IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Token letting non-participants view a completed play's replay
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS share_token VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_plays_share_token ON plays(share_token) WHERE share_token IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Practice plays are imported from replays and excluded from stats and leaderboards
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS is_practice BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- Tracks whether the expiry reminder has been sent for a game request
+ALTER TABLE game_requests ADD COLUMN IF NOT EXISTS reminder_sent BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_game_requests_reminder ON game_requests(expires_at) WHERE status = 'pending' AND reminder_sent = FALSE;
@@ -0,0 +1,10 @@
+-- Denormalized current turn so a user's pending turns can be queried without scanning play data
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS current_turn_user_id UUID;
+CREATE INDEX IF NOT EXISTS idx_plays_current_turn_user_id ON plays(current_turn_user_id);
+
+-- Backfill live plays that are waiting on a player
+UPDATE plays
+SET current_turn_user_id = (play_data->>'current_turn')::uuid
+WHERE is_live = TRUE
+  AND play_data->>'status' = 'playing'
+  AND play_data->>'current_turn' ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
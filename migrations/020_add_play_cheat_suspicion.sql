@@ -0,0 +1,3 @@
+-- Heuristic cheat suspicion score (0-100) of a play's winner, reviewed by admins
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS cheat_suspicion INTEGER;
+CREATE INDEX IF NOT EXISTS idx_plays_cheat_suspicion ON plays(cheat_suspicion) WHERE cheat_suspicion IS NOT NULL;
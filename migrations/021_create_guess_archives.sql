@@ -0,0 +1,10 @@
+-- Guesses moved out of long plays' play_data, in play order
+CREATE TABLE IF NOT EXISTS guess_archives (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    play_id UUID NOT NULL REFERENCES plays(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    guess JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guess_archives_play_seq ON guess_archives(play_id, seq);
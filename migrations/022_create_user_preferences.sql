@@ -0,0 +1,9 @@
+-- Per-user notification settings; users without a row get every email
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_partner_requests BOOLEAN NOT NULL DEFAULT true,
+    email_game_requests BOOLEAN NOT NULL DEFAULT true,
+    email_reminders BOOLEAN NOT NULL DEFAULT true,
+    email_summaries BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
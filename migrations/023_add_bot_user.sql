@@ -0,0 +1,6 @@
+-- Bot opponent every user can practice against; plays against it are practice plays
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_bot BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO users (id, email, name, display_name, email_verified, is_bot)
+VALUES ('b0700000-0000-4000-8000-000000000001', 'bot@games.invalid', 'Bot', 'Bot', TRUE, TRUE)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,19 @@
+-- Standardized result {outcome, winner_id, reason} for plays that ended before it was recorded
+-- Best effort: won plays are marked solved, other ended plays abandoned with no specific reason
+UPDATE plays
+SET play_data = play_data || jsonb_build_object('result', jsonb_build_object(
+    'outcome', 'win',
+    'winner_id', play_data->>'winner_id',
+    'reason', 'solved'))
+WHERE is_live = FALSE
+  AND NOT play_data ? 'result'
+  AND play_data->>'status' = 'completed'
+  AND play_data->>'winner_id' IS NOT NULL;
+
+UPDATE plays
+SET play_data = play_data || jsonb_build_object('result', jsonb_build_object(
+    'outcome', 'abandoned',
+    'winner_id', NULL,
+    'reason', 'ended'))
+WHERE is_live = FALSE
+  AND NOT play_data ? 'result';
@@ -0,0 +1,11 @@
+-- Human-readable game identifiers for deep links, usable wherever a game ID is accepted
+ALTER TABLE games ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+
+UPDATE games SET slug = 'bulls-and-cows'
+WHERE id = '550e8400-e29b-41d4-a716-446655440001' AND slug IS NULL;
+
+-- Any other game gets its lowercased name with runs of other characters collapsed to hyphens
+UPDATE games SET slug = trim(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'))
+WHERE slug IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_slug ON games(slug);
@@ -0,0 +1,2 @@
+-- When a partner request email was last resent, for the resend cooldown
+ALTER TABLE partner_requests ADD COLUMN IF NOT EXISTS emailed_at TIMESTAMP WITH TIME ZONE;
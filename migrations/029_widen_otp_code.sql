@@ -0,0 +1,3 @@
+-- OTP length is configurable (OTP_LENGTH, 4 to 10 digits), so the code column fits the longest codes
+-- Existing 4-digit codes are unaffected
+ALTER TABLE otps ALTER COLUMN code TYPE VARCHAR(10);
@@ -0,0 +1,4 @@
+-- Tic-Tac-Toe, the second game type; partner 1 plays X and moves first
+INSERT INTO games (id, name, slug, description, icon, details) VALUES
+    ('550e8400-e29b-41d4-a716-446655440002', 'Tic-Tac-Toe', 'tic-tac-toe', 'Take turns marking a 3x3 grid and get three in a row', '⭕', '{"type": "tic_tac_toe"}'::jsonb)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,13 @@
+-- Recorded coin flips settling decisions between partners
+CREATE TABLE IF NOT EXISTS coin_flips (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    partnership_id UUID NOT NULL REFERENCES partnerships(id) ON DELETE CASCADE,
+    flipped_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    seed VARCHAR(64) NOT NULL,
+    outcome VARCHAR(10) NOT NULL,
+    winner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_coin_flips_partnership_id ON coin_flips(partnership_id);
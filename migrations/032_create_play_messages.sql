@@ -0,0 +1,11 @@
+-- Chat messages between the partners of a play
+CREATE TABLE IF NOT EXISTS play_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    play_id UUID NOT NULL REFERENCES plays(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_play_messages_play_id ON play_messages(play_id);
+CREATE INDEX IF NOT EXISTS idx_play_messages_created_at ON play_messages(created_at);
@@ -0,0 +1,7 @@
+-- Plays whose chat messages a participant muted notifications for
+CREATE TABLE IF NOT EXISTS play_chat_mutes (
+    play_id UUID NOT NULL REFERENCES plays(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (play_id, user_id)
+);
@@ -0,0 +1,2 @@
+-- Optimistic concurrency: incremented on every play update, which only applies if the version is unchanged
+ALTER TABLE plays ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Remember the game a partner invite was sent from, so accepting it also requests that game
+ALTER TABLE partner_requests ADD COLUMN IF NOT EXISTS game_id UUID REFERENCES games(id) ON DELETE SET NULL;